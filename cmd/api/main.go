@@ -9,12 +9,15 @@ import (
 	"strconv"
 	"syscall"
 	"time"
+	"unorcitconnect/internal/database"
+	"unorcitconnect/internal/geocode"
+	"unorcitconnect/internal/scheduler"
 	"unorcitconnect/internal/server"
 
 	"github.com/joho/godotenv"
 )
 
-func gracefulShutdown(fiberServer *server.FiberServer, done chan bool) {
+func gracefulShutdown(fiberServer *server.FiberServer, jobs *scheduler.Scheduler, done chan bool) {
 	// Create context that listens for the interrupt signal from the OS.
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
@@ -33,6 +36,10 @@ func gracefulShutdown(fiberServer *server.FiberServer, done chan bool) {
 		log.Printf("Server forced to shutdown with error: %v", err)
 	}
 
+	if err := jobs.Stop(ctx); err != nil {
+		log.Printf("Scheduler forced to stop with error: %v", err)
+	}
+
 	log.Println("Server exiting")
 
 	// Notify the main goroutine that the shutdown is complete
@@ -52,6 +59,18 @@ func main() {
 
 	server.RegisterFiberRoutes()
 
+	// Start background maintenance jobs
+	db := database.New()
+	geocoder, err := geocode.NewFromEnv()
+	if err != nil {
+		log.Fatalf("failed to configure geocoding: %v", err)
+	}
+	jobs := scheduler.New(db)
+	for _, job := range scheduler.MaintenanceJobs(db, geocoder) {
+		jobs.Register(job)
+	}
+	jobs.Start()
+
 	// Create a done channel to signal when the shutdown is complete
 	done := make(chan bool, 1)
 
@@ -68,7 +87,7 @@ func main() {
 	}()
 
 	// Run graceful shutdown in a separate goroutine
-	go gracefulShutdown(server, done)
+	go gracefulShutdown(server, jobs, done)
 
 	// Wait for the graceful shutdown to complete
 	<-done
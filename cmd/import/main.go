@@ -0,0 +1,61 @@
+// Command import loads a legacy alumni CSV into the database.
+//
+//	go run ./cmd/import -file alumni.csv [-dry-run]
+//
+// Every row is validated first; nothing is written unless all rows pass.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"unorcitconnect/internal/database"
+	"unorcitconnect/internal/importer"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	path := flag.String("file", "", "path to the alumni CSV file")
+	dryRun := flag.Bool("dry-run", false, "validate the file without importing it")
+	flag.Parse()
+
+	if *path == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := godotenv.Load(".env"); err != nil {
+		log.Println("No .env file found or failed to load")
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		log.Fatalf("failed to open %s: %v", *path, err)
+	}
+	defer file.Close()
+
+	db := database.New()
+	defer db.Close()
+
+	report, err := importer.ImportAlumniCSV(context.Background(), db, file, *dryRun)
+	if err != nil {
+		log.Fatalf("import failed: %v", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(report)
+
+	switch {
+	case !report.OK():
+		log.Printf("%d invalid and %d duplicate rows; nothing was imported", report.Invalid, report.Duplicates)
+		os.Exit(1)
+	case *dryRun:
+		log.Printf("dry run: %d rows are valid", report.Valid)
+	default:
+		log.Printf("imported %d alumni", report.Imported)
+	}
+}
@@ -35,6 +35,8 @@ import SponsorshipSection from './components/SponsorshipSection'
 import SponsorshipModal from './components/SponsorshipModalMD'
 import OutstandingAlumniAwards from './components/OutstandingAlumniAwards'
 import AlumniSuccessStories from './components/AlumniSuccessStories'
+import AlumniPortal from './components/AlumniPortalMD'
+import EmailOptOutDialog from './components/EmailOptOutDialogMD'
 
 // Create Material Design theme
 const theme = createTheme({
@@ -142,6 +144,12 @@ function AppMD() {
   const [showOutstandingAlumni, setShowOutstandingAlumni] = useState(false)
   const [showAlumniSuccessStories, setShowAlumniSuccessStories] = useState(false)
   const [mapRefreshTrigger, setMapRefreshTrigger] = useState(0)
+  const [showAlumniPortal, setShowAlumniPortal] = useState(false)
+  const [loginLink, setLoginLink] = useState(() => {
+    const params = new URLSearchParams(window.location.search)
+    return { email: params.get('email') || '', token: params.get('login_token') || '' }
+  })
+  const [optOutToken, setOptOutToken] = useState(() => new URLSearchParams(window.location.search).get('opt_out_token') || '')
 
   // Check if admin is already logged in
   useEffect(() => {
@@ -149,6 +157,13 @@ function AppMD() {
     setIsAdminLoggedIn(adminLoggedIn)
   }, [])
 
+  // Open the profile portal when arriving from an emailed sign-in link
+  useEffect(() => {
+    if (loginLink.token) {
+      setShowAlumniPortal(true)
+    }
+  }, [loginLink])
+
   const handleJoinCelebration = () => {
     setShowRegistration(true)
   }
@@ -184,6 +199,8 @@ function AppMD() {
   const handleAdminLogout = () => {
     localStorage.removeItem('admin_logged_in')
     localStorage.removeItem('admin_username')
+    localStorage.removeItem('admin_token')
+    localStorage.removeItem('admin_refresh_token')
     setIsAdminLoggedIn(false)
   }
 
@@ -372,6 +389,15 @@ function AppMD() {
                   >
                     Alumni Success Stories
                   </Link>
+                  <Link 
+                    component="button"
+                    onClick={() => setShowAlumniPortal(true)}
+                    color="primary.light" 
+                    underline="hover"
+                    sx={{ textAlign: 'left' }}
+                  >
+                    My Alumni Profile
+                  </Link>
                   <Link href="#" color="primary.light" underline="hover">Contact</Link>
                   <Link 
                     component="button"
@@ -435,6 +461,25 @@ function AppMD() {
             onClose={() => setShowSponsorshipModal(false)}
           />
         )}
+        {showAlumniPortal && (
+          <AlumniPortal
+            open={showAlumniPortal}
+            onClose={() => {
+              setShowAlumniPortal(false)
+              // The link can only be used once
+              setLoginLink({ email: '', token: '' })
+            }}
+            loginEmail={loginLink.email}
+            loginToken={loginLink.token}
+          />
+        )}
+        {optOutToken && (
+          <EmailOptOutDialog
+            open={!!optOutToken}
+            token={optOutToken}
+            onClose={() => setOptOutToken('')}
+          />
+        )}
         {showAdminLogin && (
           <AdminLoginModal 
             open={showAdminLogin}
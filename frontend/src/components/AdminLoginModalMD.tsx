@@ -1,4 +1,4 @@
-import { useState } from 'react'
+import { useState, useEffect } from 'react'
 import {
   Dialog,
   DialogContent,
@@ -18,7 +18,9 @@ import {
   Lock as LockIcon,
   Visibility,
   VisibilityOff,
+  Key as KeyIcon,
 } from '@mui/icons-material'
+import AdminPasswordDialogMD from './AdminPasswordDialogMD'
 
 interface AdminLoginModalProps {
   open: boolean
@@ -32,6 +34,52 @@ const AdminLoginModalMD = ({ open, onClose, onLoginSuccess }: AdminLoginModalPro
   const [loading, setLoading] = useState(false)
   const [error, setError] = useState('')
   const [showPassword, setShowPassword] = useState(false)
+  // First-run setup, offered while no admin account exists
+  const [setupRequired, setSetupRequired] = useState(false)
+  const [setupToken, setSetupToken] = useState('')
+  const [confirmPassword, setConfirmPassword] = useState('')
+  // The password just used to log in, while the admin must change it
+  const [pendingPassword, setPendingPassword] = useState<string | null>(null)
+
+  useEffect(() => {
+    if (!open) return
+    fetch('/api/admin/setup')
+      .then((response) => response.json())
+      .then((data) => setSetupRequired(!!data.setup_required))
+      .catch(() => setSetupRequired(false))
+  }, [open])
+
+  const login = async () => {
+    const response = await fetch('/api/admin/login', {
+      method: 'POST',
+      headers: {
+        'Content-Type': 'application/json',
+      },
+      body: JSON.stringify({
+        username,
+        password
+      }),
+    })
+
+    const data = await response.json()
+
+    if (response.ok) {
+      // Store admin session (in a real app, you'd use proper session management)
+      localStorage.setItem('admin_logged_in', 'true')
+      localStorage.setItem('admin_username', data.admin.username)
+      localStorage.setItem('admin_is_superuser', data.admin.is_superuser.toString())
+      localStorage.setItem('admin_token', data.session.access_token)
+      localStorage.setItem('admin_refresh_token', data.session.refresh_token)
+      if (data.admin.must_change_password) {
+        setPendingPassword(password)
+        return
+      }
+      onLoginSuccess()
+      onClose()
+    } else {
+      setError(data.error || 'Login failed')
+    }
+  }
 
   const handleLogin = async (e: React.FormEvent) => {
     e.preventDefault()
@@ -45,28 +93,48 @@ const AdminLoginModalMD = ({ open, onClose, onLoginSuccess }: AdminLoginModalPro
     setError('')
 
     try {
-      const response = await fetch('/api/admin/login', {
+      await login()
+    } catch {
+      setError('Network error. Please try again.')
+    } finally {
+      setLoading(false)
+    }
+  }
+
+  const handleSetup = async (e: React.FormEvent) => {
+    e.preventDefault()
+
+    if (password !== confirmPassword) {
+      setError('The passwords do not match')
+      return
+    }
+
+    setLoading(true)
+    setError('')
+
+    try {
+      const response = await fetch('/api/admin/setup', {
         method: 'POST',
         headers: {
           'Content-Type': 'application/json',
         },
         body: JSON.stringify({
+          setup_token: setupToken,
           username,
           password
         }),
       })
-
-      const data = await response.json()
+      const data = await response.json().catch(() => ({}))
 
       if (response.ok) {
-        // Store admin session (in a real app, you'd use proper session management)
-        localStorage.setItem('admin_logged_in', 'true')
-        localStorage.setItem('admin_username', data.admin.username)
-        localStorage.setItem('admin_is_superuser', data.admin.is_superuser.toString())
-        onLoginSuccess()
-        onClose()
+        setSetupRequired(false)
+        await login()
+      } else if (response.status === 409) {
+        // Someone else finished setup first; log in normally
+        setSetupRequired(false)
+        setError(data.error || 'Setup has already been completed')
       } else {
-        setError(data.error || 'Login failed')
+        setError(data.error || 'Setup failed')
       }
     } catch {
       setError('Network error. Please try again.')
@@ -75,6 +143,33 @@ const AdminLoginModalMD = ({ open, onClose, onLoginSuccess }: AdminLoginModalPro
     }
   }
 
+  // Abandoning the required password change signs the admin out again
+  const cancelPasswordChange = () => {
+    localStorage.removeItem('admin_logged_in')
+    localStorage.removeItem('admin_username')
+    localStorage.removeItem('admin_is_superuser')
+    localStorage.removeItem('admin_token')
+    localStorage.removeItem('admin_refresh_token')
+    setPendingPassword(null)
+    setPassword('')
+  }
+
+  if (pendingPassword !== null) {
+    return (
+      <AdminPasswordDialogMD
+        open={open}
+        required
+        currentPassword={pendingPassword}
+        onClose={cancelPasswordChange}
+        onChanged={() => {
+          setPendingPassword(null)
+          onLoginSuccess()
+          onClose()
+        }}
+      />
+    )
+  }
+
   return (
     <Dialog 
       open={open} 
@@ -101,7 +196,7 @@ const AdminLoginModalMD = ({ open, onClose, onLoginSuccess }: AdminLoginModalPro
           <Box sx={{ display: 'flex', alignItems: 'center', gap: 1 }}>
             <AdminIcon sx={{ fontSize: 28 }} />
             <Typography variant="h5" component="div" sx={{ fontWeight: 'bold' }}>
-              Admin Login
+              {setupRequired ? 'First-Run Setup' : 'Admin Login'}
             </Typography>
           </Box>
           <IconButton onClick={onClose} size="small" sx={{ color: 'white' }}>
@@ -109,7 +204,7 @@ const AdminLoginModalMD = ({ open, onClose, onLoginSuccess }: AdminLoginModalPro
           </IconButton>
         </Box>
         <Typography variant="body2" sx={{ color: 'rgba(255, 255, 255, 0.8)', mt: 1 }}>
-          Access the administration dashboard
+          {setupRequired ? 'Create the first superuser account' : 'Access the administration dashboard'}
         </Typography>
       </Box>
 
@@ -123,14 +218,36 @@ const AdminLoginModalMD = ({ open, onClose, onLoginSuccess }: AdminLoginModalPro
         <Box sx={{ textAlign: 'center', mb: 3 }}>
           <AdminIcon sx={{ fontSize: 64, color: 'text.secondary', mb: 2 }} />
           <Typography variant="h6" gutterBottom>
-            Administrator Access
+            {setupRequired ? 'No Administrators Yet' : 'Administrator Access'}
           </Typography>
           <Typography variant="body2" color="text.secondary">
-            Please enter your credentials to access the admin dashboard
+            {setupRequired
+              ? 'Enter the one-time setup token printed in the server logs, or set as ADMIN_SETUP_TOKEN, and choose the superuser\'s credentials'
+              : 'Please enter your credentials to access the admin dashboard'}
           </Typography>
         </Box>
 
-        <Box component="form" onSubmit={handleLogin} sx={{ display: 'flex', flexDirection: 'column', gap: 3 }}>
+        <Box component="form" onSubmit={setupRequired ? handleSetup : handleLogin} sx={{ display: 'flex', flexDirection: 'column', gap: 3 }}>
+          {setupRequired && (
+            <TextField
+              fullWidth
+              label="Setup Token"
+              value={setupToken}
+              onChange={(e) => setSetupToken(e.target.value)}
+              variant="outlined"
+              required
+              autoComplete="off"
+              InputProps={{
+                startAdornment: (
+                  <InputAdornment position="start">
+                    <KeyIcon color="action" />
+                  </InputAdornment>
+                ),
+              }}
+              placeholder="Enter the setup token"
+            />
+          )}
+
           <TextField
             fullWidth
             label="Username"
@@ -177,11 +294,31 @@ const AdminLoginModalMD = ({ open, onClose, onLoginSuccess }: AdminLoginModalPro
             placeholder="Enter admin password"
           />
 
+          {setupRequired && (
+            <TextField
+              fullWidth
+              label="Confirm Password"
+              type={showPassword ? 'text' : 'password'}
+              value={confirmPassword}
+              onChange={(e) => setConfirmPassword(e.target.value)}
+              variant="outlined"
+              required
+              helperText="At least 8 characters"
+              InputProps={{
+                startAdornment: (
+                  <InputAdornment position="start">
+                    <LockIcon color="action" />
+                  </InputAdornment>
+                ),
+              }}
+            />
+          )}
+
           <Button
             type="submit"
             variant="contained"
             size="large"
-            disabled={loading || !username || !password}
+            disabled={loading || !username || !password || (setupRequired && (!setupToken || !confirmPassword))}
             startIcon={loading ? <CircularProgress size={20} /> : <AdminIcon />}
             sx={{ 
               backgroundColor: '#374151',
@@ -191,7 +328,7 @@ const AdminLoginModalMD = ({ open, onClose, onLoginSuccess }: AdminLoginModalPro
               mt: 2
             }}
           >
-            {loading ? 'Logging in...' : 'Login to Dashboard'}
+            {loading ? (setupRequired ? 'Creating account...' : 'Logging in...') : (setupRequired ? 'Create Superuser' : 'Login to Dashboard')}
           </Button>
         </Box>
 
@@ -1,5 +1,7 @@
 import { useState, useEffect } from 'react'
 import * as XLSX from 'xlsx'
+import CampaignJudgingDialogMD from './CampaignJudgingDialogMD'
+import AdminPasswordDialogMD from './AdminPasswordDialogMD'
 import {
   Box,
   Typography,
@@ -30,6 +32,7 @@ import {
   Container,
   InputAdornment,
   Tooltip,
+  MenuItem,
   GridLegacy as Grid,
 } from '@mui/material'
 
@@ -48,6 +51,14 @@ import {
   Payment as PaymentIcon,
   LocationOn as LocationIcon,
   Delete as DeleteIcon,
+  CheckCircle as ApproveIcon,
+  HighlightOff as RejectIcon,
+  Event as EventIcon,
+  Add as AddIcon,
+  MergeType as MergeIcon,
+  Link as LinkIcon,
+  Gavel as GavelIcon,
+  LockReset as LockResetIcon,
 } from '@mui/icons-material'
 
 
@@ -64,14 +75,16 @@ interface Alumni {
   Country: string
   City: string
   Paid: boolean
+  PaymentStatus?: 'none' | 'submitted' | 'under_review' | 'approved' | 'rejected'
+  PaymentRejectionReason?: string
   PaymentProof?: string
-  PaymentProofData?: number[]
   PaymentProofType?: string
   PaymentProofSize?: number
 }
 
 interface Nomination {
   ID: number
+  AlumniID?: number | null
   FirstName: string
   LastName: string
   NominatedEmail: string
@@ -96,6 +109,37 @@ interface Sponsorship {
   CreatedAt: string
 }
 
+interface CampaignCategory {
+  Name: string
+  Description: string
+  MinYearsSinceGraduation: number
+  MaxYearsSinceGraduation: number
+  RequireNomineeEmail: boolean
+}
+
+interface NominationCampaign {
+  ID: number
+  Name: string
+  OpensAt: string
+  ClosesAt: string
+  Categories: CampaignCategory[]
+}
+
+interface Nominee {
+  AlumniID?: number | null
+  FirstName: string
+  LastName: string
+  Year: number
+  Category: string
+  NominationIDs: number[]
+}
+
+interface NomineeMergeSuggestion {
+  Target: Nominee
+  Duplicate: Nominee
+  Score: number
+}
+
 interface AdminPageProps {
   onLogout: () => void
 }
@@ -112,9 +156,13 @@ const AdminPageMD = ({ onLogout }: AdminPageProps) => {
   const [sponsorshipsSearchTerm, setSponsorshipsSearchTerm] = useState('')
   const [deleteConfirmOpen, setDeleteConfirmOpen] = useState(false)
   const [deleteTarget, setDeleteTarget] = useState<{ type: 'alumni' | 'nomination' | 'sponsorship', id: number, name: string } | null>(null)
+  const [duplicatesOpen, setDuplicatesOpen] = useState(false)
+  // 'required' while the server refuses everything but a password change
+  const [passwordDialog, setPasswordDialog] = useState<'closed' | 'optional' | 'required'>('closed')
 
   // Check if current user is superuser
   const isSuperuser = localStorage.getItem('admin_is_superuser') === 'true'
+  const authHeaders = { Authorization: `Bearer ${localStorage.getItem('admin_token') || ''}` }
 
   useEffect(() => {
     fetchDashboardData()
@@ -124,12 +172,17 @@ const AdminPageMD = ({ onLogout }: AdminPageProps) => {
   const fetchDashboardData = async () => {
     setLoading(true)
     try {
-      const response = await fetch('/api/admin/dashboard')
+      const response = await fetch('/api/admin/dashboard', { headers: authHeaders })
       if (response.ok) {
         const data = await response.json()
         setAlumni(data.alumni || [])
         setNominations(data.nominations || [])
         setStats(data.stats || { total_alumni: 0, total_nominations: 0 })
+      } else if (response.status === 403) {
+        const data = await response.json().catch(() => ({}))
+        if (data.must_change_password) {
+          setPasswordDialog('required')
+        }
       }
     } catch (err) {
       console.error('Failed to fetch dashboard data:', err)
@@ -156,6 +209,7 @@ const AdminPageMD = ({ onLogout }: AdminPageProps) => {
         method: 'PUT',
         headers: {
           'Content-Type': 'application/json',
+          ...authHeaders,
         },
         body: JSON.stringify({ confirmed, feedback }),
       })
@@ -170,6 +224,51 @@ const AdminPageMD = ({ onLogout }: AdminPageProps) => {
     }
   }
 
+  // Payment proofs need the admin token, so they can't be plain links
+  const downloadPaymentProof = async (id: number) => {
+    try {
+      const response = await fetch(`/api/admin/alumni/${id}/payment-proof`, { headers: authHeaders })
+      if (response.ok) {
+        const url = URL.createObjectURL(await response.blob())
+        window.open(url, '_blank', 'noopener,noreferrer')
+        setTimeout(() => URL.revokeObjectURL(url), 60000)
+      } else {
+        const data = await response.json().catch(() => ({}))
+        alert(data.error || 'Failed to download payment proof')
+      }
+    } catch (err) {
+      console.error('Failed to download payment proof:', err)
+    }
+  }
+
+  const reviewPayment = async (id: number, action: 'approve' | 'reject') => {
+    let reason = ''
+    if (action === 'reject') {
+      reason = window.prompt('Reason for rejecting this payment proof (sent to the alumnus):') || ''
+      if (!reason.trim()) return
+    }
+
+    try {
+      const response = await fetch(`/api/admin/alumni/${id}/payment/${action}`, {
+        method: 'POST',
+        headers: {
+          'Content-Type': 'application/json',
+          ...authHeaders,
+        },
+        body: JSON.stringify({ reason }),
+      })
+
+      if (response.ok) {
+        fetchDashboardData()
+      } else {
+        const data = await response.json().catch(() => ({}))
+        alert(data.error || 'Failed to review payment')
+      }
+    } catch (err) {
+      console.error('Failed to review payment:', err)
+    }
+  }
+
   const formatDate = (dateString: string) => {
     return new Date(dateString).toLocaleDateString('en-US', {
       year: 'numeric',
@@ -301,6 +400,7 @@ const AdminPageMD = ({ onLogout }: AdminPageProps) => {
     try {
       const response = await fetch(`/api/${deleteTarget.type === 'alumni' ? 'alumni' : deleteTarget.type === 'nomination' ? 'nominations' : 'sponsorships'}/${deleteTarget.id}`, {
         method: 'DELETE',
+        headers: authHeaders,
       })
 
       if (response.ok) {
@@ -341,6 +441,18 @@ const AdminPageMD = ({ onLogout }: AdminPageProps) => {
               UNOR CIT Connect Administration
             </Typography>
           </Box>
+          <Button
+            color="inherit"
+            onClick={() => setPasswordDialog('optional')}
+            startIcon={<LockResetIcon />}
+            sx={{ 
+              mr: 1,
+              backgroundColor: 'rgba(255, 255, 255, 0.1)',
+              '&:hover': { backgroundColor: 'rgba(255, 255, 255, 0.2)' }
+            }}
+          >
+            Password
+          </Button>
           <Button
             color="inherit"
             onClick={onLogout}
@@ -478,6 +590,16 @@ const AdminPageMD = ({ onLogout }: AdminPageProps) => {
                 icon={<BusinessIcon />}
                 iconPosition="start"
               />
+              <Tab 
+                label="Campaigns"
+                icon={<EventIcon />}
+                iconPosition="start"
+              />
+              <Tab 
+                label="Judging"
+                icon={<GavelIcon />}
+                iconPosition="start"
+              />
             </Tabs>
           </Box>
 
@@ -498,6 +620,8 @@ const AdminPageMD = ({ onLogout }: AdminPageProps) => {
                     totalCount={alumni.length}
                     isSuperuser={isSuperuser}
                     onDelete={handleDeleteClick}
+                    onReviewPayment={reviewPayment}
+                    onDownloadProof={downloadPaymentProof}
                   />
                 )}
 
@@ -512,6 +636,7 @@ const AdminPageMD = ({ onLogout }: AdminPageProps) => {
                     formatDate={formatDate}
                     isSuperuser={isSuperuser}
                     onDelete={handleDeleteClick}
+                    onFindDuplicates={() => setDuplicatesOpen(true)}
                   />
                 )}
 
@@ -529,12 +654,43 @@ const AdminPageMD = ({ onLogout }: AdminPageProps) => {
                     onDelete={handleDeleteClick}
                   />
                 )}
+
+                {/* Campaigns Tab */}
+                {activeTab === 3 && (
+                  <CampaignsTab authHeaders={authHeaders} formatDate={formatDate} />
+                )}
+
+                {/* Judging Tab */}
+                {activeTab === 4 && (
+                  <JudgingTab authHeaders={authHeaders} />
+                )}
               </>
             )}
           </Box>
         </Card>
       </Container>
 
+      <DuplicateNomineesDialog
+        open={duplicatesOpen}
+        onClose={() => setDuplicatesOpen(false)}
+        onMerged={fetchDashboardData}
+        authHeaders={authHeaders}
+      />
+
+      <AdminPasswordDialogMD
+        open={passwordDialog !== 'closed'}
+        required={passwordDialog === 'required'}
+        onClose={passwordDialog === 'required' ? onLogout : () => setPasswordDialog('closed')}
+        onChanged={() => {
+          const wasRequired = passwordDialog === 'required'
+          setPasswordDialog('closed')
+          if (wasRequired) {
+            fetchDashboardData()
+            fetchSponsorships()
+          }
+        }}
+      />
+
       {/* Delete Confirmation Dialog */}
       <Dialog
         open={deleteConfirmOpen}
@@ -603,7 +759,15 @@ const AdminPageMD = ({ onLogout }: AdminPageProps) => {
 }
 
 // Alumni Tab Component
-const AlumniTab = ({ alumni, searchTerm, onSearchChange, onExport, totalCount, isSuperuser, onDelete }: {
+const paymentStatusChip: Record<string, { label: string, color: 'default' | 'info' | 'warning' | 'success' | 'error' }> = {
+  none: { label: 'Unpaid', color: 'default' },
+  submitted: { label: 'Submitted', color: 'info' },
+  under_review: { label: 'Under review', color: 'warning' },
+  approved: { label: 'Paid', color: 'success' },
+  rejected: { label: 'Rejected', color: 'error' },
+}
+
+const AlumniTab = ({ alumni, searchTerm, onSearchChange, onExport, totalCount, isSuperuser, onDelete, onReviewPayment, onDownloadProof }: {
   alumni: Alumni[]
   searchTerm: string
   onSearchChange: (term: string) => void
@@ -611,6 +775,8 @@ const AlumniTab = ({ alumni, searchTerm, onSearchChange, onExport, totalCount, i
   totalCount: number
   isSuperuser: boolean
   onDelete: (type: 'alumni' | 'nomination' | 'sponsorship', id: number, name: string) => void
+  onReviewPayment: (id: number, action: 'approve' | 'reject') => void
+  onDownloadProof: (id: number) => void
 }) => (
   <Box>
     {/* Search and Export Controls */}
@@ -674,21 +840,20 @@ const AlumniTab = ({ alumni, searchTerm, onSearchChange, onExport, totalCount, i
               <TableCell>{alumnus.Course}</TableCell>
               <TableCell>{alumnus.City}, {alumnus.Country}</TableCell>
               <TableCell>
-                <Chip
-                  label={alumnus.Paid ? 'Paid' : 'Unpaid'}
-                  color={alumnus.Paid ? 'success' : 'error'}
-                  size="small"
-                />
+                <Tooltip title={alumnus.PaymentRejectionReason || ''}>
+                  <Chip
+                    label={paymentStatusChip[alumnus.PaymentStatus || 'none'].label}
+                    color={paymentStatusChip[alumnus.PaymentStatus || 'none'].color}
+                    size="small"
+                  />
+                </Tooltip>
               </TableCell>
               <TableCell>
                 <Box sx={{ display: 'flex', gap: 1, alignItems: 'center' }}>
-                  {alumnus.Paid && alumnus.PaymentProofData && alumnus.PaymentProofData.length > 0 ? (
+                  {alumnus.PaymentProofSize && alumnus.PaymentProofSize > 0 ? (
                     <Tooltip title="Download payment proof">
                       <IconButton
-                        component="a"
-                        href={`/api/alumni/${alumnus.ID}/payment-proof`}
-                        target="_blank"
-                        rel="noopener noreferrer"
+                        onClick={() => onDownloadProof(alumnus.ID)}
                         size="small"
                         color="primary"
                       >
@@ -700,6 +865,20 @@ const AlumniTab = ({ alumni, searchTerm, onSearchChange, onExport, totalCount, i
                       No attachment
                     </Typography>
                   )}
+                  {(alumnus.PaymentStatus === 'submitted' || alumnus.PaymentStatus === 'under_review') && (
+                    <>
+                      <Tooltip title="Approve payment">
+                        <IconButton size="small" color="success" onClick={() => onReviewPayment(alumnus.ID, 'approve')}>
+                          <ApproveIcon />
+                        </IconButton>
+                      </Tooltip>
+                      <Tooltip title="Reject payment">
+                        <IconButton size="small" color="error" onClick={() => onReviewPayment(alumnus.ID, 'reject')}>
+                          <RejectIcon />
+                        </IconButton>
+                      </Tooltip>
+                    </>
+                  )}
                   {isSuperuser && (
                     <Tooltip title="Delete alumni">
                       <IconButton
@@ -722,7 +901,7 @@ const AlumniTab = ({ alumni, searchTerm, onSearchChange, onExport, totalCount, i
 )
 
 // Nominations Tab Component
-const NominationsTab = ({ nominations, searchTerm, onSearchChange, onExport, totalCount, formatDate, isSuperuser, onDelete }: {
+const NominationsTab = ({ nominations, searchTerm, onSearchChange, onExport, totalCount, formatDate, isSuperuser, onDelete, onFindDuplicates }: {
   nominations: Nomination[]
   searchTerm: string
   onSearchChange: (term: string) => void
@@ -731,6 +910,7 @@ const NominationsTab = ({ nominations, searchTerm, onSearchChange, onExport, tot
   formatDate: (date: string) => string
   isSuperuser: boolean
   onDelete: (type: 'alumni' | 'nomination' | 'sponsorship', id: number, name: string) => void
+  onFindDuplicates: () => void
 }) => (
   <Box>
     {/* Search and Export Controls */}
@@ -752,6 +932,13 @@ const NominationsTab = ({ nominations, searchTerm, onSearchChange, onExport, tot
         <Typography variant="body2" color="text.secondary">
           Showing {nominations.length} of {totalCount} nominations
         </Typography>
+        <Button
+          variant="outlined"
+          startIcon={<MergeIcon />}
+          onClick={onFindDuplicates}
+        >
+          Find Duplicates
+        </Button>
         <Button
           variant="contained"
           startIcon={<DownloadIcon />}
@@ -782,6 +969,11 @@ const NominationsTab = ({ nominations, searchTerm, onSearchChange, onExport, tot
                 <Box>
                   <Typography variant="body2" sx={{ fontWeight: 'medium' }}>
                     {nomination.FirstName} {nomination.LastName}
+                    {nomination.AlumniID && (
+                      <Tooltip title={`Linked to alumni record #${nomination.AlumniID}`}>
+                        <LinkIcon fontSize="small" color="primary" sx={{ ml: 0.5, verticalAlign: 'middle' }} />
+                      </Tooltip>
+                    )}
                   </Typography>
                   <Typography variant="caption" color="text.secondary">
                     {nomination.NominatedEmail}
@@ -817,6 +1009,129 @@ const NominationsTab = ({ nominations, searchTerm, onSearchChange, onExport, tot
   </Box>
 )
 
+// Duplicate Nominees Dialog
+const DuplicateNomineesDialog = ({ open, onClose, onMerged, authHeaders }: {
+  open: boolean
+  onClose: () => void
+  onMerged: () => void
+  authHeaders: { Authorization: string }
+}) => {
+  const [suggestions, setSuggestions] = useState<NomineeMergeSuggestion[]>([])
+  const [loading, setLoading] = useState(false)
+
+  useEffect(() => {
+    if (open) fetchSuggestions()
+  }, [open])
+
+  const fetchSuggestions = async () => {
+    setLoading(true)
+    try {
+      const response = await fetch('/api/admin/nominees/suggestions', { headers: authHeaders })
+      if (response.ok) {
+        const data = await response.json()
+        setSuggestions(data.suggestions || [])
+      }
+    } catch (err) {
+      console.error('Failed to fetch duplicate nominees:', err)
+    } finally {
+      setLoading(false)
+    }
+  }
+
+  const merge = async (suggestion: NomineeMergeSuggestion) => {
+    try {
+      const response = await fetch('/api/admin/nominees/merge', {
+        method: 'POST',
+        headers: {
+          'Content-Type': 'application/json',
+          ...authHeaders,
+        },
+        body: JSON.stringify({
+          nomination_ids: suggestion.Duplicate.NominationIDs,
+          target_nomination_id: suggestion.Target.NominationIDs[0],
+        }),
+      })
+
+      if (response.ok) {
+        onMerged()
+        fetchSuggestions()
+      } else {
+        const data = await response.json().catch(() => ({}))
+        alert(data.error || 'Failed to merge nominees')
+      }
+    } catch (err) {
+      console.error('Failed to merge nominees:', err)
+    }
+  }
+
+  const describe = (nominee: Nominee) => (
+    <Box>
+      <Typography variant="body2" sx={{ fontWeight: 'medium' }}>
+        {nominee.FirstName} {nominee.LastName} ({nominee.Year})
+        {nominee.AlumniID && (
+          <Tooltip title={`Linked to alumni record #${nominee.AlumniID}`}>
+            <LinkIcon fontSize="small" color="primary" sx={{ ml: 0.5, verticalAlign: 'middle' }} />
+          </Tooltip>
+        )}
+      </Typography>
+      <Typography variant="caption" color="text.secondary">
+        {nominee.NominationIDs.length} nomination{nominee.NominationIDs.length === 1 ? '' : 's'}
+      </Typography>
+    </Box>
+  )
+
+  return (
+    <Dialog open={open} onClose={onClose} maxWidth="md" fullWidth>
+      <DialogTitle>Possible Duplicate Nominees</DialogTitle>
+      <DialogContent>
+        {loading ? (
+          <Box sx={{ display: 'flex', justifyContent: 'center', py: 4 }}>
+            <CircularProgress />
+          </Box>
+        ) : suggestions.length === 0 ? (
+          <Typography variant="body1" color="text.secondary" sx={{ textAlign: 'center', py: 4 }}>
+            No likely duplicates found
+          </Typography>
+        ) : (
+          <TableContainer component={Paper}>
+            <Table size="small">
+              <TableHead>
+                <TableRow>
+                  <TableCell>Category</TableCell>
+                  <TableCell>Keep</TableCell>
+                  <TableCell>Merge In</TableCell>
+                  <TableCell>Similarity</TableCell>
+                  <TableCell>Actions</TableCell>
+                </TableRow>
+              </TableHead>
+              <TableBody>
+                {suggestions.map((suggestion) => (
+                  <TableRow key={suggestion.Duplicate.NominationIDs.join(',') + '-' + suggestion.Target.NominationIDs[0]} hover>
+                    <TableCell>{suggestion.Target.Category}</TableCell>
+                    <TableCell>{describe(suggestion.Target)}</TableCell>
+                    <TableCell>{describe(suggestion.Duplicate)}</TableCell>
+                    <TableCell>{Math.round(suggestion.Score * 100)}%</TableCell>
+                    <TableCell>
+                      <Button size="small" variant="contained" startIcon={<MergeIcon />} onClick={() => merge(suggestion)}>
+                        Merge
+                      </Button>
+                    </TableCell>
+                  </TableRow>
+                ))}
+              </TableBody>
+            </Table>
+          </TableContainer>
+        )}
+      </DialogContent>
+      <DialogActions sx={{ p: 3 }}>
+        <Button onClick={onClose} color="inherit">
+          Close
+        </Button>
+      </DialogActions>
+    </Dialog>
+  )
+}
+
 // Sponsorships Tab Component
 const SponsorshipsTab = ({ sponsorships, searchTerm, onSearchChange, onExport, totalCount, formatDate, onUpdate, isSuperuser, onDelete }: {
   sponsorships: Sponsorship[]
@@ -1165,4 +1480,567 @@ const SponsorshipsTab = ({ sponsorships, searchTerm, onSearchChange, onExport, t
   )
 }
 
+// Campaigns Tab Component
+const emptyCategory: CampaignCategory = {
+  Name: '',
+  Description: '',
+  MinYearsSinceGraduation: 0,
+  MaxYearsSinceGraduation: 0,
+  RequireNomineeEmail: false,
+}
+
+// datetime-local inputs take local time without a zone
+const toLocalInput = (date: string) => {
+  const d = new Date(date)
+  return new Date(d.getTime() - d.getTimezoneOffset() * 60000).toISOString().slice(0, 16)
+}
+
+const CampaignsTab = ({ authHeaders, formatDate }: {
+  authHeaders: { Authorization: string }
+  formatDate: (date: string) => string
+}) => {
+  const [campaigns, setCampaigns] = useState<NominationCampaign[]>([])
+  const [editing, setEditing] = useState<NominationCampaign | null>(null)
+  const [judging, setJudging] = useState<NominationCampaign | null>(null)
+  const [saving, setSaving] = useState(false)
+  const [error, setError] = useState('')
+
+  useEffect(() => {
+    fetchCampaigns()
+  }, [])
+
+  const fetchCampaigns = async () => {
+    try {
+      const response = await fetch('/api/admin/nomination-campaigns', { headers: authHeaders })
+      if (response.ok) {
+        const data = await response.json()
+        setCampaigns(data.campaigns || [])
+      }
+    } catch (err) {
+      console.error('Failed to fetch nomination campaigns:', err)
+    }
+  }
+
+  const startNew = () => {
+    setError('')
+    setEditing({ ID: 0, Name: '', OpensAt: '', ClosesAt: '', Categories: [{ ...emptyCategory }] })
+  }
+
+  const startEdit = (campaign: NominationCampaign) => {
+    setError('')
+    setEditing({
+      ...campaign,
+      OpensAt: toLocalInput(campaign.OpensAt),
+      ClosesAt: toLocalInput(campaign.ClosesAt),
+      Categories: campaign.Categories.map((category) => ({ ...category })),
+    })
+  }
+
+  const updateCategory = (index: number, changes: Partial<CampaignCategory>) => {
+    if (!editing) return
+    const categories = editing.Categories.map((category, i) => (i === index ? { ...category, ...changes } : category))
+    setEditing({ ...editing, Categories: categories })
+  }
+
+  const saveCampaign = async () => {
+    if (!editing) return
+    if (!editing.OpensAt || !editing.ClosesAt) {
+      setError('Opening and closing dates are required')
+      return
+    }
+
+    setSaving(true)
+    setError('')
+    try {
+      const response = await fetch(`/api/admin/nomination-campaigns${editing.ID ? `/${editing.ID}` : ''}`, {
+        method: editing.ID ? 'PUT' : 'POST',
+        headers: {
+          'Content-Type': 'application/json',
+          ...authHeaders,
+        },
+        body: JSON.stringify({
+          name: editing.Name,
+          opens_at: new Date(editing.OpensAt).toISOString(),
+          closes_at: new Date(editing.ClosesAt).toISOString(),
+          categories: editing.Categories.map((category) => ({
+            name: category.Name,
+            description: category.Description,
+            min_years_since_graduation: category.MinYearsSinceGraduation,
+            max_years_since_graduation: category.MaxYearsSinceGraduation,
+            require_nominee_email: category.RequireNomineeEmail,
+          })),
+        }),
+      })
+
+      if (response.ok) {
+        setEditing(null)
+        fetchCampaigns()
+      } else {
+        const data = await response.json().catch(() => ({}))
+        setError(data.error || 'Failed to save campaign')
+      }
+    } catch (err) {
+      setError('Network error. Please try again.')
+    } finally {
+      setSaving(false)
+    }
+  }
+
+  const deleteCampaign = async (campaign: NominationCampaign) => {
+    if (!window.confirm(`Delete the campaign "${campaign.Name}"?`)) return
+
+    try {
+      const response = await fetch(`/api/admin/nomination-campaigns/${campaign.ID}`, {
+        method: 'DELETE',
+        headers: authHeaders,
+      })
+      if (response.ok) {
+        fetchCampaigns()
+      } else {
+        const data = await response.json().catch(() => ({}))
+        alert(data.error || 'Failed to delete campaign')
+      }
+    } catch (err) {
+      console.error('Failed to delete campaign:', err)
+    }
+  }
+
+  const campaignStatus = (campaign: NominationCampaign) => {
+    const now = new Date()
+    if (now < new Date(campaign.OpensAt)) return { label: 'Upcoming', color: 'info' as const }
+    if (now < new Date(campaign.ClosesAt)) return { label: 'Open', color: 'success' as const }
+    return { label: 'Closed', color: 'default' as const }
+  }
+
+  return (
+    <Box>
+      <Box sx={{ display: 'flex', justifyContent: 'space-between', alignItems: 'center', mb: 3 }}>
+        <Typography variant="body2" color="text.secondary">
+          Nominations are accepted only while a campaign is open, and only for its categories.
+        </Typography>
+        <Button variant="contained" startIcon={<AddIcon />} onClick={startNew}>
+          New Campaign
+        </Button>
+      </Box>
+
+      <TableContainer component={Paper}>
+        <Table>
+          <TableHead>
+            <TableRow>
+              <TableCell>Name</TableCell>
+              <TableCell>Opens</TableCell>
+              <TableCell>Closes</TableCell>
+              <TableCell>Categories</TableCell>
+              <TableCell>Status</TableCell>
+              <TableCell>Actions</TableCell>
+            </TableRow>
+          </TableHead>
+          <TableBody>
+            {campaigns.map((campaign) => {
+              const status = campaignStatus(campaign)
+              return (
+                <TableRow key={campaign.ID} hover>
+                  <TableCell>
+                    <Typography variant="body2" sx={{ fontWeight: 'medium' }}>
+                      {campaign.Name}
+                    </Typography>
+                  </TableCell>
+                  <TableCell>{formatDate(campaign.OpensAt)}</TableCell>
+                  <TableCell>{formatDate(campaign.ClosesAt)}</TableCell>
+                  <TableCell>{campaign.Categories.length}</TableCell>
+                  <TableCell>
+                    <Chip label={status.label} color={status.color} size="small" />
+                  </TableCell>
+                  <TableCell>
+                    <Tooltip title="Edit Campaign">
+                      <IconButton size="small" color="primary" onClick={() => startEdit(campaign)}>
+                        <EditIcon />
+                      </IconButton>
+                    </Tooltip>
+                    <Tooltip title="Judging">
+                      <IconButton size="small" color="warning" onClick={() => setJudging(campaign)}>
+                        <GavelIcon />
+                      </IconButton>
+                    </Tooltip>
+                    <Tooltip title="Delete Campaign">
+                      <IconButton size="small" color="error" onClick={() => deleteCampaign(campaign)}>
+                        <DeleteIcon />
+                      </IconButton>
+                    </Tooltip>
+                  </TableCell>
+                </TableRow>
+              )
+            })}
+          </TableBody>
+        </Table>
+      </TableContainer>
+
+      {campaigns.length === 0 && (
+        <Box sx={{ textAlign: 'center', py: 4 }}>
+          <Typography variant="body1" color="text.secondary">
+            No campaigns yet, so nominations are closed
+          </Typography>
+        </Box>
+      )}
+
+      <CampaignJudgingDialogMD
+        campaign={judging}
+        onClose={() => setJudging(null)}
+        authHeaders={authHeaders}
+      />
+
+      <Dialog open={!!editing} onClose={() => setEditing(null)} maxWidth="md" fullWidth>
+        {editing && (
+          <>
+            <DialogTitle>{editing.ID ? 'Edit Campaign' : 'New Campaign'}</DialogTitle>
+            <DialogContent>
+              {error && (
+                <Typography variant="body2" color="error" sx={{ mb: 2 }}>
+                  {error}
+                </Typography>
+              )}
+              <Grid container spacing={2} sx={{ mt: 0 }}>
+                <Grid item xs={12}>
+                  <TextField
+                    fullWidth
+                    label="Name"
+                    value={editing.Name}
+                    onChange={(e) => setEditing({ ...editing, Name: e.target.value })}
+                  />
+                </Grid>
+                <Grid item xs={12} sm={6}>
+                  <TextField
+                    fullWidth
+                    type="datetime-local"
+                    label="Opens"
+                    value={editing.OpensAt}
+                    onChange={(e) => setEditing({ ...editing, OpensAt: e.target.value })}
+                    InputLabelProps={{ shrink: true }}
+                  />
+                </Grid>
+                <Grid item xs={12} sm={6}>
+                  <TextField
+                    fullWidth
+                    type="datetime-local"
+                    label="Closes"
+                    value={editing.ClosesAt}
+                    onChange={(e) => setEditing({ ...editing, ClosesAt: e.target.value })}
+                    InputLabelProps={{ shrink: true }}
+                  />
+                </Grid>
+              </Grid>
+
+              <Typography variant="subtitle1" sx={{ mt: 3, mb: 1, fontWeight: 'bold' }}>
+                Categories
+              </Typography>
+              {editing.Categories.map((category, index) => (
+                <Paper key={index} variant="outlined" sx={{ p: 2, mb: 2 }}>
+                  <Grid container spacing={2}>
+                    <Grid item xs={12} sm={11}>
+                      <TextField
+                        fullWidth
+                        size="small"
+                        label="Category Name"
+                        value={category.Name}
+                        onChange={(e) => updateCategory(index, { Name: e.target.value })}
+                      />
+                    </Grid>
+                    <Grid item xs={12} sm={1}>
+                      <IconButton
+                        color="error"
+                        disabled={editing.Categories.length === 1}
+                        onClick={() => setEditing({ ...editing, Categories: editing.Categories.filter((_, i) => i !== index) })}
+                      >
+                        <DeleteIcon />
+                      </IconButton>
+                    </Grid>
+                    <Grid item xs={12}>
+                      <TextField
+                        fullWidth
+                        multiline
+                        size="small"
+                        label="Description"
+                        value={category.Description}
+                        onChange={(e) => updateCategory(index, { Description: e.target.value })}
+                      />
+                    </Grid>
+                    <Grid item xs={6} sm={4}>
+                      <TextField
+                        fullWidth
+                        size="small"
+                        type="number"
+                        label="Min. years since graduation"
+                        helperText="0 for no minimum"
+                        value={category.MinYearsSinceGraduation}
+                        onChange={(e) => updateCategory(index, { MinYearsSinceGraduation: parseInt(e.target.value) || 0 })}
+                        inputProps={{ min: 0 }}
+                      />
+                    </Grid>
+                    <Grid item xs={6} sm={4}>
+                      <TextField
+                        fullWidth
+                        size="small"
+                        type="number"
+                        label="Max. years since graduation"
+                        helperText="0 for no maximum"
+                        value={category.MaxYearsSinceGraduation}
+                        onChange={(e) => updateCategory(index, { MaxYearsSinceGraduation: parseInt(e.target.value) || 0 })}
+                        inputProps={{ min: 0 }}
+                      />
+                    </Grid>
+                    <Grid item xs={12} sm={4}>
+                      <FormControlLabel
+                        control={
+                          <Checkbox
+                            checked={category.RequireNomineeEmail}
+                            onChange={(e) => updateCategory(index, { RequireNomineeEmail: e.target.checked })}
+                          />
+                        }
+                        label="Require nominee email"
+                      />
+                    </Grid>
+                  </Grid>
+                </Paper>
+              ))}
+              <Button
+                startIcon={<AddIcon />}
+                onClick={() => setEditing({ ...editing, Categories: [...editing.Categories, { ...emptyCategory }] })}
+              >
+                Add Category
+              </Button>
+            </DialogContent>
+            <DialogActions sx={{ p: 3 }}>
+              <Button onClick={() => setEditing(null)} startIcon={<CancelIcon />} color="inherit">
+                Cancel
+              </Button>
+              <Button
+                variant="contained"
+                onClick={saveCampaign}
+                disabled={saving || !editing.Name}
+                startIcon={saving ? <CircularProgress size={20} /> : <SaveIcon />}
+              >
+                Save
+              </Button>
+            </DialogActions>
+          </>
+        )}
+      </Dialog>
+    </Box>
+  )
+}
+
+interface JudgingCriterion {
+  ID: number
+  Name: string
+  Description: string
+  Weight: number
+}
+
+interface BallotEntry {
+  ID: number
+  Category: string
+  FirstName: string
+  LastName: string
+  Year: number
+}
+
+interface JudgeScore {
+  EntryID: number
+  CriterionID: number
+  Score: number
+}
+
+// Judging Tab Component, where a judge scores the shortlists they are assigned
+const JudgingTab = ({ authHeaders }: {
+  authHeaders: { Authorization: string }
+}) => {
+  const [campaigns, setCampaigns] = useState<NominationCampaign[]>([])
+  const [campaignId, setCampaignId] = useState<number | ''>('')
+  const [criteria, setCriteria] = useState<JudgingCriterion[]>([])
+  const [shortlist, setShortlist] = useState<BallotEntry[]>([])
+  const [scores, setScores] = useState<Record<string, number | ''>>({})
+  const [maxScore, setMaxScore] = useState(10)
+  const [savingEntry, setSavingEntry] = useState<number | null>(null)
+  const [error, setError] = useState('')
+
+  useEffect(() => {
+    fetchCampaigns()
+  }, [])
+
+  useEffect(() => {
+    if (campaignId) fetchBallot(campaignId)
+  }, [campaignId])
+
+  const scoreKey = (entryId: number, criterionId: number) => `${entryId}-${criterionId}`
+
+  const fetchCampaigns = async () => {
+    try {
+      const response = await fetch('/api/judging/campaigns', { headers: authHeaders })
+      if (response.ok) {
+        const data = await response.json()
+        const assigned: NominationCampaign[] = data.campaigns || []
+        setCampaigns(assigned)
+        if (assigned.length > 0) setCampaignId(assigned[0].ID)
+      }
+    } catch (err) {
+      console.error('Failed to fetch judging campaigns:', err)
+    }
+  }
+
+  const fetchBallot = async (id: number) => {
+    setError('')
+    try {
+      const response = await fetch(`/api/judging/campaigns/${id}`, { headers: authHeaders })
+      const data = await response.json().catch(() => ({}))
+      if (!response.ok) {
+        setError(data.error || 'Failed to fetch ballot')
+        return
+      }
+      setCriteria(data.criteria || [])
+      setShortlist(data.shortlist || [])
+      setMaxScore(data.max_score || 10)
+      const saved: Record<string, number | ''> = {}
+      ;(data.scores || []).forEach((score: JudgeScore) => {
+        saved[scoreKey(score.EntryID, score.CriterionID)] = score.Score
+      })
+      setScores(saved)
+    } catch (err) {
+      console.error('Failed to fetch ballot:', err)
+    }
+  }
+
+  const saveScores = async (entry: BallotEntry) => {
+    const entryScores = criteria
+      .filter((criterion) => scores[scoreKey(entry.ID, criterion.ID)] !== undefined && scores[scoreKey(entry.ID, criterion.ID)] !== '')
+      .map((criterion) => ({ criterion_id: criterion.ID, score: scores[scoreKey(entry.ID, criterion.ID)] }))
+
+    setSavingEntry(entry.ID)
+    setError('')
+    try {
+      const response = await fetch(`/api/judging/campaigns/${campaignId}/shortlist/${entry.ID}/scores`, {
+        method: 'PUT',
+        headers: {
+          'Content-Type': 'application/json',
+          ...authHeaders,
+        },
+        body: JSON.stringify({ scores: entryScores }),
+      })
+      if (!response.ok) {
+        const data = await response.json().catch(() => ({}))
+        setError(data.error || 'Failed to save scores')
+      }
+    } catch (err) {
+      setError('Network error. Please try again.')
+    } finally {
+      setSavingEntry(null)
+    }
+  }
+
+  if (campaigns.length === 0) {
+    return (
+      <Box sx={{ textAlign: 'center', py: 4 }}>
+        <Typography variant="body1" color="text.secondary">
+          You are not judging any nomination campaign
+        </Typography>
+      </Box>
+    )
+  }
+
+  return (
+    <Box>
+      <Box sx={{ display: 'flex', justifyContent: 'space-between', alignItems: 'center', mb: 3 }}>
+        <TextField
+          select
+          size="small"
+          label="Campaign"
+          value={campaignId}
+          onChange={(e) => setCampaignId(Number(e.target.value))}
+          sx={{ minWidth: 300 }}
+        >
+          {campaigns.map((campaign) => (
+            <MenuItem key={campaign.ID} value={campaign.ID}>{campaign.Name}</MenuItem>
+          ))}
+        </TextField>
+        <Typography variant="body2" color="text.secondary">
+          Score each nominee from 0 to {maxScore} on every criterion.
+        </Typography>
+      </Box>
+
+      {error && (
+        <Typography variant="body2" color="error" sx={{ mb: 2 }}>
+          {error}
+        </Typography>
+      )}
+
+      <TableContainer component={Paper}>
+        <Table>
+          <TableHead>
+            <TableRow>
+              <TableCell>Category</TableCell>
+              <TableCell>Nominee</TableCell>
+              {criteria.map((criterion) => (
+                <TableCell key={criterion.ID}>
+                  <Tooltip title={criterion.Description || ''}>
+                    <span>{criterion.Name}</span>
+                  </Tooltip>
+                </TableCell>
+              ))}
+              <TableCell>Actions</TableCell>
+            </TableRow>
+          </TableHead>
+          <TableBody>
+            {shortlist.map((entry) => (
+              <TableRow key={entry.ID} hover>
+                <TableCell>{entry.Category}</TableCell>
+                <TableCell>
+                  <Typography variant="body2" sx={{ fontWeight: 'medium' }}>
+                    {entry.FirstName} {entry.LastName}
+                  </Typography>
+                  <Typography variant="caption" color="text.secondary">
+                    Class of {entry.Year}
+                  </Typography>
+                </TableCell>
+                {criteria.map((criterion) => {
+                  const key = scoreKey(entry.ID, criterion.ID)
+                  return (
+                    <TableCell key={criterion.ID}>
+                      <TextField
+                        size="small"
+                        type="number"
+                        value={scores[key] ?? ''}
+                        onChange={(e) => setScores({ ...scores, [key]: e.target.value === '' ? '' : parseInt(e.target.value) })}
+                        inputProps={{ min: 0, max: maxScore }}
+                        sx={{ width: 80 }}
+                      />
+                    </TableCell>
+                  )
+                })}
+                <TableCell>
+                  <Button
+                    size="small"
+                    variant="contained"
+                    startIcon={<SaveIcon />}
+                    disabled={savingEntry === entry.ID}
+                    onClick={() => saveScores(entry)}
+                  >
+                    Save
+                  </Button>
+                </TableCell>
+              </TableRow>
+            ))}
+          </TableBody>
+        </Table>
+      </TableContainer>
+
+      {shortlist.length === 0 && (
+        <Box sx={{ textAlign: 'center', py: 4 }}>
+          <Typography variant="body1" color="text.secondary">
+            Nobody has been shortlisted yet
+          </Typography>
+        </Box>
+      )}
+    </Box>
+  )
+}
+
 export default AdminPageMD
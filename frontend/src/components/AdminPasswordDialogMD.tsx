@@ -0,0 +1,150 @@
+import { useState } from 'react'
+import {
+  Dialog,
+  DialogTitle,
+  DialogContent,
+  DialogActions,
+  Button,
+  TextField,
+  Typography,
+  Alert,
+  CircularProgress,
+  Box,
+} from '@mui/material'
+import { LockReset as LockResetIcon } from '@mui/icons-material'
+
+interface AdminPasswordDialogProps {
+  open: boolean
+  // The admin must change their password before doing anything else
+  required?: boolean
+  // Already known, e.g. just typed at login, so it is not asked for again
+  currentPassword?: string
+  onClose: () => void
+  onChanged: () => void
+}
+
+// Changes the signed-in admin's password. Admins whose password was reset
+// or is a known default are sent here before they can use the dashboard.
+const AdminPasswordDialogMD = ({ open, required, currentPassword, onClose, onChanged }: AdminPasswordDialogProps) => {
+  const [current, setCurrent] = useState('')
+  const [newPassword, setNewPassword] = useState('')
+  const [confirm, setConfirm] = useState('')
+  const [loading, setLoading] = useState(false)
+  const [error, setError] = useState('')
+
+  const reset = () => {
+    setCurrent('')
+    setNewPassword('')
+    setConfirm('')
+    setError('')
+  }
+
+  const handleClose = () => {
+    reset()
+    onClose()
+  }
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault()
+    if (newPassword !== confirm) {
+      setError('The new passwords do not match')
+      return
+    }
+
+    setLoading(true)
+    setError('')
+    try {
+      const response = await fetch('/api/admin/users/me/password', {
+        method: 'PUT',
+        headers: {
+          'Content-Type': 'application/json',
+          Authorization: `Bearer ${localStorage.getItem('admin_token') || ''}`,
+        },
+        body: JSON.stringify({
+          current_password: currentPassword ?? current,
+          new_password: newPassword,
+        }),
+      })
+      const data = await response.json().catch(() => ({}))
+      if (response.ok) {
+        reset()
+        onChanged()
+      } else {
+        setError(data.error || 'Failed to change password')
+      }
+    } catch {
+      setError('Network error. Please try again.')
+    } finally {
+      setLoading(false)
+    }
+  }
+
+  return (
+    <Dialog open={open} onClose={required ? undefined : handleClose} maxWidth="xs" fullWidth>
+      <Box component="form" onSubmit={handleSubmit}>
+        <DialogTitle sx={{ display: 'flex', alignItems: 'center', gap: 1 }}>
+          <LockResetIcon color="primary" />
+          Change Password
+        </DialogTitle>
+        <DialogContent>
+          {required && (
+            <Alert severity="warning" sx={{ mb: 2 }}>
+              You must choose a new password before using the dashboard.
+            </Alert>
+          )}
+          {error && <Alert severity="error" sx={{ mb: 2 }}>{error}</Alert>}
+          {currentPassword === undefined && (
+            <TextField
+              fullWidth
+              margin="normal"
+              type="password"
+              label="Current Password"
+              value={current}
+              onChange={(e) => setCurrent(e.target.value)}
+              autoComplete="current-password"
+              required
+            />
+          )}
+          <TextField
+            fullWidth
+            margin="normal"
+            type="password"
+            label="New Password"
+            value={newPassword}
+            onChange={(e) => setNewPassword(e.target.value)}
+            autoComplete="new-password"
+            required
+          />
+          <TextField
+            fullWidth
+            margin="normal"
+            type="password"
+            label="Confirm New Password"
+            value={confirm}
+            onChange={(e) => setConfirm(e.target.value)}
+            autoComplete="new-password"
+            required
+          />
+          <Typography variant="caption" color="text.secondary">
+            At least 8 characters, different from the current password.
+          </Typography>
+        </DialogContent>
+        <DialogActions sx={{ p: 3 }}>
+          <Button onClick={handleClose} color="inherit" disabled={loading}>
+            {required ? 'Log Out' : 'Cancel'}
+          </Button>
+          <Button
+            type="submit"
+            variant="contained"
+            disabled={loading || newPassword.length < 8 || !confirm || (currentPassword === undefined && !current)}
+            startIcon={loading ? <CircularProgress size={20} /> : undefined}
+          >
+            Change Password
+          </Button>
+        </DialogActions>
+      </Box>
+    </Dialog>
+  )
+}
+
+export default AdminPasswordDialogMD
@@ -0,0 +1,448 @@
+import { useState, useEffect } from 'react'
+import toast from 'react-hot-toast'
+import {
+  Dialog,
+  DialogContent,
+  TextField,
+  Button,
+  Typography,
+  Box,
+  Alert,
+  CircularProgress,
+  IconButton,
+  Chip,
+  Divider,
+  FormControlLabel,
+  Switch,
+  MenuItem,
+} from '@mui/material'
+import {
+  Close as CloseIcon,
+  AccountCircle as AccountIcon,
+  Email as EmailIcon,
+  Save as SaveIcon,
+  UploadFile as UploadIcon,
+  Payment as PaymentIcon,
+  Logout as LogoutIcon,
+  Lock as LockIcon,
+} from '@mui/icons-material'
+
+interface AlumniPortalProps {
+  open: boolean
+  onClose: () => void
+  // Set when the page was opened from an emailed sign-in link
+  loginEmail?: string
+  loginToken?: string
+}
+
+interface Profile {
+  ID: number
+  FirstName: string
+  LastName: string
+  Email: string
+  Phone: string
+  Year: number
+  Course: string
+  Company: string
+  Position: string
+  Country: string
+  City: string
+  Latitude: number
+  Longitude: number
+  PaymentStatus: string
+  PaymentRejectionReason: string
+  PaymentProof: string
+  ShowEmail: boolean
+  ShowPhone: boolean
+  ShowEmployer: boolean
+  MapPrecision: string
+}
+
+const mapPrecisionOptions = [
+  { value: 'exact', label: 'Exact location' },
+  { value: 'city', label: 'City only' },
+  { value: 'country', label: 'Country only' },
+  { value: 'hidden', label: 'Hidden from the map' },
+]
+
+const paymentStatusLabels: Record<string, { label: string, color: 'default' | 'info' | 'warning' | 'success' | 'error' }> = {
+  none: { label: 'Unpaid', color: 'default' },
+  submitted: { label: 'Proof submitted', color: 'info' },
+  under_review: { label: 'Under review', color: 'warning' },
+  approved: { label: 'Paid', color: 'success' },
+  rejected: { label: 'Proof rejected', color: 'error' },
+}
+
+const AlumniPortalMD = ({ open, onClose, loginEmail, loginToken }: AlumniPortalProps) => {
+  const [token, setToken] = useState(localStorage.getItem('alumni_token') || '')
+  const [email, setEmail] = useState('')
+  const [linkSent, setLinkSent] = useState(false)
+  const [profile, setProfile] = useState<Profile | null>(null)
+  const [loading, setLoading] = useState(false)
+  const [error, setError] = useState('')
+
+  const authHeaders = () => ({ Authorization: `Bearer ${token}` })
+
+  const signOut = () => {
+    localStorage.removeItem('alumni_token')
+    setToken('')
+    setProfile(null)
+  }
+
+  // Exchange the token from a sign-in link for a session
+  useEffect(() => {
+    if (!open || !loginEmail || !loginToken) return
+    const verify = async () => {
+      setLoading(true)
+      setError('')
+      try {
+        const response = await fetch('/api/alumni/login/verify', {
+          method: 'POST',
+          headers: { 'Content-Type': 'application/json' },
+          body: JSON.stringify({ email: loginEmail, token: loginToken }),
+        })
+        const data = await response.json()
+        if (response.ok) {
+          localStorage.setItem('alumni_token', data.session.access_token)
+          setToken(data.session.access_token)
+          setProfile(data.alumni)
+        } else {
+          setError(data.error || 'Sign-in failed')
+        }
+      } catch {
+        setError('Network error. Please try again.')
+      } finally {
+        setLoading(false)
+        window.history.replaceState({}, '', window.location.pathname)
+      }
+    }
+    verify()
+  }, [open, loginEmail, loginToken])
+
+  useEffect(() => {
+    if (!open || !token || profile) return
+    const load = async () => {
+      setLoading(true)
+      try {
+        const response = await fetch('/api/me', { headers: authHeaders() })
+        if (response.ok) {
+          const data = await response.json()
+          setProfile(data.alumni)
+        } else {
+          signOut()
+        }
+      } catch {
+        setError('Network error. Please try again.')
+      } finally {
+        setLoading(false)
+      }
+    }
+    load()
+    // eslint-disable-next-line react-hooks/exhaustive-deps
+  }, [open, token])
+
+  const requestLink = async (e: React.FormEvent) => {
+    e.preventDefault()
+    setLoading(true)
+    setError('')
+    try {
+      const response = await fetch('/api/alumni/login', {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify({ email }),
+      })
+      const data = await response.json()
+      if (response.ok) {
+        setLinkSent(true)
+      } else {
+        setError(data.error || 'Failed to send sign-in link')
+      }
+    } catch {
+      setError('Network error. Please try again.')
+    } finally {
+      setLoading(false)
+    }
+  }
+
+  const saveProfile = async () => {
+    if (!profile) return
+    setLoading(true)
+    setError('')
+    try {
+      const response = await fetch('/api/me', {
+        method: 'PUT',
+        headers: { ...authHeaders(), 'Content-Type': 'application/json' },
+        body: JSON.stringify({
+          firstName: profile.FirstName,
+          lastName: profile.LastName,
+          phone: profile.Phone,
+          year: Number(profile.Year),
+          course: profile.Course,
+          company: profile.Company,
+          position: profile.Position,
+          country: profile.Country,
+          city: profile.City,
+        }),
+      })
+      const data = await response.json()
+      if (response.ok) {
+        setProfile(data.alumni)
+        toast.success('Profile updated')
+      } else {
+        setError(data.error || 'Failed to update profile')
+      }
+    } catch {
+      setError('Network error. Please try again.')
+    } finally {
+      setLoading(false)
+    }
+  }
+
+  const savePrivacy = async () => {
+    if (!profile) return
+    setLoading(true)
+    setError('')
+    try {
+      const response = await fetch('/api/me/privacy', {
+        method: 'PUT',
+        headers: { ...authHeaders(), 'Content-Type': 'application/json' },
+        body: JSON.stringify({
+          showEmail: profile.ShowEmail,
+          showPhone: profile.ShowPhone,
+          showEmployer: profile.ShowEmployer,
+          mapPrecision: profile.MapPrecision,
+        }),
+      })
+      const data = await response.json()
+      if (response.ok) {
+        setProfile(data.alumni)
+        toast.success('Privacy settings updated')
+      } else {
+        setError(data.error || 'Failed to update privacy settings')
+      }
+    } catch {
+      setError('Network error. Please try again.')
+    } finally {
+      setLoading(false)
+    }
+  }
+
+  const uploadProof = async (file: File) => {
+    const body = new FormData()
+    body.append('payment_proof', file)
+    setLoading(true)
+    setError('')
+    try {
+      const response = await fetch('/api/me/payment-proof', { method: 'POST', headers: authHeaders(), body })
+      const data = await response.json()
+      if (response.ok) {
+        setProfile(prev => prev && { ...prev, PaymentStatus: 'submitted', PaymentRejectionReason: '', PaymentProof: data.filename })
+        toast.success('Payment proof uploaded')
+      } else {
+        setError(data.error || 'Failed to upload payment proof')
+      }
+    } catch {
+      setError('Network error. Please try again.')
+    } finally {
+      setLoading(false)
+    }
+  }
+
+  const payOnline = async () => {
+    setLoading(true)
+    setError('')
+    try {
+      const response = await fetch('/api/me/checkout', { method: 'POST', headers: authHeaders() })
+      const data = await response.json()
+      if (response.ok) {
+        window.location.href = data.checkout_url
+      } else {
+        setError(data.error || 'Failed to start online payment')
+      }
+    } catch {
+      setError('Network error. Please try again.')
+    } finally {
+      setLoading(false)
+    }
+  }
+
+  const field = (label: string, key: keyof Profile, type = 'text') => (
+    <TextField
+      fullWidth
+      label={label}
+      type={type}
+      value={profile ? profile[key] : ''}
+      onChange={(e) => setProfile(prev => prev && { ...prev, [key]: e.target.value })}
+    />
+  )
+
+  const toggle = (label: string, key: 'ShowEmail' | 'ShowPhone' | 'ShowEmployer') => (
+    <FormControlLabel
+      label={label}
+      control={
+        <Switch
+          checked={profile ? profile[key] : false}
+          onChange={(e) => setProfile(prev => prev && { ...prev, [key]: e.target.checked })}
+        />
+      }
+    />
+  )
+
+  const status = paymentStatusLabels[profile?.PaymentStatus || 'none'] || paymentStatusLabels.none
+
+  return (
+    <Dialog open={open} onClose={onClose} maxWidth="sm" fullWidth>
+      <Box sx={{
+        background: 'linear-gradient(135deg, #667eea 0%, #764ba2 100%)',
+        color: 'white',
+        p: 3,
+      }}>
+        <Box sx={{ display: 'flex', justifyContent: 'space-between', alignItems: 'center' }}>
+          <Box sx={{ display: 'flex', alignItems: 'center', gap: 1 }}>
+            <AccountIcon sx={{ fontSize: 28 }} />
+            <Typography variant="h5" component="div" sx={{ fontWeight: 'bold' }}>
+              My Alumni Profile
+            </Typography>
+          </Box>
+          <IconButton onClick={onClose} size="small" sx={{ color: 'white' }}>
+            <CloseIcon />
+          </IconButton>
+        </Box>
+      </Box>
+
+      <DialogContent sx={{ p: 3 }}>
+        {error && (
+          <Alert severity="error" sx={{ mb: 3 }}>
+            {error}
+          </Alert>
+        )}
+
+        {loading && !profile && (
+          <Box sx={{ display: 'flex', justifyContent: 'center', py: 4 }}>
+            <CircularProgress />
+          </Box>
+        )}
+
+        {!profile && !loading && (
+          linkSent ? (
+            <Alert severity="success">
+              If <strong>{email}</strong> is registered, we've emailed you a sign-in link. It expires in 15 minutes.
+            </Alert>
+          ) : (
+            <Box component="form" onSubmit={requestLink} sx={{ display: 'flex', flexDirection: 'column', gap: 2 }}>
+              <Typography variant="body2" color="text.secondary">
+                Enter the email you registered with and we'll send you a link to sign in.
+              </Typography>
+              <TextField
+                fullWidth
+                type="email"
+                label="Email Address"
+                value={email}
+                onChange={(e) => setEmail(e.target.value)}
+                required
+              />
+              <Button type="submit" variant="contained" startIcon={<EmailIcon />} disabled={!email}>
+                Email me a sign-in link
+              </Button>
+            </Box>
+          )
+        )}
+
+        {profile && (
+          <Box sx={{ display: 'flex', flexDirection: 'column', gap: 2 }}>
+            <Typography variant="body2" color="text.secondary">
+              Signed in as <strong>{profile.Email}</strong>
+            </Typography>
+            <Box sx={{ display: 'flex', gap: 2 }}>
+              {field('First Name', 'FirstName')}
+              {field('Last Name', 'LastName')}
+            </Box>
+            {field('Phone Number', 'Phone')}
+            <Box sx={{ display: 'flex', gap: 2 }}>
+              {field('Year Graduated', 'Year', 'number')}
+              {field('Course', 'Course')}
+            </Box>
+            <Box sx={{ display: 'flex', gap: 2 }}>
+              {field('Company', 'Company')}
+              {field('Position', 'Position')}
+            </Box>
+            <Box sx={{ display: 'flex', gap: 2 }}>
+              {field('City', 'City')}
+              {field('Country', 'Country')}
+            </Box>
+            <Button variant="contained" startIcon={loading ? <CircularProgress size={20} /> : <SaveIcon />} onClick={saveProfile} disabled={loading}>
+              Save Profile
+            </Button>
+
+            <Divider />
+
+            <Typography variant="subtitle1" sx={{ fontWeight: 'bold' }}>
+              Directory Privacy
+            </Typography>
+            <Typography variant="body2" color="text.secondary">
+              Choose what other visitors see about you in the alumni directory and map.
+            </Typography>
+            <Box sx={{ display: 'flex', flexDirection: 'column' }}>
+              {toggle('Show my email address', 'ShowEmail')}
+              {toggle('Show my phone number', 'ShowPhone')}
+              {toggle('Show my company and position', 'ShowEmployer')}
+            </Box>
+            <TextField
+              select
+              fullWidth
+              label="Map location"
+              value={profile.MapPrecision || 'city'}
+              onChange={(e) => setProfile(prev => prev && { ...prev, MapPrecision: e.target.value })}
+            >
+              {mapPrecisionOptions.map(option => (
+                <MenuItem key={option.value} value={option.value}>
+                  {option.label}
+                </MenuItem>
+              ))}
+            </TextField>
+            <Button variant="outlined" startIcon={<LockIcon />} onClick={savePrivacy} disabled={loading}>
+              Save Privacy Settings
+            </Button>
+
+            <Divider />
+
+            <Box sx={{ display: 'flex', alignItems: 'center', gap: 1 }}>
+              <Typography variant="subtitle1" sx={{ fontWeight: 'bold' }}>
+                Registration Payment
+              </Typography>
+              <Chip label={status.label} color={status.color} size="small" />
+            </Box>
+            {profile.PaymentStatus === 'rejected' && profile.PaymentRejectionReason && (
+              <Alert severity="warning">{profile.PaymentRejectionReason}</Alert>
+            )}
+            {profile.PaymentStatus !== 'approved' && (
+              <Box sx={{ display: 'flex', gap: 2 }}>
+                <Button variant="outlined" component="label" startIcon={<UploadIcon />} disabled={loading} fullWidth>
+                  Upload Payment Proof
+                  <input
+                    type="file"
+                    hidden
+                    accept=".pdf,.jpg,.jpeg,.png,.heic,.heif"
+                    onChange={(e) => {
+                      const file = e.target.files?.[0]
+                      if (file) uploadProof(file)
+                    }}
+                  />
+                </Button>
+                <Button variant="outlined" startIcon={<PaymentIcon />} onClick={payOnline} disabled={loading} fullWidth>
+                  Pay Online
+                </Button>
+              </Box>
+            )}
+
+            <Button color="inherit" startIcon={<LogoutIcon />} onClick={signOut}>
+              Sign out
+            </Button>
+          </Box>
+        )}
+      </DialogContent>
+    </Dialog>
+  )
+}
+
+export default AlumniPortalMD
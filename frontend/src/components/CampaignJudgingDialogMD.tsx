@@ -0,0 +1,482 @@
+import { useState, useEffect } from 'react'
+import {
+  Box,
+  Typography,
+  Button,
+  TextField,
+  Table,
+  TableBody,
+  TableCell,
+  TableContainer,
+  TableHead,
+  TableRow,
+  Paper,
+  Chip,
+  IconButton,
+  Dialog,
+  DialogTitle,
+  DialogContent,
+  DialogActions,
+  Checkbox,
+  FormControlLabel,
+  Tabs,
+  Tab,
+  Alert,
+  Tooltip,
+  FormControl,
+  InputLabel,
+  Select,
+  MenuItem,
+} from '@mui/material'
+import {
+  Add as AddIcon,
+  Delete as DeleteIcon,
+  Save as SaveIcon,
+  Lock as LockIcon,
+  Public as PublishIcon,
+  EmojiEvents as AwardIcon,
+} from '@mui/icons-material'
+
+interface Campaign {
+  ID: number
+  Name: string
+  Categories: { Name: string }[]
+}
+
+interface NomineeGroup {
+  AlumniID?: number | null
+  FirstName: string
+  LastName: string
+  Year: number
+  Category: string
+  Count: number
+}
+
+interface ShortlistEntry {
+  ID: number
+  Category: string
+  AlumniID?: number | null
+  FirstName: string
+  LastName: string
+  Year: number
+  Nominations: number
+}
+
+interface Criterion {
+  ID: number
+  Name: string
+  Description: string
+  Weight: number
+}
+
+interface Judge {
+  ID: number
+  Username: string
+}
+
+interface Ranking {
+  Entry: ShortlistEntry & { Winner: boolean }
+  Score: number
+  Rank: number
+  CompleteJudges: number
+}
+
+interface CampaignJudgingDialogProps {
+  campaign: Campaign | null
+  onClose: () => void
+  authHeaders: { Authorization: string }
+}
+
+// Shortlisting, criteria, judges and results for one nomination campaign
+const CampaignJudgingDialogMD = ({ campaign, onClose, authHeaders }: CampaignJudgingDialogProps) => {
+  const [tab, setTab] = useState(0)
+  const [category, setCategory] = useState('')
+  const [tally, setTally] = useState<NomineeGroup[]>([])
+  const [shortlist, setShortlist] = useState<ShortlistEntry[]>([])
+  const [criteria, setCriteria] = useState<Criterion[]>([])
+  const [candidates, setCandidates] = useState<Judge[]>([])
+  const [judgeIds, setJudgeIds] = useState<number[]>([])
+  const [rankings, setRankings] = useState<Ranking[]>([])
+  const [judgeCount, setJudgeCount] = useState(0)
+  const [lockedAt, setLockedAt] = useState<string | null>(null)
+  const [publishedAt, setPublishedAt] = useState<string | null>(null)
+  const [error, setError] = useState('')
+  const [message, setMessage] = useState('')
+
+  const base = campaign ? `/api/admin/nomination-campaigns/${campaign.ID}` : ''
+  const locked = !!lockedAt
+
+  useEffect(() => {
+    if (!campaign) return
+    setTab(0)
+    setError('')
+    setMessage('')
+    setCategory(campaign.Categories[0]?.Name || '')
+    fetchShortlist()
+    fetchCriteria()
+    fetchJudges()
+    fetchRankings()
+  }, [campaign])
+
+  useEffect(() => {
+    if (campaign && category) fetchTally()
+  }, [campaign, category])
+
+  const request = async (url: string, options: RequestInit = {}) => {
+    setError('')
+    setMessage('')
+    const response = await fetch(url, {
+      ...options,
+      headers: { 'Content-Type': 'application/json', ...authHeaders },
+    })
+    const data = await response.json().catch(() => ({}))
+    if (!response.ok) {
+      setError(data.error || 'Request failed')
+      return null
+    }
+    return data
+  }
+
+  const fetchTally = async () => {
+    const data = await request(`${base}/tally?category=${encodeURIComponent(category)}`)
+    if (data) setTally(data.nominees || [])
+  }
+
+  const fetchShortlist = async () => {
+    const data = await request(`${base}/shortlist`)
+    if (data) setShortlist(data.shortlist || [])
+  }
+
+  const fetchCriteria = async () => {
+    const data = await request(`${base}/criteria`)
+    if (data) setCriteria(data.criteria || [])
+  }
+
+  const fetchJudges = async () => {
+    const [candidateData, judgeData] = await Promise.all([
+      request('/api/admin/nomination-campaigns/judge-candidates'),
+      request(`${base}/judges`),
+    ])
+    if (candidateData) setCandidates(candidateData.judges || [])
+    if (judgeData) setJudgeIds((judgeData.judges || []).map((judge: Judge) => judge.ID))
+  }
+
+  const fetchRankings = async () => {
+    const data = await request(`${base}/rankings`)
+    if (data) {
+      setRankings(data.rankings || [])
+      setJudgeCount(data.judges || 0)
+      setLockedAt(data.locked_at || null)
+      setPublishedAt(data.published_at || null)
+    }
+  }
+
+  const isShortlisted = (nominee: NomineeGroup) => shortlist.some((entry) =>
+    entry.Category === nominee.Category && (nominee.AlumniID
+      ? entry.AlumniID === nominee.AlumniID
+      : !entry.AlumniID && entry.FirstName === nominee.FirstName && entry.LastName === nominee.LastName && entry.Year === nominee.Year))
+
+  const addToShortlist = async (nominee: NomineeGroup) => {
+    const data = await request(`${base}/shortlist`, {
+      method: 'POST',
+      body: JSON.stringify({
+        category: nominee.Category,
+        alumni_id: nominee.AlumniID || null,
+        first_name: nominee.FirstName,
+        last_name: nominee.LastName,
+        year: nominee.Year,
+      }),
+    })
+    if (data) fetchShortlist()
+  }
+
+  const removeFromShortlist = async (entry: ShortlistEntry) => {
+    if (!window.confirm(`Remove ${entry.FirstName} ${entry.LastName} from the shortlist? Their scores are deleted.`)) return
+    const data = await request(`${base}/shortlist/${entry.ID}`, { method: 'DELETE' })
+    if (data) fetchShortlist()
+  }
+
+  const updateCriterion = (index: number, changes: Partial<Criterion>) => {
+    setCriteria(criteria.map((criterion, i) => (i === index ? { ...criterion, ...changes } : criterion)))
+  }
+
+  const saveCriteria = async () => {
+    const data = await request(`${base}/criteria`, {
+      method: 'PUT',
+      body: JSON.stringify({
+        criteria: criteria.map((criterion) => ({
+          id: criterion.ID,
+          name: criterion.Name,
+          description: criterion.Description,
+          weight: criterion.Weight,
+        })),
+      }),
+    })
+    if (data) {
+      setCriteria(data.criteria || [])
+      setMessage('Criteria saved')
+    }
+  }
+
+  const saveJudges = async () => {
+    const data = await request(`${base}/judges`, {
+      method: 'PUT',
+      body: JSON.stringify({ admin_ids: judgeIds }),
+    })
+    if (data) setMessage('Judges saved')
+  }
+
+  const lockResults = async () => {
+    if (!window.confirm('Lock the results? Scores, the shortlist, criteria and judges can no longer change.')) return
+    const data = await request(`${base}/lock`, { method: 'POST' })
+    if (data) fetchRankings()
+  }
+
+  const publishResults = async () => {
+    if (!window.confirm('Publish the winners on the awards page?')) return
+    const data = await request(`${base}/publish`, { method: 'POST' })
+    if (data) fetchRankings()
+  }
+
+  const totalWeight = criteria.reduce((sum, criterion) => sum + (criterion.Weight || 0), 0)
+
+  return (
+    <Dialog open={!!campaign} onClose={onClose} maxWidth="lg" fullWidth>
+      <DialogTitle sx={{ display: 'flex', alignItems: 'center', gap: 1 }}>
+        <AwardIcon color="warning" />
+        Judging: {campaign?.Name}
+        {locked && <Chip label="Locked" size="small" icon={<LockIcon />} sx={{ ml: 1 }} />}
+        {publishedAt && <Chip label="Published" size="small" color="success" sx={{ ml: 1 }} />}
+      </DialogTitle>
+      <Box sx={{ borderBottom: 1, borderColor: 'divider', px: 3 }}>
+        <Tabs value={tab} onChange={(_, value) => { setTab(value); if (value === 3) fetchRankings() }}>
+          <Tab label={`Shortlist (${shortlist.length})`} />
+          <Tab label={`Criteria (${criteria.length})`} />
+          <Tab label={`Judges (${judgeIds.length})`} />
+          <Tab label="Results" />
+        </Tabs>
+      </Box>
+      <DialogContent>
+        {error && <Alert severity="error" sx={{ mb: 2 }}>{error}</Alert>}
+        {message && <Alert severity="success" sx={{ mb: 2 }}>{message}</Alert>}
+
+        {/* Shortlist */}
+        {tab === 0 && campaign && (
+          <Box>
+            <FormControl size="small" sx={{ minWidth: 300, mb: 2 }}>
+              <InputLabel>Category</InputLabel>
+              <Select value={category} label="Category" onChange={(e) => setCategory(e.target.value)}>
+                {campaign.Categories.map((c) => (
+                  <MenuItem key={c.Name} value={c.Name}>{c.Name}</MenuItem>
+                ))}
+              </Select>
+            </FormControl>
+
+            <Typography variant="subtitle1" sx={{ fontWeight: 'bold', mb: 1 }}>Nomination Tally</Typography>
+            <TableContainer component={Paper} sx={{ mb: 3 }}>
+              <Table size="small">
+                <TableHead>
+                  <TableRow>
+                    <TableCell>Nominee</TableCell>
+                    <TableCell>Year</TableCell>
+                    <TableCell>Nominations</TableCell>
+                    <TableCell>Actions</TableCell>
+                  </TableRow>
+                </TableHead>
+                <TableBody>
+                  {tally.map((nominee) => (
+                    <TableRow key={`${nominee.AlumniID || ''}-${nominee.FirstName}-${nominee.LastName}-${nominee.Year}`} hover>
+                      <TableCell>{nominee.FirstName} {nominee.LastName}</TableCell>
+                      <TableCell>{nominee.Year}</TableCell>
+                      <TableCell>{nominee.Count}</TableCell>
+                      <TableCell>
+                        {isShortlisted(nominee) ? (
+                          <Chip label="Shortlisted" size="small" color="success" />
+                        ) : (
+                          <Button size="small" startIcon={<AddIcon />} disabled={locked} onClick={() => addToShortlist(nominee)}>
+                            Shortlist
+                          </Button>
+                        )}
+                      </TableCell>
+                    </TableRow>
+                  ))}
+                  {tally.length === 0 && (
+                    <TableRow>
+                      <TableCell colSpan={4} align="center">No nominations in this category yet</TableCell>
+                    </TableRow>
+                  )}
+                </TableBody>
+              </Table>
+            </TableContainer>
+
+            <Typography variant="subtitle1" sx={{ fontWeight: 'bold', mb: 1 }}>Shortlist</Typography>
+            <TableContainer component={Paper}>
+              <Table size="small">
+                <TableHead>
+                  <TableRow>
+                    <TableCell>Category</TableCell>
+                    <TableCell>Nominee</TableCell>
+                    <TableCell>Year</TableCell>
+                    <TableCell>Nominations</TableCell>
+                    <TableCell>Actions</TableCell>
+                  </TableRow>
+                </TableHead>
+                <TableBody>
+                  {shortlist.map((entry) => (
+                    <TableRow key={entry.ID} hover>
+                      <TableCell>{entry.Category}</TableCell>
+                      <TableCell>{entry.FirstName} {entry.LastName}</TableCell>
+                      <TableCell>{entry.Year}</TableCell>
+                      <TableCell>{entry.Nominations}</TableCell>
+                      <TableCell>
+                        <Tooltip title="Remove from shortlist">
+                          <span>
+                            <IconButton size="small" color="error" disabled={locked} onClick={() => removeFromShortlist(entry)}>
+                              <DeleteIcon />
+                            </IconButton>
+                          </span>
+                        </Tooltip>
+                      </TableCell>
+                    </TableRow>
+                  ))}
+                </TableBody>
+              </Table>
+            </TableContainer>
+          </Box>
+        )}
+
+        {/* Criteria */}
+        {tab === 1 && (
+          <Box>
+            <Typography variant="body2" color="text.secondary" sx={{ mb: 2 }}>
+              Judges score every shortlisted nominee from 0 to 10 on each criterion. A criterion counts in proportion to its weight.
+            </Typography>
+            {criteria.map((criterion, index) => (
+              <Paper key={criterion.ID || `new-${index}`} variant="outlined" sx={{ p: 2, mb: 2, display: 'flex', gap: 2, alignItems: 'flex-start' }}>
+                <TextField
+                  size="small"
+                  label="Criterion"
+                  value={criterion.Name}
+                  onChange={(e) => updateCriterion(index, { Name: e.target.value })}
+                  disabled={locked}
+                  sx={{ flex: 2 }}
+                />
+                <TextField
+                  size="small"
+                  label="Description"
+                  value={criterion.Description}
+                  onChange={(e) => updateCriterion(index, { Description: e.target.value })}
+                  disabled={locked}
+                  sx={{ flex: 3 }}
+                />
+                <TextField
+                  size="small"
+                  type="number"
+                  label="Weight"
+                  value={criterion.Weight}
+                  onChange={(e) => updateCriterion(index, { Weight: parseFloat(e.target.value) || 0 })}
+                  helperText={totalWeight > 0 ? `${Math.round((criterion.Weight / totalWeight) * 100)}% of the score` : ''}
+                  inputProps={{ min: 0, step: 0.5 }}
+                  disabled={locked}
+                  sx={{ flex: 1 }}
+                />
+                <IconButton color="error" disabled={locked} onClick={() => setCriteria(criteria.filter((_, i) => i !== index))}>
+                  <DeleteIcon />
+                </IconButton>
+              </Paper>
+            ))}
+            <Box sx={{ display: 'flex', gap: 1 }}>
+              <Button
+                startIcon={<AddIcon />}
+                disabled={locked}
+                onClick={() => setCriteria([...criteria, { ID: 0, Name: '', Description: '', Weight: 1 }])}
+              >
+                Add Criterion
+              </Button>
+              <Button variant="contained" startIcon={<SaveIcon />} disabled={locked} onClick={saveCriteria}>
+                Save Criteria
+              </Button>
+            </Box>
+          </Box>
+        )}
+
+        {/* Judges */}
+        {tab === 2 && (
+          <Box>
+            <Typography variant="body2" color="text.secondary" sx={{ mb: 2 }}>
+              Admins with the judge or awards committee role can be assigned.
+            </Typography>
+            {candidates.map((candidate) => (
+              <FormControlLabel
+                key={candidate.ID}
+                sx={{ display: 'block' }}
+                control={
+                  <Checkbox
+                    checked={judgeIds.includes(candidate.ID)}
+                    disabled={locked}
+                    onChange={(e) => setJudgeIds(e.target.checked
+                      ? [...judgeIds, candidate.ID]
+                      : judgeIds.filter((id) => id !== candidate.ID))}
+                  />
+                }
+                label={candidate.Username}
+              />
+            ))}
+            {candidates.length === 0 && (
+              <Typography variant="body2" color="text.secondary">No admins can judge yet</Typography>
+            )}
+            <Button variant="contained" startIcon={<SaveIcon />} disabled={locked} onClick={saveJudges} sx={{ mt: 2 }}>
+              Save Judges
+            </Button>
+          </Box>
+        )}
+
+        {/* Results */}
+        {tab === 3 && (
+          <Box>
+            <TableContainer component={Paper} sx={{ mb: 2 }}>
+              <Table size="small">
+                <TableHead>
+                  <TableRow>
+                    <TableCell>Category</TableCell>
+                    <TableCell>Rank</TableCell>
+                    <TableCell>Nominee</TableCell>
+                    <TableCell>Score</TableCell>
+                    <TableCell>Judges Done</TableCell>
+                  </TableRow>
+                </TableHead>
+                <TableBody>
+                  {rankings.map((ranking) => (
+                    <TableRow key={ranking.Entry.ID} hover>
+                      <TableCell>{ranking.Entry.Category}</TableCell>
+                      <TableCell>
+                        {ranking.Rank}
+                        {locked && ranking.Entry.Winner && <AwardIcon fontSize="small" color="warning" sx={{ ml: 1, verticalAlign: 'middle' }} />}
+                      </TableCell>
+                      <TableCell>{ranking.Entry.FirstName} {ranking.Entry.LastName}</TableCell>
+                      <TableCell>{ranking.Score.toFixed(2)}</TableCell>
+                      <TableCell>{ranking.CompleteJudges} of {judgeCount}</TableCell>
+                    </TableRow>
+                  ))}
+                </TableBody>
+              </Table>
+            </TableContainer>
+            <Box sx={{ display: 'flex', gap: 1 }}>
+              <Button variant="contained" color="warning" startIcon={<LockIcon />} disabled={locked} onClick={lockResults}>
+                Lock Results
+              </Button>
+              <Button variant="contained" color="success" startIcon={<PublishIcon />} disabled={!locked || !!publishedAt} onClick={publishResults}>
+                Publish Winners
+              </Button>
+            </Box>
+          </Box>
+        )}
+      </DialogContent>
+      <DialogActions sx={{ p: 3 }}>
+        <Button onClick={onClose} color="inherit">Close</Button>
+      </DialogActions>
+    </Dialog>
+  )
+}
+
+export default CampaignJudgingDialogMD
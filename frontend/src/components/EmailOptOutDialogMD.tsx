@@ -0,0 +1,90 @@
+import { useState } from 'react'
+import {
+  Dialog,
+  DialogTitle,
+  DialogContent,
+  DialogActions,
+  Button,
+  Typography,
+  Alert,
+  CircularProgress,
+} from '@mui/material'
+import { UnsubscribeOutlined as UnsubscribeIcon } from '@mui/icons-material'
+
+interface EmailOptOutDialogProps {
+  open: boolean
+  token: string
+  onClose: () => void
+}
+
+// Confirms an unsubscribe link from a nomination email. Nothing changes
+// until the button is pressed, so link scanners cannot unsubscribe anyone.
+const EmailOptOutDialogMD = ({ open, token, onClose }: EmailOptOutDialogProps) => {
+  const [loading, setLoading] = useState(false)
+  const [error, setError] = useState('')
+  const [done, setDone] = useState('')
+
+  const handleClose = () => {
+    // The token stays valid, but there is no need to keep it in the address bar
+    window.history.replaceState({}, '', window.location.pathname)
+    onClose()
+  }
+
+  const optOut = async () => {
+    setLoading(true)
+    setError('')
+    try {
+      const response = await fetch('/api/email/opt-out', {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify({ token }),
+      })
+      const data = await response.json().catch(() => ({}))
+      if (response.ok) {
+        setDone(data.email ? `${data.email} will no longer receive emails about nominations.` : data.message)
+      } else {
+        setError(data.error || 'Failed to unsubscribe')
+      }
+    } catch (err) {
+      setError('Network error. Please try again.')
+    } finally {
+      setLoading(false)
+    }
+  }
+
+  return (
+    <Dialog open={open} onClose={handleClose} maxWidth="xs" fullWidth>
+      <DialogTitle sx={{ display: 'flex', alignItems: 'center', gap: 1 }}>
+        <UnsubscribeIcon color="primary" />
+        Unsubscribe
+      </DialogTitle>
+      <DialogContent>
+        {error && <Alert severity="error" sx={{ mb: 2 }}>{error}</Alert>}
+        {done ? (
+          <Alert severity="success">{done}</Alert>
+        ) : (
+          <Typography variant="body1">
+            Stop receiving emails about Outstanding Alumni nominations? Verification codes and other account emails are still sent.
+          </Typography>
+        )}
+      </DialogContent>
+      <DialogActions sx={{ p: 3 }}>
+        <Button onClick={handleClose} color="inherit">
+          {done ? 'Close' : 'Cancel'}
+        </Button>
+        {!done && (
+          <Button
+            variant="contained"
+            onClick={optOut}
+            disabled={loading}
+            startIcon={loading ? <CircularProgress size={20} /> : undefined}
+          >
+            Unsubscribe
+          </Button>
+        )}
+      </DialogActions>
+    </Dialog>
+  )
+}
+
+export default EmailOptOutDialogMD
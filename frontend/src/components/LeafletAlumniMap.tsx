@@ -11,18 +11,19 @@ L.Icon.Default.mergeOptions({
   shadowUrl: 'https://cdnjs.cloudflare.com/ajax/libs/leaflet/1.7.1/images/marker-shadow.png',
 })
 
+// Fields an alumnus keeps private are left out of the public list
 interface Alumni {
   ID: number
   FirstName: string
   LastName: string
-  Email: string
-  Phone: string
+  Email?: string
+  Phone?: string
   Year: number
   Course: string
-  Company: string
-  Position: string
-  Country: string
-  City: string
+  Company?: string
+  Position?: string
+  Country?: string
+  City?: string
   Latitude?: number
   Longitude?: number
   Paid?: boolean
@@ -178,7 +179,7 @@ const LeafletAlumniMap = ({ refreshTrigger }: LeafletAlumniMapProps) => {
       
       // If no coordinates, try to get default country coordinates
       if ((!latitude || latitude === 0) && (!longitude || longitude === 0)) {
-        const coords = getCountryCoordinates(alumnus.Country)
+        const coords = alumnus.Country ? getCountryCoordinates(alumnus.Country) : null
         if (coords) {
           latitude = coords.lat
           longitude = coords.lng
@@ -193,7 +194,7 @@ const LeafletAlumniMap = ({ refreshTrigger }: LeafletAlumniMapProps) => {
           locationMap.get(key)!.alumni.push(alumnus)
         } else {
           locationMap.set(key, {
-            country: alumnus.Country,
+            country: alumnus.Country || '',
             city: alumnus.City || 'Unknown City',
             latitude: latitude,
             longitude: longitude,
@@ -337,9 +338,11 @@ const LeafletAlumniMap = ({ refreshTrigger }: LeafletAlumniMapProps) => {
                               <div className="font-medium text-gray-900">
                                 {alumnus.FirstName} {alumnus.LastName}
                               </div>
-                              <div className="text-sm text-gray-600">
-                                {alumnus.Position} at {alumnus.Company}
-                              </div>
+                              {alumnus.Company && (
+                                <div className="text-sm text-gray-600">
+                                  {alumnus.Position ? `${alumnus.Position} at ${alumnus.Company}` : alumnus.Company}
+                                </div>
+                              )}
                               <div className="text-xs text-gray-500">
                                 Class of {alumnus.Year} • {alumnus.Course}
                               </div>
@@ -366,14 +369,14 @@ const LeafletAlumniMap = ({ refreshTrigger }: LeafletAlumniMapProps) => {
           <div className="mt-12 grid grid-cols-1 md:grid-cols-3 gap-6">
             <div className="bg-white rounded-xl shadow-lg p-6 text-center">
               <div className="text-3xl font-bold text-blue-600 mb-2">
-                {new Set(alumni.map(a => a.Country)).size}
+                {new Set(alumni.map(a => a.Country).filter(Boolean)).size}
               </div>
               <div className="text-gray-600">Countries Represented</div>
             </div>
             
             <div className="bg-white rounded-xl shadow-lg p-6 text-center">
               <div className="text-3xl font-bold text-green-600 mb-2">
-                {new Set(alumni.map(a => a.Company)).size}
+                {new Set(alumni.map(a => a.Company).filter(Boolean)).size}
               </div>
               <div className="text-gray-600">Companies</div>
             </div>
@@ -0,0 +1,338 @@
+import { useEffect, useState } from 'react'
+import toast from 'react-hot-toast'
+import {
+  Box,
+  Typography,
+  Button,
+  TextField,
+  Alert,
+  Card,
+  CardContent,
+  Chip,
+  CircularProgress,
+  FormControl,
+  InputLabel,
+  Select,
+  MenuItem,
+  Grid,
+  Divider,
+} from '@mui/material'
+import {
+  Edit as EditIcon,
+  Undo as WithdrawIcon,
+  History as HistoryIcon,
+  Link as LinkIcon,
+} from '@mui/icons-material'
+
+interface CampaignCategory {
+  Name: string
+}
+
+interface OwnNomination {
+  ID: number
+  CampaignID?: number | null
+  AlumniID?: number | null
+  FirstName: string
+  LastName: string
+  NominatedEmail: string
+  Year: number
+  Category: string
+  CreatedAt: string
+}
+
+interface NominationChange {
+  ID: number
+  Action: string
+  ChangedBy: string
+  FirstName: string
+  LastName: string
+  NominatedEmail: string
+  Year: number
+  Category: string
+  CreatedAt: string
+}
+
+interface MyNominationsProps {
+  verificationToken: string
+  categories: CampaignCategory[]
+}
+
+const actionLabels: Record<string, string> = {
+  created: 'Submitted',
+  updated: 'Edited',
+  withdrawn: 'Withdrawn',
+  deleted: 'Removed by an administrator',
+}
+
+// Lists the verified nominator's own nominations, which they can edit or
+// withdraw while the campaign is open
+const MyNominationsMD = ({ verificationToken, categories }: MyNominationsProps) => {
+  const [nominations, setNominations] = useState<OwnNomination[]>([])
+  const [openCampaignId, setOpenCampaignId] = useState<number | null>(null)
+  const [loading, setLoading] = useState(true)
+  const [error, setError] = useState('')
+  const [editing, setEditing] = useState<OwnNomination | null>(null)
+  const [saving, setSaving] = useState(false)
+  const [history, setHistory] = useState<{ id: number, changes: NominationChange[] } | null>(null)
+
+  const headers = {
+    'Content-Type': 'application/json',
+    'X-Verification-Token': verificationToken,
+  }
+
+  useEffect(() => {
+    fetchNominations()
+  }, [verificationToken])
+
+  const fetchNominations = async () => {
+    setLoading(true)
+    try {
+      const response = await fetch('/api/nominations/mine', { headers })
+      const data = await response.json().catch(() => ({}))
+      if (response.ok) {
+        setNominations(data.nominations || [])
+        setOpenCampaignId(data.open_campaign_id ?? null)
+      } else {
+        setError(data.error || 'Failed to load your nominations')
+      }
+    } catch (err) {
+      setError('Network error. Please try again.')
+    } finally {
+      setLoading(false)
+    }
+  }
+
+  const saveEdit = async () => {
+    if (!editing) return
+    setSaving(true)
+    setError('')
+    try {
+      const response = await fetch(`/api/nominations/mine/${editing.ID}`, {
+        method: 'PUT',
+        headers,
+        body: JSON.stringify({
+          alumniId: editing.AlumniID || undefined,
+          firstName: editing.FirstName,
+          lastName: editing.LastName,
+          nominatedEmail: editing.NominatedEmail,
+          year: editing.Year,
+          category: editing.Category,
+        }),
+      })
+      const data = await response.json().catch(() => ({}))
+      if (response.ok) {
+        toast.success('Nomination updated')
+        setEditing(null)
+        setHistory(null)
+        fetchNominations()
+      } else {
+        setError(data.error || 'Failed to update nomination')
+      }
+    } catch (err) {
+      setError('Network error. Please try again.')
+    } finally {
+      setSaving(false)
+    }
+  }
+
+  const withdraw = async (nomination: OwnNomination) => {
+    if (!window.confirm(`Withdraw your nomination of ${nomination.FirstName} ${nomination.LastName} for ${nomination.Category}?`)) return
+    setError('')
+    try {
+      const response = await fetch(`/api/nominations/mine/${nomination.ID}`, { method: 'DELETE', headers })
+      const data = await response.json().catch(() => ({}))
+      if (response.ok) {
+        toast.success('Nomination withdrawn')
+        setHistory(null)
+        fetchNominations()
+      } else {
+        setError(data.error || 'Failed to withdraw nomination')
+      }
+    } catch (err) {
+      setError('Network error. Please try again.')
+    }
+  }
+
+  const toggleHistory = async (nomination: OwnNomination) => {
+    if (history?.id === nomination.ID) {
+      setHistory(null)
+      return
+    }
+    try {
+      const response = await fetch(`/api/nominations/mine/${nomination.ID}/history`, { headers })
+      const data = await response.json().catch(() => ({}))
+      if (response.ok) {
+        setHistory({ id: nomination.ID, changes: data.history || [] })
+      } else {
+        setError(data.error || 'Failed to load history')
+      }
+    } catch (err) {
+      setError('Network error. Please try again.')
+    }
+  }
+
+  if (loading) {
+    return (
+      <Box sx={{ display: 'flex', justifyContent: 'center', py: 4 }}>
+        <CircularProgress />
+      </Box>
+    )
+  }
+
+  return (
+    <Box sx={{ py: 2 }}>
+      {error && <Alert severity="error" sx={{ mb: 2 }}>{error}</Alert>}
+
+      {nominations.length === 0 && (
+        <Typography variant="body1" color="text.secondary" sx={{ textAlign: 'center', py: 4 }}>
+          You have no nominations
+        </Typography>
+      )}
+
+      {nominations.map((nomination) => {
+        const editable = !!openCampaignId && nomination.CampaignID === openCampaignId
+        const isEditing = editing?.ID === nomination.ID
+
+        return (
+          <Card key={nomination.ID} variant="outlined" sx={{ mb: 2 }}>
+            <CardContent>
+              {isEditing && editing ? (
+                <Grid container spacing={2}>
+                  {editing.AlumniID && (
+                    <Grid item xs={12}>
+                      <Alert
+                        severity="info"
+                        icon={<LinkIcon />}
+                        action={
+                          <Button color="inherit" size="small" onClick={() => setEditing({ ...editing, AlumniID: null })}>
+                            Not this person
+                          </Button>
+                        }
+                      >
+                        This nominee is linked to their alumni record.
+                      </Alert>
+                    </Grid>
+                  )}
+                  <Grid item xs={12}>
+                    <FormControl fullWidth size="small">
+                      <InputLabel>Award Category</InputLabel>
+                      <Select
+                        value={editing.Category}
+                        label="Award Category"
+                        onChange={(e) => setEditing({ ...editing, Category: e.target.value })}
+                      >
+                        {categories.map((category) => (
+                          <MenuItem key={category.Name} value={category.Name}>{category.Name}</MenuItem>
+                        ))}
+                      </Select>
+                    </FormControl>
+                  </Grid>
+                  <Grid item xs={12} sm={6}>
+                    <TextField
+                      fullWidth
+                      size="small"
+                      label="First Name"
+                      value={editing.FirstName}
+                      disabled={!!editing.AlumniID}
+                      onChange={(e) => setEditing({ ...editing, FirstName: e.target.value })}
+                    />
+                  </Grid>
+                  <Grid item xs={12} sm={6}>
+                    <TextField
+                      fullWidth
+                      size="small"
+                      label="Last Name"
+                      value={editing.LastName}
+                      disabled={!!editing.AlumniID}
+                      onChange={(e) => setEditing({ ...editing, LastName: e.target.value })}
+                    />
+                  </Grid>
+                  <Grid item xs={12} sm={6}>
+                    <TextField
+                      fullWidth
+                      size="small"
+                      type="number"
+                      label="Graduation Year"
+                      value={editing.Year}
+                      disabled={!!editing.AlumniID}
+                      onChange={(e) => setEditing({ ...editing, Year: parseInt(e.target.value) || 0 })}
+                    />
+                  </Grid>
+                  <Grid item xs={12} sm={6}>
+                    <TextField
+                      fullWidth
+                      size="small"
+                      type="email"
+                      label="Nominee Email"
+                      value={editing.NominatedEmail}
+                      onChange={(e) => setEditing({ ...editing, NominatedEmail: e.target.value })}
+                    />
+                  </Grid>
+                  <Grid item xs={12} sx={{ display: 'flex', justifyContent: 'flex-end', gap: 1 }}>
+                    <Button onClick={() => setEditing(null)} disabled={saving} color="inherit">
+                      Cancel
+                    </Button>
+                    <Button
+                      variant="contained"
+                      onClick={saveEdit}
+                      disabled={saving || !editing.FirstName || !editing.LastName || !editing.Category || !editing.Year}
+                      startIcon={saving ? <CircularProgress size={20} /> : null}
+                      sx={{ backgroundColor: '#d97706', '&:hover': { backgroundColor: '#b45309' } }}
+                    >
+                      Save Changes
+                    </Button>
+                  </Grid>
+                </Grid>
+              ) : (
+                <Box sx={{ display: 'flex', justifyContent: 'space-between', alignItems: 'flex-start', gap: 2 }}>
+                  <Box>
+                    <Typography variant="subtitle1" sx={{ fontWeight: 'bold' }}>
+                      {nomination.FirstName} {nomination.LastName}
+                      {nomination.AlumniID && <LinkIcon fontSize="small" color="primary" sx={{ ml: 1, verticalAlign: 'middle' }} />}
+                    </Typography>
+                    <Typography variant="body2" color="text.secondary">
+                      Class of {nomination.Year}{nomination.NominatedEmail ? ` · ${nomination.NominatedEmail}` : ''}
+                    </Typography>
+                    <Box sx={{ display: 'flex', gap: 1, mt: 1 }}>
+                      <Chip label={nomination.Category} size="small" color="warning" />
+                      {!editable && <Chip label="Campaign closed" size="small" />}
+                    </Box>
+                  </Box>
+                  <Box sx={{ display: 'flex', flexWrap: 'wrap', gap: 1, justifyContent: 'flex-end' }}>
+                    {editable && (
+                      <Button size="small" startIcon={<EditIcon />} onClick={() => { setError(''); setEditing({ ...nomination }) }}>
+                        Edit
+                      </Button>
+                    )}
+                    {editable && (
+                      <Button size="small" color="error" startIcon={<WithdrawIcon />} onClick={() => withdraw(nomination)}>
+                        Withdraw
+                      </Button>
+                    )}
+                    <Button size="small" color="inherit" startIcon={<HistoryIcon />} onClick={() => toggleHistory(nomination)}>
+                      History
+                    </Button>
+                  </Box>
+                </Box>
+              )}
+
+              {history?.id === nomination.ID && (
+                <Box sx={{ mt: 2 }}>
+                  <Divider sx={{ mb: 1 }} />
+                  {history.changes.map((change) => (
+                    <Typography key={change.ID} variant="body2" color="text.secondary" sx={{ py: 0.5 }}>
+                      <strong>{actionLabels[change.Action] || change.Action}</strong>{' '}
+                      {new Date(change.CreatedAt).toLocaleString()}: {change.FirstName} {change.LastName}, Class of {change.Year}, {change.Category}
+                    </Typography>
+                  ))}
+                </Box>
+              )}
+            </CardContent>
+          </Card>
+        )
+      })}
+    </Box>
+  )
+}
+
+export default MyNominationsMD
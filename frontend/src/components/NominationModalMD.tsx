@@ -1,5 +1,6 @@
-import { useState } from 'react'
+import { useEffect, useState } from 'react'
 import toast from 'react-hot-toast'
+import MyNominationsMD from './MyNominationsMD'
 import {
   Dialog,
   DialogTitle,
@@ -24,6 +25,7 @@ import {
   Chip,
   IconButton,
   Paper,
+  Autocomplete,
 } from '@mui/material'
 import {
   Close as CloseIcon,
@@ -41,6 +43,7 @@ interface NominationModalProps {
 }
 
 interface Nomination {
+  alumniId?: number
   firstName: string
   lastName: string
   nominatedEmail: string
@@ -49,13 +52,56 @@ interface Nomination {
   category: string
 }
 
+interface NomineeCandidate {
+  ID: number
+  FirstName: string
+  LastName: string
+  Year: number
+  Course: string
+}
+
+interface CampaignCategory {
+  Name: string
+  Description: string
+  MinYearsSinceGraduation: number
+  MaxYearsSinceGraduation: number
+  RequireNomineeEmail: boolean
+}
+
+interface NominationCampaign {
+  ID: number
+  Name: string
+  OpensAt: string
+  ClosesAt: string
+  Categories: CampaignCategory[]
+}
+
 const steps = ['Email Verification', 'Nomination Form']
 
+// Describes a category's rules for the nominee, or returns an empty string
+const categoryRules = (category: CampaignCategory) => {
+  const rules: string[] = []
+  if (category.MinYearsSinceGraduation > 0) {
+    rules.push(`graduated at least ${category.MinYearsSinceGraduation} years ago`)
+  }
+  if (category.MaxYearsSinceGraduation > 0) {
+    rules.push(`graduated at most ${category.MaxYearsSinceGraduation} years ago`)
+  }
+  if (category.RequireNomineeEmail) {
+    rules.push("the nominee's email is required")
+  }
+  return rules.join('; ')
+}
+
 const NominationModalMD = ({ open, onClose }: NominationModalProps) => {
   const [activeStep, setActiveStep] = useState(0)
   const [email, setEmail] = useState('')
   const [loading, setLoading] = useState(false)
   const [error, setError] = useState('')
+  const [otpSent, setOtpSent] = useState(false)
+  const [otpCode, setOtpCode] = useState('')
+  const [verificationToken, setVerificationToken] = useState('')
+  const [showMine, setShowMine] = useState(false)
 
   const [formData, setFormData] = useState<Nomination>({
     firstName: '',
@@ -66,17 +112,52 @@ const NominationModalMD = ({ open, onClose }: NominationModalProps) => {
     category: ''
   })
 
-  const categories = [
-    'Outstanding Alumni in Technology',
-    'Outstanding Alumni in Business',
-    'Outstanding Alumni in Education',
-    'Outstanding Alumni in Public Service',
-    'Outstanding Alumni in Healthcare',
-    'Outstanding Alumni in Arts & Culture',
-    'Outstanding Alumni in Social Impact',
-    'Young Achiever Award',
-    'Lifetime Achievement Award'
-  ]
+  const [campaign, setCampaign] = useState<NominationCampaign | null>(null)
+  const [campaignLoaded, setCampaignLoaded] = useState(false)
+
+  useEffect(() => {
+    if (!open) return
+
+    setCampaignLoaded(false)
+    fetch('/api/nominations/campaign')
+      .then((response) => response.json())
+      .then((data) => setCampaign(data.open ? data.campaign : null))
+      .catch(() => setCampaign(null))
+      .finally(() => setCampaignLoaded(true))
+  }, [open])
+
+  const [nomineeSearch, setNomineeSearch] = useState('')
+  const [candidates, setCandidates] = useState<NomineeCandidate[]>([])
+  const [selectedNominee, setSelectedNominee] = useState<NomineeCandidate | null>(null)
+
+  // Suggest alumni as the nominator types, once they are verified
+  useEffect(() => {
+    if (!verificationToken || nomineeSearch.trim().length < 2) {
+      setCandidates([])
+      return
+    }
+
+    const timer = setTimeout(() => {
+      fetch(`/api/nominees/search?q=${encodeURIComponent(nomineeSearch.trim())}`, {
+        headers: { 'X-Verification-Token': verificationToken },
+      })
+        .then((response) => response.json())
+        .then((data) => setCandidates(data.candidates || []))
+        .catch(() => setCandidates([]))
+    }, 300)
+    return () => clearTimeout(timer)
+  }, [nomineeSearch, verificationToken])
+
+  const selectNominee = (candidate: NomineeCandidate | null) => {
+    setSelectedNominee(candidate)
+    setFormData(prev => candidate
+      ? { ...prev, alumniId: candidate.ID, firstName: candidate.FirstName, lastName: candidate.LastName, year: candidate.Year }
+      : { ...prev, alumniId: undefined })
+  }
+
+  const categories = campaign?.Categories ?? []
+  const selectedCategory = categories.find((category) => category.Name === formData.category)
+  const nominationsClosed = campaignLoaded && !campaign
 
   const checkAlumniEmail = async () => {
     if (!email) {
@@ -99,9 +180,20 @@ const NominationModalMD = ({ open, onClose }: NominationModalProps) => {
 
       if (response.ok) {
         if (data.exists) {
-          // User is registered, proceed to nomination form
-          setFormData(prev => ({ ...prev, nominatorEmail: email }))
-          setActiveStep(1)
+          // User is registered, send a verification code to their email
+          const otpResponse = await fetch('/api/otp/send', {
+            method: 'POST',
+            headers: {
+              'Content-Type': 'application/json',
+            },
+            body: JSON.stringify({ email, purpose: 'nomination' }),
+          })
+          const otpData = await otpResponse.json()
+          if (otpResponse.ok) {
+            setOtpSent(true)
+          } else {
+            setError(otpData.error || 'Failed to send verification code')
+          }
         } else {
           // User is not registered, show error message
           setError('You must be a registered alumni to submit nominations. Please register first before nominating.')
@@ -116,6 +208,36 @@ const NominationModalMD = ({ open, onClose }: NominationModalProps) => {
     }
   }
 
+  const verifyNominatorOTP = async () => {
+    setLoading(true)
+    setError('')
+
+    try {
+      const response = await fetch('/api/otp/verify', {
+        method: 'POST',
+        headers: {
+          'Content-Type': 'application/json',
+        },
+        body: JSON.stringify({ email, code: otpCode, purpose: 'nomination' }),
+      })
+
+      const data = await response.json()
+
+      if (response.ok) {
+        setVerificationToken(data.verification_token)
+        setFormData(prev => ({ ...prev, nominatorEmail: email }))
+        setShowMine(nominationsClosed)
+        setActiveStep(1)
+      } else {
+        setError(data.error || 'Invalid verification code')
+      }
+    } catch (err) {
+      setError('Network error. Please try again.')
+    } finally {
+      setLoading(false)
+    }
+  }
+
   const submitNomination = async () => {
     setLoading(true)
     setError('')
@@ -125,6 +247,7 @@ const NominationModalMD = ({ open, onClose }: NominationModalProps) => {
         method: 'POST',
         headers: {
           'Content-Type': 'application/json',
+          'X-Verification-Token': verificationToken,
         },
         body: JSON.stringify(formData),
       })
@@ -157,6 +280,18 @@ const NominationModalMD = ({ open, onClose }: NominationModalProps) => {
               We need to verify that you are a registered alumni before you can submit nominations
             </Typography>
 
+            {nominationsClosed && (
+              <Alert severity="info" sx={{ mb: 3, textAlign: 'left' }}>
+                Nominations are currently closed. Please check back when the next awards campaign opens. You can still verify your email to view the nominations you made.
+              </Alert>
+            )}
+
+            {campaign && (
+              <Typography variant="body2" sx={{ mb: 2 }}>
+                <strong>{campaign.Name}</strong> is open until {new Date(campaign.ClosesAt).toLocaleString()}
+              </Typography>
+            )}
+
             {/* Nomination Guidelines */}
             <Alert severity="warning" sx={{ mb: 3, textAlign: 'left' }}>
               <Typography variant="subtitle2" sx={{ fontWeight: 'bold', mb: 1 }}>
@@ -180,11 +315,28 @@ const NominationModalMD = ({ open, onClose }: NominationModalProps) => {
               variant="outlined"
               sx={{ mb: 3 }}
               onKeyPress={(e) => e.key === 'Enter' && checkAlumniEmail()}
+              disabled={otpSent}
             />
+
+            {otpSent && (
+              <TextField
+                fullWidth
+                label="Verification Code"
+                helperText={`Enter the code we sent to ${email}`}
+                value={otpCode}
+                onChange={(e) => setOtpCode(e.target.value.replace(/\D/g, ''))}
+                variant="outlined"
+                sx={{ mb: 3 }}
+                onKeyPress={(e) => e.key === 'Enter' && verifyNominatorOTP()}
+              />
+            )}
           </Box>
         )
 
       case 1:
+        if (showMine) {
+          return <MyNominationsMD verificationToken={verificationToken} categories={categories} />
+        }
         return (
           <Box sx={{ py: 2 }}>
             <Box sx={{ display: 'flex', alignItems: 'center', mb: 3 }}>
@@ -217,16 +369,61 @@ const NominationModalMD = ({ open, onClose }: NominationModalProps) => {
                   onChange={(e) => setFormData(prev => ({ ...prev, category: e.target.value }))}
                 >
                   {categories.map((category) => (
-                    <MenuItem key={category} value={category}>
-                      {category}
+                    <MenuItem key={category.Name} value={category.Name}>
+                      {category.Name}
                     </MenuItem>
                   ))}
                 </Select>
               </FormControl>
 
+              {selectedCategory && (selectedCategory.Description || categoryRules(selectedCategory)) && (
+                <Alert severity="info" icon={<InfoIcon />}>
+                  {selectedCategory.Description && (
+                    <Typography variant="body2">{selectedCategory.Description}</Typography>
+                  )}
+                  {categoryRules(selectedCategory) && (
+                    <Typography variant="body2" sx={{ mt: selectedCategory.Description ? 1 : 0 }}>
+                      <strong>Eligibility:</strong> {categoryRules(selectedCategory)}
+                    </Typography>
+                  )}
+                </Alert>
+              )}
+
+              <Autocomplete
+                options={candidates}
+                value={selectedNominee}
+                onChange={(_, candidate) => selectNominee(candidate)}
+                inputValue={nomineeSearch}
+                onInputChange={(_, value) => setNomineeSearch(value)}
+                filterOptions={(options) => options}
+                getOptionLabel={(candidate) => `${candidate.FirstName} ${candidate.LastName}`}
+                isOptionEqualToValue={(option, value) => option.ID === value.ID}
+                noOptionsText={nomineeSearch.trim().length < 2 ? 'Type a name to search' : 'No matching alumni'}
+                renderOption={(props, candidate) => (
+                  <li {...props} key={candidate.ID}>
+                    <Box>
+                      <Typography variant="body2">{candidate.FirstName} {candidate.LastName}</Typography>
+                      <Typography variant="caption" color="text.secondary">
+                        {candidate.Course} {candidate.Year}
+                      </Typography>
+                    </Box>
+                  </li>
+                )}
+                renderInput={(params) => (
+                  <TextField
+                    {...params}
+                    label="Find the nominee in the alumni records"
+                    helperText={selectedNominee
+                      ? 'Linked to the alumni record. Clear this field to enter the nominee by hand.'
+                      : 'Optional. If the nominee is not listed, enter their details below.'}
+                  />
+                )}
+              />
+
               <Box sx={{ display: 'flex', gap: 2, flexDirection: { xs: 'column', sm: 'row' } }}>
                 <TextField
                   fullWidth
+                  disabled={!!selectedNominee}
                   label="Nominee First Name"
                   value={formData.firstName}
                   onChange={(e) => setFormData(prev => ({ ...prev, firstName: e.target.value }))}
@@ -234,6 +431,7 @@ const NominationModalMD = ({ open, onClose }: NominationModalProps) => {
                 />
                 <TextField
                   fullWidth
+                  disabled={!!selectedNominee}
                   label="Nominee Last Name"
                   value={formData.lastName}
                   onChange={(e) => setFormData(prev => ({ ...prev, lastName: e.target.value }))}
@@ -245,14 +443,16 @@ const NominationModalMD = ({ open, onClose }: NominationModalProps) => {
                 <TextField
                   fullWidth
                   type="email"
-                  label="Nominee Email (Optional)"
+                  label={selectedCategory?.RequireNomineeEmail && !selectedNominee ? 'Nominee Email' : 'Nominee Email (Optional)'}
                   value={formData.nominatedEmail}
                   onChange={(e) => setFormData(prev => ({ ...prev, nominatedEmail: e.target.value }))}
                   placeholder="nominee@example.com"
+                  required={selectedCategory?.RequireNomineeEmail && !selectedNominee}
                 />
                 <TextField
                   fullWidth
                   type="number"
+                  disabled={!!selectedNominee}
                   label="Graduation Year"
                   value={formData.year}
                   onChange={(e) => setFormData(prev => ({ ...prev, year: parseInt(e.target.value) }))}
@@ -332,7 +532,7 @@ const NominationModalMD = ({ open, onClose }: NominationModalProps) => {
         </Box>
         <Typography variant="body2" sx={{ color: 'rgba(255, 255, 255, 0.8)', mt: 1 }}>
           {activeStep === 0 && 'Enter your email to get started'}
-          {activeStep === 1 && 'Nominate a deserving alumni for recognition'}
+          {activeStep === 1 && (showMine ? 'View, edit or withdraw your nominations' : 'Nominate a deserving alumni for recognition')}
         </Typography>
       </Box>
 
@@ -405,11 +605,16 @@ const NominationModalMD = ({ open, onClose }: NominationModalProps) => {
             Back
           </Button>
         )}
+        {activeStep === 1 && !nominationsClosed && (
+          <Button onClick={() => { setError(''); setShowMine(!showMine) }} disabled={loading}>
+            {showMine ? 'New Nomination' : 'My Nominations'}
+          </Button>
+        )}
         <Box sx={{ flex: 1 }} />
         {activeStep === 0 && (
           <button
-            onClick={checkAlumniEmail}
-            disabled={loading || !email}
+            onClick={otpSent ? verifyNominatorOTP : checkAlumniEmail}
+            disabled={loading || !email || (otpSent && !otpCode)}
             style={{
               backgroundColor: loading || !email ? '#e5e7eb' : '#d97706',
               color: loading || !email ? '#9ca3af' : '#ffffff',
@@ -431,14 +636,14 @@ const NominationModalMD = ({ open, onClose }: NominationModalProps) => {
               }
             }}
           >
-            {loading ? 'Checking...' : 'Vote'}
+            {loading ? 'Checking...' : otpSent ? 'Verify' : 'Vote'}
           </button>
         )}
-        {activeStep === 1 && (
+        {activeStep === 1 && !showMine && (
           <Button
             variant="contained"
             onClick={submitNomination}
-            disabled={loading || !formData.firstName || !formData.lastName || !formData.category || !formData.year}
+            disabled={loading || !formData.firstName || !formData.lastName || !formData.category || !formData.year || (selectedCategory?.RequireNomineeEmail && !selectedNominee && !formData.nominatedEmail)}
             startIcon={loading ? <CircularProgress size={20} /> : null}
             sx={{ 
               backgroundColor: '#d97706',
@@ -1,9 +1,40 @@
+import { useEffect, useState } from 'react'
+
+interface AwardWinner {
+  CampaignID: number
+  CampaignName: string
+  Category: string
+  FirstName: string
+  LastName: string
+  Year: number
+}
+
 interface OutstandingAlumniAwardsProps {
   onBack: () => void
   onNominate?: () => void
 }
 
 const OutstandingAlumniAwards = ({ onBack, onNominate }: OutstandingAlumniAwardsProps) => {
+  const [winners, setWinners] = useState<AwardWinner[]>([])
+
+  useEffect(() => {
+    fetch('/api/awards/winners')
+      .then((response) => response.json())
+      .then((data) => setWinners(data.winners || []))
+      .catch(() => setWinners([]))
+  }, [])
+
+  // Winners grouped by campaign, most recent first as the API returns them
+  const winnersByCampaign = winners.reduce<{ name: string, winners: AwardWinner[] }[]>((groups, winner) => {
+    const last = groups[groups.length - 1]
+    if (last && last.winners[0].CampaignID === winner.CampaignID) {
+      last.winners.push(winner)
+    } else {
+      groups.push({ name: winner.CampaignName, winners: [winner] })
+    }
+    return groups
+  }, [])
+
   const awardCategories = [
     {
       title: "Pioneer in Tech and Innovation",
@@ -157,6 +188,28 @@ const OutstandingAlumniAwards = ({ onBack, onNominate }: OutstandingAlumniAwards
           </div>
         </div>
 
+        {/* Award Winners */}
+        {winnersByCampaign.length > 0 && (
+          <div className="mb-16">
+            <h2 className="text-3xl font-bold text-center text-gray-900 mb-12">Award Winners</h2>
+            {winnersByCampaign.map((campaign) => (
+              <div key={campaign.name} className="mb-10">
+                <h3 className="text-2xl font-semibold text-gray-900 mb-6 text-center">{campaign.name}</h3>
+                <div className="grid grid-cols-1 md:grid-cols-2 lg:grid-cols-3 gap-6">
+                  {campaign.winners.map((winner) => (
+                    <div key={`${winner.Category}-${winner.FirstName}-${winner.LastName}`} className="bg-white rounded-2xl shadow-lg p-6 text-center">
+                      <div className="text-4xl mb-3">🏆</div>
+                      <p className="text-sm font-medium text-amber-600 mb-2">{winner.Category}</p>
+                      <h4 className="text-xl font-bold text-gray-900">{winner.FirstName} {winner.LastName}</h4>
+                      <p className="text-gray-500">Class of {winner.Year}</p>
+                    </div>
+                  ))}
+                </div>
+              </div>
+            ))}
+          </div>
+        )}
+
         {/* Award Categories */}
         <div className="mb-16">
           <h2 className="text-3xl font-bold text-center text-gray-900 mb-12">Award Categories</h2>
@@ -352,10 +352,6 @@ const RegistrationModal = ({ onClose }: RegistrationModalProps) => {
         formDataToSend.append('country', formData.country)
         formDataToSend.append('city', formData.city)
         
-        // Add coordinates if they exist
-        if (formData.latitude) formDataToSend.append('latitude', formData.latitude.toString())
-        if (formData.longitude) formDataToSend.append('longitude', formData.longitude.toString())
-        
         // Add the file
         formDataToSend.append('payment_proof', paymentProofFile)
         
@@ -763,19 +759,15 @@ const RegistrationModal = ({ onClose }: RegistrationModalProps) => {
 
                 <div>
                   <label className="block text-sm font-medium text-gray-700 mb-2">
-                    Payment Proof/Receipt (PDF only, Max 5MB)
+                    Payment Proof/Receipt (PDF or image, Max 5MB)
                   </label>
                   <input
                     type="file"
-                    accept=".pdf"
+                    accept=".pdf,.jpg,.jpeg,.png,.heic,.heif"
                     onChange={(e) => {
                       const file = e.target.files?.[0]
                       if (file) {
-                        // Validate file type and size
-                        if (file.type !== 'application/pdf') {
-                          setError('Only PDF files are allowed for payment proof')
-                          return
-                        }
+                        // The server checks the file type by its content
                         if (file.size > 5 * 1024 * 1024) { // 5MB
                           setError('File size must be less than 5MB')
                           return
@@ -799,7 +791,7 @@ const RegistrationModal = ({ onClose }: RegistrationModalProps) => {
                     className="w-full px-4 py-3 border border-gray-300 rounded-lg focus:ring-2 focus:ring-indigo-500 focus:border-transparent file:mr-4 file:py-2 file:px-4 file:rounded-lg file:border-0 file:text-sm file:font-medium file:bg-indigo-50 file:text-indigo-700 hover:file:bg-indigo-100"
                   />
                   <p className="text-sm text-gray-500 mt-1">
-                    Only PDF files accepted (Max 5MB)
+                    PDF, JPEG, PNG or HEIC accepted (Max 5MB)
                   </p>
                   {formData.paymentProof && (
                     <div className="mt-2 flex items-center text-green-600">
@@ -99,6 +99,7 @@ const RegistrationModalMD = ({ open, onClose, onSuccess }: RegistrationModalProp
     city: ''
   })
   const [paymentProofFile, setPaymentProofFile] = useState<File | null>(null)
+  const [verificationToken, setVerificationToken] = useState('')
 
   useEffect(() => {
     if (open) {
@@ -232,6 +233,7 @@ const RegistrationModalMD = ({ open, onClose, onSuccess }: RegistrationModalProp
       const data = await response.json()
 
       if (response.ok) {
+        setVerificationToken(data.verification_token)
         if (data.alumni_exists && data.alumni_data) {
           setExistingAlumni(data.alumni_data)
           // Map backend field names (PascalCase) to frontend field names (camelCase)
@@ -312,22 +314,19 @@ const RegistrationModalMD = ({ open, onClose, onSuccess }: RegistrationModalProp
         formDataToSend.append('country', formData.country)
         formDataToSend.append('city', formData.city)
         
-        // Add coordinates if they exist
-        if (formData.latitude) formDataToSend.append('latitude', formData.latitude.toString())
-        if (formData.longitude) formDataToSend.append('longitude', formData.longitude.toString())
-        
         // Add the file
         formDataToSend.append('payment_proof', paymentProofFile)
         
         response = await fetch(url, {
           method: method,
+          headers: { 'X-Verification-Token': verificationToken },
           body: formDataToSend, // No Content-Type header - browser will set it with boundary
         })
       } else {
         console.log('Sending as JSON (no file)')
         response = await fetch(url, {
           method,
-          headers: { 'Content-Type': 'application/json' },
+          headers: { 'Content-Type': 'application/json', 'X-Verification-Token': verificationToken },
           body: JSON.stringify(formData),
         })
       }
@@ -550,15 +549,11 @@ const RegistrationModalMD = ({ open, onClose, onSuccess }: RegistrationModalProp
                   <input
                     type="file"
                     hidden
-                    accept=".pdf"
+                    accept=".pdf,.jpg,.jpeg,.png,.heic,.heif"
                     onChange={(e) => {
                       const file = e.target.files?.[0]
                       if (file) {
-                        // Validate file type and size
-                        if (file.type !== 'application/pdf') {
-                          setError('Only PDF files are allowed for payment proof')
-                          return
-                        }
+                        // The server checks the file type by its content
                         if (file.size > 5 * 1024 * 1024) { // 5MB
                           setError('File size must be less than 5MB')
                           return
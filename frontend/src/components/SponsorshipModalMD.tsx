@@ -64,6 +64,7 @@ const SponsorshipModalMD = ({ open, onClose, selectedLevel = '' }: SponsorshipMo
   const [error, setError] = useState('')
   const [timeLeft, setTimeLeft] = useState(120) // 2 minutes in seconds
   const [existingSponsorship, setExistingSponsorship] = useState<SponsorshipFormData | null>(null)
+  const [verificationToken, setVerificationToken] = useState('')
 
   const [formData, setFormData] = useState<SponsorshipFormData>({
     email: '',
@@ -181,6 +182,7 @@ const SponsorshipModalMD = ({ open, onClose, selectedLevel = '' }: SponsorshipMo
       const data = await response.json()
 
       if (response.ok) {
+        setVerificationToken(data.verification_token)
         // Check if sponsorship exists for this email
         try {
           const sponsorshipResponse = await fetch(`/api/sponsorships/email/${encodeURIComponent(email)}`, {
@@ -238,6 +240,7 @@ const SponsorshipModalMD = ({ open, onClose, selectedLevel = '' }: SponsorshipMo
           method: 'PUT',
           headers: {
             'Content-Type': 'application/json',
+            'X-Verification-Token': verificationToken,
           },
           body: JSON.stringify(formData),
         })
@@ -247,6 +250,7 @@ const SponsorshipModalMD = ({ open, onClose, selectedLevel = '' }: SponsorshipMo
           method: 'POST',
           headers: {
             'Content-Type': 'application/json',
+            'X-Verification-Token': verificationToken,
           },
           body: JSON.stringify(formData),
         })
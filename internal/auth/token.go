@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token expired")
+)
+
+// Claims is the payload carried inside a signed token.
+type Claims struct {
+	Subject   string `json:"sub"`
+	Kind      string `json:"kind"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// TokenManager issues and validates HMAC-SHA256 signed tokens of the form
+// base64url(claims).base64url(signature).
+type TokenManager struct {
+	secret []byte
+	now    func() time.Time
+}
+
+func NewTokenManager(secret []byte) *TokenManager {
+	return &TokenManager{
+		secret: secret,
+		now:    time.Now,
+	}
+}
+
+// NewTokenManagerFromEnv reads the signing secret from SESSION_SECRET. When it
+// is not set a random secret is generated, which means tokens do not survive
+// a restart and are not shared between replicas.
+func NewTokenManagerFromEnv() *TokenManager {
+	secret := []byte(os.Getenv("SESSION_SECRET"))
+	if len(secret) == 0 {
+		log.Println("Warning: SESSION_SECRET is not set, using a random secret for this process")
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			log.Fatalf("failed to generate session secret: %v", err)
+		}
+	}
+	return NewTokenManager(secret)
+}
+
+// Issue signs a token of the given kind for subject that expires after ttl.
+func (m *TokenManager) Issue(kind, subject string, ttl time.Duration) (string, time.Time, error) {
+	now := m.now()
+	expiresAt := now.Add(ttl)
+
+	payload, err := json.Marshal(Claims{
+		Subject:   subject,
+		Kind:      kind,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to encode token claims: %w", err)
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + m.sign(encoded), expiresAt, nil
+}
+
+// Parse validates the signature, kind and expiry of token and returns its claims.
+func (m *TokenManager) Parse(token, kind string) (*Claims, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok || encoded == "" || signature == "" {
+		return nil, ErrInvalidToken
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(m.sign(encoded))) {
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if claims.Kind != kind {
+		return nil, ErrInvalidToken
+	}
+
+	if m.now().Unix() >= claims.ExpiresAt {
+		return nil, ErrExpiredToken
+	}
+
+	return &claims, nil
+}
+
+func (m *TokenManager) sign(encoded string) string {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
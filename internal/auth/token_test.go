@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIssueAndParse(t *testing.T) {
+	m := NewTokenManager([]byte("secret"))
+
+	token, _, err := m.Issue("access", "42", time.Minute)
+	if err != nil {
+		t.Fatalf("Issue() returned error: %v", err)
+	}
+
+	claims, err := m.Parse(token, "access")
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+	if claims.Subject != "42" {
+		t.Fatalf("expected subject 42, got %s", claims.Subject)
+	}
+}
+
+func TestParseRejectsWrongKind(t *testing.T) {
+	m := NewTokenManager([]byte("secret"))
+
+	token, _, _ := m.Issue("refresh", "42", time.Minute)
+	if _, err := m.Parse(token, "access"); err != ErrInvalidToken {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestParseRejectsTamperedToken(t *testing.T) {
+	m := NewTokenManager([]byte("secret"))
+	other := NewTokenManager([]byte("other"))
+
+	token, _, _ := other.Issue("access", "42", time.Minute)
+	if _, err := m.Parse(token, "access"); err != ErrInvalidToken {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestParseRejectsExpiredToken(t *testing.T) {
+	m := NewTokenManager([]byte("secret"))
+
+	token, _, _ := m.Issue("access", "42", time.Minute)
+	m.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	if _, err := m.Parse(token, "access"); err != ErrExpiredToken {
+		t.Fatalf("expected ErrExpiredToken, got %v", err)
+	}
+}
@@ -2,32 +2,67 @@ package database
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 type Admin struct {
-	ID          int       `gorm:"column:id;primaryKey"`
-	Username    string    `gorm:"column:username;unique"`
-	Password    string    `gorm:"column:password"`
-	IsSuperuser bool      `gorm:"column:is_superuser;default:false"`
-	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt   time.Time `gorm:"column:updated_at;autoUpdateTime"`
+	ID          int    `gorm:"column:id;primaryKey"`
+	Username    string `gorm:"column:username;unique"`
+	Password    string `gorm:"column:password"`
+	IsSuperuser bool   `gorm:"column:is_superuser;default:false"` // superseded by roles, kept for existing rows
+	Disabled    bool   `gorm:"column:disabled;default:false"`
+	// MustChangePassword is set for seeded accounts and admin-issued resets.
+	MustChangePassword bool      `gorm:"column:must_change_password;default:false"`
+	Roles              []Role    `gorm:"many2many:admin_roles;"`
+	CreatedAt          time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt          time.Time `gorm:"column:updated_at;autoUpdateTime"`
 }
 
 func (Admin) TableName() string {
 	return "admins"
 }
 
+var (
+	ErrAdminDisabled      = errors.New("admin account is disabled")
+	ErrInvalidPassword    = errors.New("current password is incorrect")
+	ErrSetupAlreadyDone   = errors.New("an admin account already exists")
+	ErrKnownSeedPassword  = errors.New("refusing to seed a well-known password outside development mode")
+	ErrSamePasswordReused = errors.New("new password must differ from the current password")
+	ErrUnknownRole        = errors.New("unknown role")
+	ErrNoRoles            = errors.New("at least one role is required")
+	ErrRoleRepeated       = errors.New("a role is listed more than once")
+	ErrLastAdminManager   = errors.New("at least one enabled admin must keep the admin.manage permission")
+)
+
+// knownPasswords are credentials that have been published in this
+// repository. Accounts still using them are forced to change password.
+var knownPasswords = []string{"unorcitconnect@25"}
+
 type AdminService interface {
-	CreateAdmin(ctx context.Context, username, password string) (*Admin, error)
+	CreateAdmin(ctx context.Context, username, password string, roles ...string) (*Admin, error)
 	CreateSuperuser(ctx context.Context, username, password string) (*Admin, error)
 	AuthenticateAdmin(ctx context.Context, username, password string) (*Admin, error)
-	SeedDefaultAdmin(ctx context.Context) error
+	GetAdminByID(ctx context.Context, id int) (*Admin, error)
+	ListAdmins(ctx context.Context) ([]Admin, error)
+	SetAdminDisabled(ctx context.Context, id int, disabled bool) error
+	ResetAdminPassword(ctx context.Context, id int, newPassword string) error
+	ChangeAdminPassword(ctx context.Context, id int, currentPassword, newPassword string) error
+	DeleteAdmin(ctx context.Context, id int) error
+	CountAdmins(ctx context.Context) (int64, error)
+	CreateFirstSuperuser(ctx context.Context, username, password string) (*Admin, error)
+	SeedAdmin(ctx context.Context, username, password string, devMode bool) error
+	ExpireKnownPasswords(ctx context.Context) error
 }
 
-func (s *service) CreateAdmin(ctx context.Context, username, password string) (*Admin, error) {
+// CreateAdmin creates an admin with the given roles, or the viewer role when
+// none are given. An unknown role returns ErrUnknownRole and creates nothing.
+func (s *service) CreateAdmin(ctx context.Context, username, password string, roles ...string) (*Admin, error) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
 		return nil, err
@@ -38,8 +73,10 @@ func (s *service) CreateAdmin(ctx context.Context, username, password string) (*
 		Password: string(hashedPassword),
 	}
 
-	result := s.db.WithContext(ctx).Create(admin)
-	return admin, result.Error
+	if len(roles) == 0 {
+		roles = []string{RoleViewer}
+	}
+	return admin, s.createAdminWithRoles(ctx, admin, roles...)
 }
 
 func (s *service) CreateSuperuser(ctx context.Context, username, password string) (*Admin, error) {
@@ -54,8 +91,16 @@ func (s *service) CreateSuperuser(ctx context.Context, username, password string
 		IsSuperuser: true,
 	}
 
-	result := s.db.WithContext(ctx).Create(admin)
-	return admin, result.Error
+	return admin, s.createAdminWithRoles(ctx, admin, RoleSuperuser)
+}
+
+func (s *service) createAdminWithRoles(ctx context.Context, admin *Admin, roles ...string) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(admin).Error; err != nil {
+			return err
+		}
+		return s.setAdminRoles(tx, admin.ID, roles)
+	})
 }
 
 func (s *service) AuthenticateAdmin(ctx context.Context, username, password string) (*Admin, error) {
@@ -70,28 +115,201 @@ func (s *service) AuthenticateAdmin(ctx context.Context, username, password stri
 		return nil, err
 	}
 
+	if admin.Disabled {
+		return nil, ErrAdminDisabled
+	}
+
 	return &admin, nil
 }
 
-func (s *service) SeedDefaultAdmin(ctx context.Context) error {
-	// Check if regular admin already exists
+func (s *service) GetAdminByID(ctx context.Context, id int) (*Admin, error) {
+	var admin Admin
+	result := s.db.WithContext(ctx).Where("id = ?", id).First(&admin)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("admin not found")
+		}
+		return nil, fmt.Errorf("failed to find admin by ID: %w", result.Error)
+	}
+
+	return &admin, nil
+}
+
+func (s *service) ListAdmins(ctx context.Context) ([]Admin, error) {
+	var admins []Admin
+	result := s.db.WithContext(ctx).Preload("Roles").Order("username ASC").Find(&admins)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list admins: %w", result.Error)
+	}
+	return admins, nil
+}
+
+func (s *service) SetAdminDisabled(ctx context.Context, id int, disabled bool) error {
+	return s.keepingAdminManager(ctx, func(tx *gorm.DB) error {
+		result := tx.Model(&Admin{}).Where("id = ?", id).Update("disabled", disabled)
+		if result.Error != nil {
+			return fmt.Errorf("failed to update admin: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("admin not found")
+		}
+		return nil
+	})
+}
+
+// ResetAdminPassword sets a temporary password chosen by another admin; the
+// account has to change it on next login.
+func (s *service) ResetAdminPassword(ctx context.Context, id int, newPassword string) error {
+	return s.updateAdminPassword(ctx, id, newPassword, true)
+}
+
+func (s *service) ChangeAdminPassword(ctx context.Context, id int, currentPassword, newPassword string) error {
+	admin, err := s.GetAdminByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(admin.Password), []byte(currentPassword)); err != nil {
+		return ErrInvalidPassword
+	}
+
+	if currentPassword == newPassword {
+		return ErrSamePasswordReused
+	}
+
+	return s.updateAdminPassword(ctx, id, newPassword, false)
+}
+
+func (s *service) updateAdminPassword(ctx context.Context, id int, newPassword string, mustChange bool) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	result := s.db.WithContext(ctx).Model(&Admin{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"password":             string(hashedPassword),
+		"must_change_password": mustChange,
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update password: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("admin not found")
+	}
+	return nil
+}
+
+func (s *service) DeleteAdmin(ctx context.Context, id int) error {
+	return s.keepingAdminManager(ctx, func(tx *gorm.DB) error {
+		if err := tx.Model(&Admin{ID: id}).Association("Roles").Clear(); err != nil {
+			return fmt.Errorf("failed to clear admin roles: %w", err)
+		}
+
+		result := tx.Delete(&Admin{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete admin: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("admin not found")
+		}
+		return nil
+	})
+}
+
+func (s *service) CountAdmins(ctx context.Context) (int64, error) {
 	var count int64
-	s.db.WithContext(ctx).Model(&Admin{}).Where("username = ?", "admin").Count(&count)
-	if count == 0 {
-		_, err := s.CreateAdmin(ctx, "admin", "unorcitconnect@25")
-		if err != nil {
+	err := s.db.WithContext(ctx).Model(&Admin{}).Count(&count).Error
+	return count, err
+}
+
+// CreateFirstSuperuser creates the initial superuser during first-run setup.
+// It fails with ErrSetupAlreadyDone once any admin exists.
+func (s *service) CreateFirstSuperuser(ctx context.Context, username, password string) (*Admin, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	admin := &Admin{
+		Username:    username,
+		Password:    string(hashedPassword),
+		IsSuperuser: true,
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Serialize concurrent setup attempts so only one can see an empty table.
+		if err := tx.Exec("LOCK TABLE admins IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
 			return err
 		}
-	}
 
-	// Check if superuser already exists
-	var superuserCount int64
-	s.db.WithContext(ctx).Model(&Admin{}).Where("username = ?", "unorcitconnect").Count(&superuserCount)
-	if superuserCount == 0 {
-		_, err := s.CreateSuperuser(ctx, "unorcitconnect", "unorcitconnect@25")
-		if err != nil {
+		var count int64
+		if err := tx.Model(&Admin{}).Count(&count).Error; err != nil {
 			return err
 		}
+		if count > 0 {
+			return ErrSetupAlreadyDone
+		}
+
+		if err := tx.Create(admin).Error; err != nil {
+			return err
+		}
+		return s.setAdminRoles(tx, admin.ID, []string{RoleSuperuser})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return admin, nil
+}
+
+// SeedAdmin creates a superuser with the given credentials if it does not
+// exist yet. Seeded accounts must change their password on first login, and
+// well-known passwords are rejected outside development mode.
+func (s *service) SeedAdmin(ctx context.Context, username, password string, devMode bool) error {
+	if !devMode && slices.Contains(knownPasswords, password) {
+		return ErrKnownSeedPassword
+	}
+
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&Admin{}).Where("username = ?", username).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check for admin %s: %w", username, err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	// Flagged in the same insert, so the account can never exist without it
+	return s.createAdminWithRoles(ctx, &Admin{
+		Username:           username,
+		Password:           string(hashedPassword),
+		IsSuperuser:        true,
+		MustChangePassword: true,
+	}, RoleSuperuser)
+}
+
+// ExpireKnownPasswords flags every admin still using a well-known password so
+// they are forced to pick a new one on next login.
+func (s *service) ExpireKnownPasswords(ctx context.Context) error {
+	var admins []Admin
+	if err := s.db.WithContext(ctx).Where("must_change_password = ?", false).Find(&admins).Error; err != nil {
+		return fmt.Errorf("failed to list admins: %w", err)
+	}
+
+	for _, admin := range admins {
+		for _, known := range knownPasswords {
+			if bcrypt.CompareHashAndPassword([]byte(admin.Password), []byte(known)) != nil {
+				continue
+			}
+			if err := s.db.WithContext(ctx).Model(&admin).Update("must_change_password", true).Error; err != nil {
+				return fmt.Errorf("failed to expire password for %s: %w", admin.Username, err)
+			}
+			break
+		}
 	}
 
 	return nil
@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -24,49 +25,126 @@ type Alumni struct {
 	Latitude         float64   `gorm:"column:latitude"`
 	Longitude        float64   `gorm:"column:longitude"`
 	IsVerified       bool      `gorm:"column:is_verified;default:false"`
-	Paid             bool      `gorm:"column:paid;default:false"`
-	PaymentProof     string    `gorm:"column:payment_proof"`
-	PaymentProofData []byte    `gorm:"column:payment_proof_data"`
+	Paid             bool      `gorm:"column:paid;default:false"` // set only when the payment is approved
+	PaymentProof     string    `gorm:"column:payment_proof"`      // file name; the bytes are an Attachment
 	PaymentProofType string    `gorm:"column:payment_proof_type"`
 	PaymentProofSize int64     `gorm:"column:payment_proof_size"`
 	CreatedAt        time.Time `gorm:"column:created_at;autoCreateTime"` // auto on insert
 	UpdatedAt        time.Time `gorm:"column:updated_at;autoUpdateTime"` // auto on update
-}
 
-type OTP struct {
-	ID        int       `gorm:"column:id;primaryKey"`
-	Email     string    `gorm:"column:email;index"`
-	Code      string    `gorm:"column:code"`
-	Purpose   string    `gorm:"column:purpose"` // "registration" or "nomination"
-	ExpiresAt time.Time `gorm:"column:expires_at"`
-	Used      bool      `gorm:"column:used;default:false"`
-	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+	// Payment review state, see payment_review_repository.go
+	PaymentStatus          string `gorm:"column:payment_status;default:none"`
+	PaymentRejectionReason string `gorm:"column:payment_rejection_reason"`
+
+	// Directory privacy, chosen by the alumnus and applied to the public
+	// alumni list and map
+	ShowEmail    bool   `gorm:"column:show_email;default:false"`
+	ShowPhone    bool   `gorm:"column:show_phone;default:false"`
+	ShowEmployer bool   `gorm:"column:show_employer;default:true"`
+	MapPrecision string `gorm:"column:map_precision;default:city"`
 }
 
 func (Alumni) TableName() string {
 	return "alumni"
 }
 
-func (OTP) TableName() string {
-	return "otp"
+// SetLocation sets the alumnus's country and city. Coordinates come only
+// from the geocode backfill, so they are cleared when either changes and
+// the new city is looked up on the next run. It reports whether they were.
+func (a *Alumni) SetLocation(country, city string) bool {
+	moved := !strings.EqualFold(strings.TrimSpace(a.Country), strings.TrimSpace(country)) ||
+		!strings.EqualFold(strings.TrimSpace(a.City), strings.TrimSpace(city))
+	if moved {
+		a.Latitude, a.Longitude = 0, 0
+	}
+	a.Country, a.City = country, city
+	return moved
+}
+
+// Map precisions, from most to least revealing. An alumnus's location is
+// shown on the public map no more precisely than their MapPrecision.
+const (
+	MapPrecisionExact   = "exact"
+	MapPrecisionCity    = "city"
+	MapPrecisionCountry = "country"
+	MapPrecisionHidden  = "hidden"
+)
+
+// CityPrecisionDecimals is how many decimals of latitude and longitude are
+// shown for city precision, about 11 km: enough to place an alumnus in their
+// city without pointing at their address.
+const CityPrecisionDecimals = 1
+
+// IsMapPrecision reports whether value is one of the MapPrecision constants.
+func IsMapPrecision(value string) bool {
+	switch value {
+	case MapPrecisionExact, MapPrecisionCity, MapPrecisionCountry, MapPrecisionHidden:
+		return true
+	}
+	return false
+}
+
+// MaxAlumniPageSize caps the page size accepted by SearchAlumni.
+const MaxAlumniPageSize = 1000
+
+var ErrInvalidSortField = errors.New("invalid sort field")
+
+// alumniSortColumns maps the sort fields accepted from clients to columns.
+var alumniSortColumns = map[string]string{
+	"id":             "id",
+	"first_name":     "first_name",
+	"last_name":      "last_name",
+	"email":          "email",
+	"year":           "year",
+	"course":         "course",
+	"company":        "company",
+	"position":       "position",
+	"country":        "country",
+	"city":           "city",
+	"paid":           "paid",
+	"payment_status": "payment_status",
+	"is_verified":    "is_verified",
+	"created_at":     "created_at",
+}
+
+// IsAlumniSortField reports whether name is accepted as AlumniQuery.SortBy.
+func IsAlumniSortField(name string) bool {
+	_, ok := alumniSortColumns[name]
+	return ok
+}
+
+// AlumniQuery describes a filtered, sorted page of alumni. Zero values mean
+// "no filter"; Paid and IsVerified are pointers so false can be filtered on.
+type AlumniQuery struct {
+	Page          int
+	PageSize      int
+	Year          int
+	Course        string
+	Country       string
+	City          string
+	Paid          *bool
+	PaymentStatus string
+	IsVerified    *bool
+	Search        string // matched against name, company, position and email
+	Public        bool   // match only the fields each alumnus shows publicly
+	SortBy        string
+	SortDesc      bool
 }
 
 type AlumniService interface {
 	GetAllAlumni(ctx context.Context) ([]Alumni, error)
 	GetPaginatedAlumni(ctx context.Context, page int, pageSize int) ([]Alumni, int64, error)
+	SearchAlumni(ctx context.Context, q AlumniQuery) ([]Alumni, int64, error)
+	EachAlumni(ctx context.Context, q AlumniQuery, fn func(Alumni) error) error
 	FindAlumniByEmail(ctx context.Context, email string) (*Alumni, error)
 	GetAlumniByID(ctx context.Context, id int) (*Alumni, error)
 	SaveAlumni(ctx context.Context, a *Alumni) error
+	UpdateAlumni(ctx context.Context, a *Alumni, columns ...string) error
+	CreateAlumniBatch(ctx context.Context, alumni []Alumni) error
 	DeleteAlumni(ctx context.Context, id int) error
 	GetAlumniWithLocation(ctx context.Context) ([]Alumni, error)
 }
 
-type OTPService interface {
-	CreateOTP(ctx context.Context, email, purpose string) (*OTP, error)
-	VerifyOTP(ctx context.Context, email, code, purpose string) (*OTP, error)
-	CleanupExpiredOTPs(ctx context.Context) error
-}
-
 func (s *service) GetAllAlumni(ctx context.Context) ([]Alumni, error) {
 	var alumni []Alumni
 	result := s.db.WithContext(ctx).Find(&alumni)
@@ -95,6 +173,125 @@ func (s *service) GetPaginatedAlumni(ctx context.Context, page int, pageSize int
 	return alumni, total, nil
 }
 
+func (s *service) SearchAlumni(ctx context.Context, q AlumniQuery) ([]Alumni, int64, error) {
+	order, err := alumniOrder(q)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.PageSize < 1 {
+		q.PageSize = 10
+	}
+	if q.PageSize > MaxAlumniPageSize {
+		q.PageSize = MaxAlumniPageSize
+	}
+
+	query := s.filterAlumni(s.db.WithContext(ctx).Model(&Alumni{}), q)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count alumni: %w", err)
+	}
+
+	var alumni []Alumni
+	result := query.
+		Order(order).
+		Limit(q.PageSize).
+		Offset((q.Page - 1) * q.PageSize).
+		Find(&alumni)
+	if result.Error != nil {
+		return nil, total, fmt.Errorf("failed to search alumni: %w", result.Error)
+	}
+
+	return alumni, total, nil
+}
+
+// EachAlumni calls fn for every alumnus matching q, in q's sort order and
+// ignoring paging. Rows are streamed rather than loaded at once.
+func (s *service) EachAlumni(ctx context.Context, q AlumniQuery, fn func(Alumni) error) error {
+	order, err := alumniOrder(q)
+	if err != nil {
+		return err
+	}
+
+	query := s.filterAlumni(s.db.WithContext(ctx).Model(&Alumni{}), q).Order(order)
+	if err := eachRow(query, fn); err != nil {
+		return fmt.Errorf("failed to stream alumni: %w", err)
+	}
+	return nil
+}
+
+// alumniOrder validates q's sort field and returns the ORDER BY clause.
+func alumniOrder(q AlumniQuery) (string, error) {
+	column := "id"
+	if q.SortBy != "" {
+		var ok bool
+		if column, ok = alumniSortColumns[q.SortBy]; !ok {
+			return "", ErrInvalidSortField
+		}
+	}
+	direction := "ASC"
+	if q.SortDesc {
+		direction = "DESC"
+	}
+	return fmt.Sprintf("%s %s, id ASC", column, direction), nil
+}
+
+// filterAlumni applies the filter fields of q to query.
+func (s *service) filterAlumni(query *gorm.DB, q AlumniQuery) *gorm.DB {
+	if q.Year != 0 {
+		query = query.Where("year = ?", q.Year)
+	}
+	if q.Course != "" {
+		query = query.Where("course = ?", q.Course)
+	}
+	if q.Country != "" {
+		query = query.Where("country = ?", q.Country)
+		if q.Public {
+			// Only match alumni whose map precision shows their country
+			query = query.Where("map_precision <> ?", MapPrecisionHidden)
+		}
+	}
+	if q.City != "" {
+		query = query.Where("city ILIKE ?", escapeLike(q.City))
+		if q.Public {
+			query = query.Where("map_precision NOT IN ?", []string{MapPrecisionCountry, MapPrecisionHidden})
+		}
+	}
+	if q.Paid != nil {
+		query = query.Where("paid = ?", *q.Paid)
+	}
+	if q.PaymentStatus != "" {
+		query = query.Where("payment_status = ?", q.PaymentStatus)
+	}
+	if q.IsVerified != nil {
+		query = query.Where("is_verified = ?", *q.IsVerified)
+	}
+	if search := strings.TrimSpace(q.Search); search != "" && q.Public {
+		// Matching a hidden field would reveal it one query at a time
+		pattern := "%" + escapeLike(search) + "%"
+		query = query.Where(
+			"first_name ILIKE ? OR last_name ILIKE ? OR (show_employer AND (company ILIKE ? OR position ILIKE ?))",
+			pattern, pattern, pattern, pattern,
+		)
+	} else if search != "" {
+		pattern := "%" + escapeLike(search) + "%"
+		query = query.Where(
+			"first_name ILIKE ? OR last_name ILIKE ? OR company ILIKE ? OR position ILIKE ? OR email ILIKE ?",
+			pattern, pattern, pattern, pattern, pattern,
+		)
+	}
+	return query
+}
+
+// escapeLike escapes the LIKE wildcards in a user-supplied value.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
 func (s *service) FindAlumniByEmail(ctx context.Context, email string) (*Alumni, error) {
 	var alumni Alumni
 	result := s.db.WithContext(ctx).Where("email = ?", email).First(&alumni)
@@ -139,70 +336,51 @@ func (s *service) SaveAlumni(ctx context.Context, a *Alumni) error {
 	return nil
 }
 
-func (s *service) DeleteAlumni(ctx context.Context, id int) error {
-	result := s.db.WithContext(ctx).Delete(&Alumni{}, id)
-	if result.Error != nil {
-		return fmt.Errorf("failed to delete alumni: %w", result.Error)
-	}
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("alumni not found")
+// UpdateAlumni writes only the given columns of a. Anything else changed in
+// the row since a was loaded, such as a payment review, a payment webhook or
+// geocoded coordinates, is left alone.
+func (s *service) UpdateAlumni(ctx context.Context, a *Alumni, columns ...string) error {
+	a.FirstName = strings.ToUpper(a.FirstName)
+	a.LastName = strings.ToUpper(a.LastName)
+
+	if err := s.db.WithContext(ctx).Model(a).Select(columns).Updates(a).Error; err != nil {
+		return fmt.Errorf("failed to update alumni: %w", err)
 	}
 	return nil
 }
 
-func (s *service) GetAlumniWithLocation(ctx context.Context) ([]Alumni, error) {
-	var alumni []Alumni
-	result := s.db.WithContext(ctx).Where("latitude != 0 AND longitude != 0").Find(&alumni)
-	return alumni, result.Error
-}
-
-// OTP Service Methods
-func (s *service) CreateOTP(ctx context.Context, email, purpose string) (*OTP, error) {
-	// Generate 4-digit OTP
-	code := fmt.Sprintf("%04d", time.Now().UnixNano()%10000)
-
-	// Set expiration to 2 minutes from now
-	expiresAt := time.Now().Add(2 * time.Minute)
-
-	otp := &OTP{
-		Email:     email,
-		Code:      code,
-		Purpose:   purpose,
-		ExpiresAt: expiresAt,
-		Used:      false,
+// CreateAlumniBatch creates every alumnus in one transaction; if any insert
+// fails none of them are kept.
+func (s *service) CreateAlumniBatch(ctx context.Context, alumni []Alumni) error {
+	for i := range alumni {
+		alumni[i].FirstName = strings.ToUpper(alumni[i].FirstName)
+		alumni[i].LastName = strings.ToUpper(alumni[i].LastName)
 	}
 
-	if err := s.db.WithContext(ctx).Create(otp).Error; err != nil {
-		return nil, fmt.Errorf("failed to create OTP: %w", err)
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(alumni, 100).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to import alumni: %w", err)
 	}
-
-	return otp, nil
+	return nil
 }
 
-func (s *service) VerifyOTP(ctx context.Context, email, code, purpose string) (*OTP, error) {
-	var otp OTP
-	result := s.db.WithContext(ctx).Where(
-		"email = ? AND code = ? AND purpose = ? AND used = false AND expires_at > ?",
-		email, code, purpose, time.Now(),
-	).First(&otp)
-
+func (s *service) DeleteAlumni(ctx context.Context, id int) error {
+	result := s.db.WithContext(ctx).Delete(&Alumni{}, id)
 	if result.Error != nil {
-		if result.Error == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("invalid or expired OTP")
-		}
-		return nil, fmt.Errorf("failed to verify OTP: %w", result.Error)
+		return fmt.Errorf("failed to delete alumni: %w", result.Error)
 	}
-
-	// Mark OTP as used
-	otp.Used = true
-	if err := s.db.WithContext(ctx).Save(&otp).Error; err != nil {
-		return nil, fmt.Errorf("failed to mark OTP as used: %w", err)
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("alumni not found")
 	}
-
-	return &otp, nil
+	return nil
 }
 
-func (s *service) CleanupExpiredOTPs(ctx context.Context) error {
-	result := s.db.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&OTP{})
-	return result.Error
+func (s *service) GetAlumniWithLocation(ctx context.Context) ([]Alumni, error) {
+	var alumni []Alumni
+	result := s.db.WithContext(ctx).
+		Where("latitude != 0 AND longitude != 0 AND map_precision <> ?", MapPrecisionHidden).
+		Find(&alumni)
+	return alumni, result.Error
 }
@@ -0,0 +1,28 @@
+package database
+
+import "testing"
+
+func TestAlumniSetLocation(t *testing.T) {
+	tests := []struct {
+		name          string
+		country, city string
+		wantCleared   bool
+	}{
+		{"unchanged", "Philippines", "Bacolod", false},
+		{"case and spacing only", " philippines", "BACOLOD ", false},
+		{"new city", "Philippines", "Iloilo", true},
+		{"new country", "Japan", "Bacolod", true},
+	}
+	for _, tt := range tests {
+		a := Alumni{Country: "Philippines", City: "Bacolod", Latitude: 10.67, Longitude: 122.95}
+		if moved := a.SetLocation(tt.country, tt.city); moved != tt.wantCleared {
+			t.Errorf("%s: SetLocation() = %v; want %v", tt.name, moved, tt.wantCleared)
+		}
+		if a.Country != tt.country || a.City != tt.city {
+			t.Errorf("%s: expected %q, %q; got %q, %q", tt.name, tt.country, tt.city, a.Country, a.City)
+		}
+		if cleared := a.Latitude == 0 && a.Longitude == 0; cleared != tt.wantCleared {
+			t.Errorf("%s: expected coordinates cleared %v; got %v, %v", tt.name, tt.wantCleared, a.Latitude, a.Longitude)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Attachment kinds.
+const (
+	AttachmentPaymentProof = "payment_proof"
+)
+
+// Attachment records a file kept in the blob store on behalf of an alumnus.
+// The bytes live under StorageKey; only metadata is kept in the database.
+type Attachment struct {
+	ID          int       `gorm:"column:id;primaryKey"`
+	AlumniID    int       `gorm:"column:alumni_id;index;not null"`
+	Alumni      *Alumni   `gorm:"foreignKey:AlumniID;constraint:OnDelete:CASCADE" json:"-"`
+	Kind        string    `gorm:"column:kind;not null"`
+	StorageKey  string    `gorm:"column:storage_key;unique;not null"`
+	Filename    string    `gorm:"column:filename"`
+	ContentType string    `gorm:"column:content_type"`
+	Size        int64     `gorm:"column:size"`
+	Checksum    string    `gorm:"column:checksum"` // hex SHA-256 of the content
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (Attachment) TableName() string {
+	return "attachments"
+}
+
+// LegacyPaymentProof is a payment proof still stored inline in the alumni row.
+type LegacyPaymentProof struct {
+	AlumniID    int
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+type AttachmentService interface {
+	GetAttachment(ctx context.Context, alumniID int, kind string) (*Attachment, error)
+	ListAttachments(ctx context.Context, alumniID int) ([]Attachment, error)
+	ReplaceAttachment(ctx context.Context, a *Attachment) ([]Attachment, error)
+	CreateAlumniWithPaymentProof(ctx context.Context, a *Alumni, store func(alumniID int) (*Attachment, error)) error
+	SubmitPaymentProof(ctx context.Context, alumniID int, store func(alumniID int) (*Attachment, error)) (*Alumni, []Attachment, error)
+	MigrateLegacyPaymentProofs(ctx context.Context, store func(LegacyPaymentProof) (*Attachment, error)) (int, error)
+}
+
+// GetAttachment returns the alumnus's attachment of the given kind, or nil
+// when there is none.
+func (s *service) GetAttachment(ctx context.Context, alumniID int, kind string) (*Attachment, error) {
+	var attachment Attachment
+	result := s.db.WithContext(ctx).
+		Where("alumni_id = ? AND kind = ?", alumniID, kind).
+		Order("created_at DESC").
+		First(&attachment)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find attachment: %w", result.Error)
+	}
+
+	return &attachment, nil
+}
+
+func (s *service) ListAttachments(ctx context.Context, alumniID int) ([]Attachment, error) {
+	var attachments []Attachment
+	result := s.db.WithContext(ctx).Where("alumni_id = ?", alumniID).Order("created_at DESC").Find(&attachments)
+	return attachments, result.Error
+}
+
+// ReplaceAttachment records a as the alumnus's only attachment of its kind
+// and returns the attachments it replaced, whose blobs the caller should
+// delete once the new one is committed.
+func (s *service) ReplaceAttachment(ctx context.Context, a *Attachment) ([]Attachment, error) {
+	var replaced []Attachment
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		replaced, err = replaceAttachment(tx, a)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save attachment: %w", err)
+	}
+	return replaced, nil
+}
+
+func replaceAttachment(tx *gorm.DB, a *Attachment) ([]Attachment, error) {
+	var replaced []Attachment
+	if err := tx.Where("alumni_id = ? AND kind = ?", a.AlumniID, a.Kind).Find(&replaced).Error; err != nil {
+		return nil, err
+	}
+	if len(replaced) > 0 {
+		if err := tx.Delete(&replaced).Error; err != nil {
+			return nil, err
+		}
+	}
+	return replaced, tx.Create(a).Error
+}
+
+// CreateAlumniWithPaymentProof creates a with a payment proof awaiting
+// review. store writes the file for the new alumnus and returns the
+// attachment to record. The row and the attachment are saved together: on
+// error neither exists, and the caller deletes whatever store wrote.
+func (s *service) CreateAlumniWithPaymentProof(ctx context.Context, a *Alumni, store func(alumniID int) (*Attachment, error)) error {
+	a.FirstName = strings.ToUpper(a.FirstName)
+	a.LastName = strings.ToUpper(a.LastName)
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(a).Error; err != nil {
+			return err
+		}
+		attachment, err := store(a.ID)
+		if err != nil {
+			return err
+		}
+		_, err = attachPaymentProof(tx, a, attachment)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create alumni: %w", err)
+	}
+	return nil
+}
+
+// SubmitPaymentProof replaces the alumnus's payment proof with the one store
+// writes and queues it for review. The row stays locked until the proof is
+// recorded, so a review landing meanwhile is not overwritten; once the
+// payment is approved it returns ErrInvalidPaymentTransition without calling
+// store. On success it returns the updated alumnus and the attachments
+// replaced, whose blobs the caller should delete; on error the caller
+// deletes whatever store wrote.
+func (s *service) SubmitPaymentProof(ctx context.Context, alumniID int, store func(alumniID int) (*Attachment, error)) (*Alumni, []Attachment, error) {
+	var alumni Alumni
+	var replaced []Attachment
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&alumni, alumniID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("alumni not found")
+			}
+			return err
+		}
+		if !CanTransitionPayment(alumni.PaymentStatus, PaymentSubmitted) {
+			return ErrInvalidPaymentTransition
+		}
+
+		attachment, err := store(alumni.ID)
+		if err != nil {
+			return err
+		}
+		replaced, err = attachPaymentProof(tx, &alumni, attachment)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, ErrInvalidPaymentTransition) {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("failed to submit payment proof: %w", err)
+	}
+	return &alumni, replaced, nil
+}
+
+// attachPaymentProof records attachment as a's payment proof and marks the
+// payment submitted.
+func attachPaymentProof(tx *gorm.DB, a *Alumni, attachment *Attachment) ([]Attachment, error) {
+	replaced, err := replaceAttachment(tx, attachment)
+	if err != nil {
+		return nil, err
+	}
+
+	a.PaymentProof = attachment.Filename
+	a.PaymentProofType = attachment.ContentType
+	a.PaymentProofSize = attachment.Size
+	a.PaymentStatus = PaymentSubmitted
+	a.PaymentRejectionReason = ""
+	err = tx.Model(a).
+		Select("payment_proof", "payment_proof_type", "payment_proof_size", "payment_status", "payment_rejection_reason").
+		Updates(a).Error
+	return replaced, err
+}
+
+// MigrateLegacyPaymentProofs moves payment proofs stored in the old
+// alumni.payment_proof_data column into the blob store. For each row, store
+// writes the bytes and returns the attachment to record; the attachment is
+// created and the inline bytes cleared in one transaction. Once no inline
+// bytes remain the column is dropped. It returns the number of rows moved.
+func (s *service) MigrateLegacyPaymentProofs(ctx context.Context, store func(LegacyPaymentProof) (*Attachment, error)) (int, error) {
+	db := s.db.WithContext(ctx)
+	if !db.Migrator().HasColumn(&Alumni{}, "payment_proof_data") {
+		return 0, nil
+	}
+
+	var ids []int
+	if err := db.Table("alumni").
+		Where("payment_proof_data IS NOT NULL AND length(payment_proof_data) > 0").
+		Order("id ASC").
+		Pluck("id", &ids).Error; err != nil {
+		return 0, fmt.Errorf("failed to list legacy payment proofs: %w", err)
+	}
+
+	moved := 0
+	for _, id := range ids {
+		// Load one row at a time so at most one file is held in memory.
+		var row struct {
+			PaymentProof     string
+			PaymentProofType string
+			PaymentProofData []byte
+		}
+		if err := db.Table("alumni").
+			Select("payment_proof, payment_proof_type, payment_proof_data").
+			Where("id = ?", id).
+			Take(&row).Error; err != nil {
+			return moved, fmt.Errorf("failed to load payment proof for alumni %d: %w", id, err)
+		}
+
+		attachment, err := store(LegacyPaymentProof{
+			AlumniID:    id,
+			Filename:    row.PaymentProof,
+			ContentType: row.PaymentProofType,
+			Data:        row.PaymentProofData,
+		})
+		if err != nil {
+			return moved, fmt.Errorf("failed to store payment proof for alumni %d: %w", id, err)
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(attachment).Error; err != nil {
+				return err
+			}
+			return tx.Table("alumni").Where("id = ?", id).Update("payment_proof_data", nil).Error
+		})
+		if err != nil {
+			return moved, fmt.Errorf("failed to record payment proof for alumni %d: %w", id, err)
+		}
+		moved++
+	}
+
+	if err := db.Migrator().DropColumn(&Alumni{}, "payment_proof_data"); err != nil {
+		return moved, fmt.Errorf("failed to drop payment_proof_data column: %w", err)
+	}
+
+	return moved, nil
+}
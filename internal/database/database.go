@@ -25,6 +25,19 @@ type Service interface {
 	AdminService
 	CourseService
 	SponsorshipService
+	RoleService
+	RateLimitService
+	LockService
+	AttachmentService
+	PaymentReviewService
+	PaymentService
+	GeocodeService
+	AlumniMapService
+	NominationCampaignService
+	NomineeService
+	JudgingService
+	EmailOptOutService
+	NominatorService
 }
 
 type service struct {
@@ -54,10 +67,18 @@ func New() Service {
 		log.Fatalf("failed to connect to DB: %v", err)
 	}
 
-	if err := db.AutoMigrate(&Alumni{}, &OTP{}, &Nomination{}, &Country{}, &Admin{}, &Course{}, &Sponsorship{}); err != nil {
+	if err := db.AutoMigrate(&Alumni{}, &OTP{}, &Nomination{}, &Country{}, &Admin{}, &Course{}, &Sponsorship{}, &Permission{}, &Role{}, &RateLimitCounter{}, &Attachment{}, &PaymentReview{}, &Payment{}, &GeocodeCache{}, &NominationCampaign{}, &CampaignCategory{}, &JudgingCriterion{}, &CampaignJudge{}, &ShortlistEntry{}, &JudgeScore{}, &EmailOptOut{}, &NominationChange{}); err != nil {
 		log.Fatalf("failed to migrate tables: %v", err)
 	}
 
+	// Nominations used to be unique per nominator and category forever; they
+	// are now unique within a campaign
+	if db.Migrator().HasIndex(&Nomination{}, "idx_nominator_category") {
+		if err := db.Migrator().DropIndex(&Nomination{}, "idx_nominator_category"); err != nil {
+			log.Fatalf("failed to drop old nomination index: %v", err)
+		}
+	}
+
 	dbInstance = &service{
 		db: db,
 	}
@@ -72,9 +93,19 @@ func New() Service {
 		log.Printf("Warning: failed to seed courses: %v", err)
 	}
 
-	// Seed default admin
-	if err := dbInstance.SeedDefaultAdmin(context.Background()); err != nil {
-		log.Printf("Warning: failed to seed default admin: %v", err)
+	// Seed roles and permissions
+	if err := dbInstance.SeedRoles(context.Background()); err != nil {
+		log.Printf("Warning: failed to seed roles: %v", err)
+	}
+
+	// Queue payments accepted before proofs were reviewed
+	if err := dbInstance.BackfillPaymentStatus(context.Background()); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Force a password change for accounts still on a published password
+	if err := dbInstance.ExpireKnownPasswords(context.Background()); err != nil {
+		log.Printf("Warning: failed to expire known admin passwords: %v", err)
 	}
 
 	return dbInstance
@@ -139,3 +170,24 @@ func (s *service) Close() error {
 	log.Printf("Disconnected from database: %s", database)
 	return sqlDB.Close()
 }
+
+// eachRow streams the rows of query into T one at a time, so large result
+// sets are never loaded into memory at once.
+func eachRow[T any](query *gorm.DB, fn func(T) error) error {
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record T
+		if err := query.ScanRows(rows, &record); err != nil {
+			return err
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
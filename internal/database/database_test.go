@@ -2,7 +2,9 @@ package database
 
 import (
 	"context"
+	"errors"
 	"log"
+	"sync"
 	"testing"
 	"time"
 
@@ -91,6 +93,1180 @@ func TestHealth(t *testing.T) {
 	}
 }
 
+func TestVerifyOTPInvalidatesAfterMaxAttempts(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+
+	otp, err := srv.CreateOTP(ctx, "attempts@example.com", "registration")
+	if err != nil {
+		t.Fatalf("CreateOTP() returned error: %v", err)
+	}
+
+	wrong := "x" + otp.Code
+	for i := 0; i < otpMaxAttempts; i++ {
+		if _, err := srv.VerifyOTP(ctx, otp.Email, wrong, otp.Purpose); err == nil {
+			t.Fatalf("expected wrong code to be rejected")
+		}
+	}
+
+	if _, err := srv.VerifyOTP(ctx, otp.Email, otp.Code, otp.Purpose); err != ErrOTPInvalid {
+		t.Fatalf("expected ErrOTPInvalid after too many attempts, got %v", err)
+	}
+}
+
+func TestVerifyOTPConcurrentGuesses(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+
+	otp, err := srv.CreateOTP(ctx, "concurrent@example.com", "registration")
+	if err != nil {
+		t.Fatalf("CreateOTP() returned error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4*otpMaxAttempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			srv.VerifyOTP(ctx, otp.Email, "x"+otp.Code, otp.Purpose)
+		}()
+	}
+	wg.Wait()
+
+	var stored OTP
+	if err := srv.(*service).db.First(&stored, otp.ID).Error; err != nil {
+		t.Fatalf("failed to load OTP: %v", err)
+	}
+	if stored.Attempts != otpMaxAttempts || !stored.Used {
+		t.Fatalf("expected %d attempts and the OTP invalidated, got %d attempts, used %v", otpMaxAttempts, stored.Attempts, stored.Used)
+	}
+}
+
+func TestCreateOTPInvalidatesPreviousCode(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+
+	first, err := srv.CreateOTP(ctx, "reissue@example.com", "nomination")
+	if err != nil {
+		t.Fatalf("CreateOTP() returned error: %v", err)
+	}
+	second, err := srv.CreateOTP(ctx, "reissue@example.com", "nomination")
+	if err != nil {
+		t.Fatalf("CreateOTP() returned error: %v", err)
+	}
+
+	if first.Code != second.Code {
+		if _, err := srv.VerifyOTP(ctx, first.Email, first.Code, first.Purpose); err == nil {
+			t.Fatalf("expected earlier code to be invalidated")
+		}
+	}
+	if _, err := srv.VerifyOTP(ctx, second.Email, second.Code, second.Purpose); err != nil {
+		t.Fatalf("expected latest code to verify, got %v", err)
+	}
+}
+
+func TestCreateLoginLink(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+
+	link, err := srv.CreateLoginLink(ctx, "login@example.com")
+	if err != nil {
+		t.Fatalf("CreateLoginLink() returned error: %v", err)
+	}
+	if len(link.Code) != 64 {
+		t.Errorf("expected a 64 character token, got %q", link.Code)
+	}
+
+	if _, err := srv.VerifyOTP(ctx, link.Email, link.Code, "registration"); err != ErrOTPInvalid {
+		t.Errorf("expected a login token not to verify for another purpose, got %v", err)
+	}
+	if _, err := srv.VerifyOTP(ctx, link.Email, link.Code, LoginPurpose); err != nil {
+		t.Fatalf("VerifyOTP() returned error: %v", err)
+	}
+	if _, err := srv.VerifyOTP(ctx, link.Email, link.Code, LoginPurpose); err != ErrOTPInvalid {
+		t.Errorf("expected a login link to work only once, got %v", err)
+	}
+}
+
+func TestCreateAdminRoles(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+
+	if _, err := srv.CreateAdmin(ctx, "roles.unknown", "roles-password", RoleViewer, "no-such-role"); !errors.Is(err, ErrUnknownRole) {
+		t.Fatalf("expected ErrUnknownRole, got %v", err)
+	}
+	// The failed attempt left no account behind, so the username is free
+	admin, err := srv.CreateAdmin(ctx, "roles.unknown", "roles-password")
+	if err != nil {
+		t.Fatalf("CreateAdmin() returned error: %v", err)
+	}
+	roles, err := srv.GetAdminRoles(ctx, admin.ID)
+	if err != nil || len(roles) != 1 || roles[0].Name != RoleViewer {
+		t.Errorf("expected the viewer role by default, got %+v, %v", roles, err)
+	}
+
+	if _, err := srv.CreateAdmin(ctx, "roles.repeated", "roles-password", RoleViewer, RoleViewer); !errors.Is(err, ErrRoleRepeated) {
+		t.Errorf("expected ErrRoleRepeated, got %v", err)
+	}
+	if err := srv.SetAdminRoles(ctx, admin.ID, nil); !errors.Is(err, ErrNoRoles) {
+		t.Errorf("expected ErrNoRoles, got %v", err)
+	}
+}
+
+func TestAdminAccountManagement(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+
+	admin, err := srv.CreateAdmin(ctx, "manage.target", "first-password")
+	if err != nil {
+		t.Fatalf("CreateAdmin() returned error: %v", err)
+	}
+
+	// Disabled admins cannot log in until enabled again
+	if err := srv.SetAdminDisabled(ctx, admin.ID, true); err != nil {
+		t.Fatalf("SetAdminDisabled() returned error: %v", err)
+	}
+	if _, err := srv.AuthenticateAdmin(ctx, "manage.target", "first-password"); !errors.Is(err, ErrAdminDisabled) {
+		t.Errorf("expected ErrAdminDisabled, got %v", err)
+	}
+	if err := srv.SetAdminDisabled(ctx, admin.ID, false); err != nil {
+		t.Fatalf("SetAdminDisabled() returned error: %v", err)
+	}
+	if _, err := srv.AuthenticateAdmin(ctx, "manage.target", "first-password"); err != nil {
+		t.Errorf("expected an enabled admin to log in, got %v", err)
+	}
+	if err := srv.SetAdminDisabled(ctx, -1, true); err == nil {
+		t.Errorf("expected disabling a missing admin to fail")
+	}
+
+	// A reset password works at once but has to be changed
+	if err := srv.ResetAdminPassword(ctx, admin.ID, "temporary-password"); err != nil {
+		t.Fatalf("ResetAdminPassword() returned error: %v", err)
+	}
+	reset, err := srv.AuthenticateAdmin(ctx, "manage.target", "temporary-password")
+	if err != nil {
+		t.Fatalf("AuthenticateAdmin() returned error: %v", err)
+	}
+	if !reset.MustChangePassword {
+		t.Errorf("expected a reset password to require a change")
+	}
+	if _, err := srv.AuthenticateAdmin(ctx, "manage.target", "first-password"); err == nil {
+		t.Errorf("expected the old password to stop working")
+	}
+
+	if err := srv.ChangeAdminPassword(ctx, admin.ID, "wrong-password", "new-password"); !errors.Is(err, ErrInvalidPassword) {
+		t.Errorf("expected ErrInvalidPassword, got %v", err)
+	}
+	if err := srv.ChangeAdminPassword(ctx, admin.ID, "temporary-password", "temporary-password"); !errors.Is(err, ErrSamePasswordReused) {
+		t.Errorf("expected ErrSamePasswordReused, got %v", err)
+	}
+	if err := srv.ChangeAdminPassword(ctx, admin.ID, "temporary-password", "new-password"); err != nil {
+		t.Fatalf("ChangeAdminPassword() returned error: %v", err)
+	}
+	changed, err := srv.AuthenticateAdmin(ctx, "manage.target", "new-password")
+	if err != nil {
+		t.Fatalf("AuthenticateAdmin() returned error: %v", err)
+	}
+	if changed.MustChangePassword {
+		t.Errorf("expected changing the password to clear the forced change")
+	}
+}
+
+func TestLastAdminManager(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+
+	manager, err := srv.CreateAdmin(ctx, "roles.manager", "roles-password", RoleSuperuser)
+	if err != nil {
+		t.Fatalf("CreateAdmin() returned error: %v", err)
+	}
+	// Leave roles.manager as the only admin who can manage admins
+	admins, err := srv.ListAdmins(ctx)
+	if err != nil {
+		t.Fatalf("ListAdmins() returned error: %v", err)
+	}
+	for _, a := range admins {
+		if a.ID != manager.ID {
+			if err := srv.SetAdminRoles(ctx, a.ID, []string{RoleViewer}); err != nil {
+				t.Fatalf("SetAdminRoles(%s) returned error: %v", a.Username, err)
+			}
+		}
+	}
+
+	if err := srv.SetAdminRoles(ctx, manager.ID, []string{RoleViewer}); !errors.Is(err, ErrLastAdminManager) {
+		t.Errorf("expected demoting the last manager to fail, got %v", err)
+	}
+	if err := srv.SetAdminDisabled(ctx, manager.ID, true); !errors.Is(err, ErrLastAdminManager) {
+		t.Errorf("expected disabling the last manager to fail, got %v", err)
+	}
+	if err := srv.DeleteAdmin(ctx, manager.ID); !errors.Is(err, ErrLastAdminManager) {
+		t.Errorf("expected deleting the last manager to fail, got %v", err)
+	}
+	roles, err := srv.GetAdminRoles(ctx, manager.ID)
+	if err != nil || len(roles) != 1 || roles[0].Name != RoleSuperuser {
+		t.Errorf("expected the refused change to be rolled back, got %+v, %v", roles, err)
+	}
+
+	// With a second manager the first may step down
+	second, err := srv.CreateAdmin(ctx, "roles.manager2", "roles-password", RoleSuperuser)
+	if err != nil {
+		t.Fatalf("CreateAdmin() returned error: %v", err)
+	}
+	if err := srv.SetAdminRoles(ctx, manager.ID, []string{RoleViewer}); err != nil {
+		t.Errorf("SetAdminRoles() returned error: %v", err)
+	}
+	if err := srv.SetAdminRoles(ctx, second.ID, []string{RoleViewer, RoleJudge}); !errors.Is(err, ErrLastAdminManager) {
+		t.Errorf("expected demoting the remaining manager to fail, got %v", err)
+	}
+}
+
+func TestSeedRolesBackfillsOnce(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+
+	admin, err := srv.CreateSuperuser(ctx, "roles.stripped", "roles-password")
+	if err != nil {
+		t.Fatalf("CreateSuperuser() returned error: %v", err)
+	}
+	// An admin left without roles after the backfill ran stays that way
+	db := srv.(*service).db
+	if err := db.Exec("DELETE FROM admin_roles WHERE admin_id = ?", admin.ID).Error; err != nil {
+		t.Fatalf("failed to clear roles: %v", err)
+	}
+	if err := srv.SeedRoles(ctx); err != nil {
+		t.Fatalf("SeedRoles() returned error: %v", err)
+	}
+	roles, err := srv.GetAdminRoles(ctx, admin.ID)
+	if err != nil || len(roles) != 0 {
+		t.Errorf("expected no roles to be restored, got %+v, %v", roles, err)
+	}
+}
+
+// withoutAdmins runs fn against a service whose view of the database has
+// no admins, inside a transaction that is rolled back afterwards.
+func withoutAdmins(t *testing.T, fn func(s *service)) {
+	t.Helper()
+	tx := New().(*service).db.Begin()
+	defer tx.Rollback()
+	if err := tx.Exec("DELETE FROM admin_roles").Error; err != nil {
+		t.Fatalf("failed to clear admin roles: %v", err)
+	}
+	if err := tx.Exec("DELETE FROM admins").Error; err != nil {
+		t.Fatalf("failed to clear admins: %v", err)
+	}
+	fn(&service{db: tx})
+}
+
+func TestCreateFirstSuperuser(t *testing.T) {
+	ctx := context.Background()
+	withoutAdmins(t, func(s *service) {
+		admin, err := s.CreateFirstSuperuser(ctx, "first.superuser", "first-password")
+		if err != nil {
+			t.Fatalf("CreateFirstSuperuser() returned error: %v", err)
+		}
+		roles, err := s.GetAdminRoles(ctx, admin.ID)
+		if err != nil || len(roles) != 1 || roles[0].Name != RoleSuperuser {
+			t.Errorf("expected the superuser role, got %+v, %v", roles, err)
+		}
+		if _, err := s.CreateFirstSuperuser(ctx, "second.superuser", "second-password"); !errors.Is(err, ErrSetupAlreadyDone) {
+			t.Errorf("expected ErrSetupAlreadyDone once an admin exists, got %v", err)
+		}
+	})
+}
+
+func TestSeedAdmin(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+
+	if err := srv.SeedAdmin(ctx, "seed.known", knownPasswords[0], false); !errors.Is(err, ErrKnownSeedPassword) {
+		t.Errorf("expected ErrKnownSeedPassword outside dev mode, got %v", err)
+	}
+	if err := srv.SeedAdmin(ctx, "seed.known", knownPasswords[0], true); err != nil {
+		t.Errorf("expected a known password to be allowed in dev mode, got %v", err)
+	}
+
+	if err := srv.SeedAdmin(ctx, "seed.admin", "seed-password", false); err != nil {
+		t.Fatalf("SeedAdmin() returned error: %v", err)
+	}
+	admin, err := srv.AuthenticateAdmin(ctx, "seed.admin", "seed-password")
+	if err != nil {
+		t.Fatalf("AuthenticateAdmin() returned error: %v", err)
+	}
+	if !admin.MustChangePassword {
+		t.Errorf("expected a seeded admin to have to change their password")
+	}
+	roles, err := srv.GetAdminRoles(ctx, admin.ID)
+	if err != nil || len(roles) != 1 || roles[0].Name != RoleSuperuser {
+		t.Errorf("expected the superuser role, got %+v, %v", roles, err)
+	}
+
+	// Seeding again leaves the existing account alone
+	if err := srv.ChangeAdminPassword(ctx, admin.ID, "seed-password", "changed-password"); err != nil {
+		t.Fatalf("ChangeAdminPassword() returned error: %v", err)
+	}
+	if err := srv.SeedAdmin(ctx, "seed.admin", "seed-password", false); err != nil {
+		t.Fatalf("SeedAdmin() returned error: %v", err)
+	}
+	if _, err := srv.AuthenticateAdmin(ctx, "seed.admin", "changed-password"); err != nil {
+		t.Errorf("expected the changed password to survive a second seed, got %v", err)
+	}
+}
+
+func TestExpireKnownPasswords(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+
+	known, err := srv.CreateAdmin(ctx, "expire.known", knownPasswords[0])
+	if err != nil {
+		t.Fatalf("CreateAdmin() returned error: %v", err)
+	}
+	other, err := srv.CreateAdmin(ctx, "expire.other", "private-password")
+	if err != nil {
+		t.Fatalf("CreateAdmin() returned error: %v", err)
+	}
+
+	if err := srv.ExpireKnownPasswords(ctx); err != nil {
+		t.Fatalf("ExpireKnownPasswords() returned error: %v", err)
+	}
+
+	for _, tt := range []struct {
+		id   int
+		want bool
+	}{{known.ID, true}, {other.ID, false}} {
+		admin, err := srv.GetAdminByID(ctx, tt.id)
+		if err != nil {
+			t.Fatalf("GetAdminByID() returned error: %v", err)
+		}
+		if admin.MustChangePassword != tt.want {
+			t.Errorf("%s: expected MustChangePassword %v", admin.Username, tt.want)
+		}
+	}
+}
+
+func TestSeedRolesBackfillsLegacyAdmins(t *testing.T) {
+	ctx := context.Background()
+	withoutAdmins(t, func(s *service) {
+		legacy := []Admin{
+			{Username: "legacy.superuser", Password: "x", IsSuperuser: true},
+			{Username: "legacy.staff", Password: "x"},
+		}
+		if err := s.db.Create(&legacy).Error; err != nil {
+			t.Fatalf("failed to create legacy admins: %v", err)
+		}
+
+		if err := s.SeedRoles(ctx); err != nil {
+			t.Fatalf("SeedRoles() returned error: %v", err)
+		}
+		for i, want := range []string{RoleSuperuser, RoleRegistrar} {
+			roles, err := s.GetAdminRoles(ctx, legacy[i].ID)
+			if err != nil || len(roles) != 1 || roles[0].Name != want {
+				t.Errorf("%s: expected the %s role, got %+v, %v", legacy[i].Username, want, roles, err)
+			}
+		}
+	})
+}
+
+func TestSearchAlumni(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+
+	for _, a := range []Alumni{
+		{FirstName: "Ana", LastName: "Search", Email: "ana.search@example.com", Year: 2010, Course: "BSIT", Company: "Acme", Paid: true},
+		{FirstName: "Ben", LastName: "Search", Email: "ben.search@example.com", Year: 2010, Course: "BSCS", Company: "Globex"},
+		{FirstName: "Cai", LastName: "Search", Email: "cai.search@example.com", Year: 2012, Course: "BSIT", Company: "Acme 100%"},
+		{FirstName: "Dee", LastName: "Search", Email: "dee.search@example.com", Year: 2013, Country: "Japan", City: "Osaka", MapPrecision: MapPrecisionCountry},
+		{FirstName: "Eli", LastName: "Search", Email: "eli.search@example.com", Year: 2013, Country: "Japan", City: "Osaka", MapPrecision: MapPrecisionHidden},
+	} {
+		a := a
+		if err := srv.SaveAlumni(ctx, &a); err != nil {
+			t.Fatalf("SaveAlumni() returned error: %v", err)
+		}
+	}
+
+	paid := true
+	tests := []struct {
+		name  string
+		query AlumniQuery
+		want  int64
+	}{
+		{"year", AlumniQuery{Search: "search", Year: 2010}, 2},
+		{"city", AlumniQuery{Search: "search", City: "osaka"}, 2},
+		{"public city", AlumniQuery{Search: "search", City: "osaka", Public: true}, 0},
+		{"public country", AlumniQuery{Search: "search", Country: "Japan", Public: true}, 1},
+		{"course", AlumniQuery{Search: "search", Course: "BSIT"}, 2},
+		{"paid", AlumniQuery{Search: "search", Paid: &paid}, 1},
+		{"free text", AlumniQuery{Search: "acme"}, 2},
+		{"literal wildcard", AlumniQuery{Search: "100%"}, 1},
+		{"email", AlumniQuery{Search: "ana.search@"}, 1},
+		{"public email", AlumniQuery{Search: "ana.search@", Public: true}, 0},
+	}
+	for _, tt := range tests {
+		_, total, err := srv.SearchAlumni(ctx, tt.query)
+		if err != nil {
+			t.Fatalf("%s: SearchAlumni() returned error: %v", tt.name, err)
+		}
+		if total != tt.want {
+			t.Errorf("%s: expected %d results, got %d", tt.name, tt.want, total)
+		}
+	}
+
+	alumni, _, err := srv.SearchAlumni(ctx, AlumniQuery{Search: "search", SortBy: "year", SortDesc: true})
+	if err != nil {
+		t.Fatalf("SearchAlumni() returned error: %v", err)
+	}
+	if len(alumni) == 0 || alumni[0].Year != 2013 {
+		t.Errorf("expected results sorted by year descending")
+	}
+
+	if _, _, err := srv.SearchAlumni(ctx, AlumniQuery{SortBy: "payment_proof_data"}); err != ErrInvalidSortField {
+		t.Errorf("expected ErrInvalidSortField, got %v", err)
+	}
+}
+
+func TestReplaceAttachment(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+
+	alumni := Alumni{FirstName: "Ana", LastName: "Attach", Email: "attach@example.com"}
+	if err := srv.SaveAlumni(ctx, &alumni); err != nil {
+		t.Fatalf("SaveAlumni() returned error: %v", err)
+	}
+
+	first := &Attachment{AlumniID: alumni.ID, Kind: AttachmentPaymentProof, StorageKey: "test/first"}
+	if _, err := srv.ReplaceAttachment(ctx, first); err != nil {
+		t.Fatalf("ReplaceAttachment() returned error: %v", err)
+	}
+	second := &Attachment{AlumniID: alumni.ID, Kind: AttachmentPaymentProof, StorageKey: "test/second"}
+	replaced, err := srv.ReplaceAttachment(ctx, second)
+	if err != nil {
+		t.Fatalf("ReplaceAttachment() returned error: %v", err)
+	}
+	if len(replaced) != 1 || replaced[0].StorageKey != "test/first" {
+		t.Errorf("expected the first attachment to be replaced, got %+v", replaced)
+	}
+
+	got, err := srv.GetAttachment(ctx, alumni.ID, AttachmentPaymentProof)
+	if err != nil || got == nil || got.StorageKey != "test/second" {
+		t.Errorf("expected the second attachment to be current, got %+v (%v)", got, err)
+	}
+}
+
+func TestPaymentProofIsAtomic(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+	reviewer := &Admin{ID: 1, Username: "reviewer"}
+	storeAs := func(key string) func(int) (*Attachment, error) {
+		return func(alumniID int) (*Attachment, error) {
+			return &Attachment{AlumniID: alumniID, Kind: AttachmentPaymentProof, StorageKey: key, Filename: key + ".png", Size: 10}, nil
+		}
+	}
+
+	// A failed upload leaves no registration behind
+	failed := Alumni{FirstName: "Ana", LastName: "Atomic", Email: "atomic.failed@example.com"}
+	err := srv.CreateAlumniWithPaymentProof(ctx, &failed, func(int) (*Attachment, error) {
+		return nil, errors.New("blob store down")
+	})
+	if err == nil {
+		t.Fatalf("expected the failed upload to fail the registration")
+	}
+	if found, err := srv.FindAlumniByEmail(ctx, "atomic.failed@example.com"); err != nil || found != nil {
+		t.Errorf("expected no alumni row after a failed upload, got %+v (%v)", found, err)
+	}
+
+	alumni := Alumni{FirstName: "Ben", LastName: "Atomic", Email: "atomic@example.com"}
+	if err := srv.CreateAlumniWithPaymentProof(ctx, &alumni, storeAs("test/atomic-first")); err != nil {
+		t.Fatalf("CreateAlumniWithPaymentProof() returned error: %v", err)
+	}
+	if alumni.PaymentStatus != PaymentSubmitted || alumni.PaymentProof != "test/atomic-first.png" {
+		t.Errorf("expected a submitted proof, got %+v", alumni)
+	}
+
+	updated, replaced, err := srv.SubmitPaymentProof(ctx, alumni.ID, storeAs("test/atomic-second"))
+	if err != nil {
+		t.Fatalf("SubmitPaymentProof() returned error: %v", err)
+	}
+	if len(replaced) != 1 || replaced[0].StorageKey != "test/atomic-first" || updated.PaymentProof != "test/atomic-second.png" {
+		t.Errorf("expected the first proof to be replaced, got %+v, %+v", replaced, updated)
+	}
+
+	if _, err := srv.ReviewPayment(ctx, alumni.ID, reviewer, PaymentApproved, ""); err != nil {
+		t.Fatalf("ReviewPayment() returned error: %v", err)
+	}
+	called := false
+	_, _, err = srv.SubmitPaymentProof(ctx, alumni.ID, func(int) (*Attachment, error) {
+		called = true
+		return nil, errors.New("unexpected store")
+	})
+	if !errors.Is(err, ErrInvalidPaymentTransition) || called {
+		t.Errorf("expected an approved payment to refuse a new proof without storing it, got %v (stored %v)", err, called)
+	}
+}
+
+func TestReviewPayment(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+	reviewer := &Admin{ID: 1, Username: "reviewer"}
+
+	alumni := Alumni{FirstName: "Ana", LastName: "Review", Email: "review@example.com", PaymentStatus: PaymentSubmitted}
+	if err := srv.SaveAlumni(ctx, &alumni); err != nil {
+		t.Fatalf("SaveAlumni() returned error: %v", err)
+	}
+
+	if _, err := srv.ReviewPayment(ctx, alumni.ID, reviewer, PaymentRejected, ""); err != ErrRejectionReasonRequired {
+		t.Fatalf("expected ErrRejectionReasonRequired, got %v", err)
+	}
+
+	rejected, err := srv.ReviewPayment(ctx, alumni.ID, reviewer, PaymentRejected, "Amount does not match")
+	if err != nil {
+		t.Fatalf("ReviewPayment() returned error: %v", err)
+	}
+	if rejected.Paid || rejected.PaymentRejectionReason != "Amount does not match" {
+		t.Errorf("expected an unpaid rejection with a reason, got %+v", rejected)
+	}
+
+	if _, err := srv.ReviewPayment(ctx, alumni.ID, reviewer, PaymentApproved, ""); err != ErrInvalidPaymentTransition {
+		t.Errorf("expected a rejected payment to need a new proof before approval, got %v", err)
+	}
+
+	reviews, err := srv.GetPaymentReviews(ctx, alumni.ID)
+	if err != nil {
+		t.Fatalf("GetPaymentReviews() returned error: %v", err)
+	}
+	if len(reviews) != 1 || reviews[0].FromStatus != PaymentSubmitted || reviews[0].ReviewerName != "reviewer" {
+		t.Errorf("expected one audited rejection, got %+v", reviews)
+	}
+}
+
+func TestUpdateAlumniKeepsOtherColumns(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+	reviewer := &Admin{ID: 1, Username: "reviewer"}
+
+	alumni := Alumni{FirstName: "Ana", LastName: "Stale", Email: "stale@example.com", PaymentStatus: PaymentSubmitted}
+	if err := srv.SaveAlumni(ctx, &alumni); err != nil {
+		t.Fatalf("SaveAlumni() returned error: %v", err)
+	}
+	stale, err := srv.GetAlumniByID(ctx, alumni.ID)
+	if err != nil {
+		t.Fatalf("GetAlumniByID() returned error: %v", err)
+	}
+
+	// An admin approves the payment while the alumnus edits their profile
+	if _, err := srv.ReviewPayment(ctx, alumni.ID, reviewer, PaymentApproved, ""); err != nil {
+		t.Fatalf("ReviewPayment() returned error: %v", err)
+	}
+	stale.Company = "Acme"
+	if err := srv.UpdateAlumni(ctx, stale, "company"); err != nil {
+		t.Fatalf("UpdateAlumni() returned error: %v", err)
+	}
+
+	got, err := srv.GetAlumniByID(ctx, alumni.ID)
+	if err != nil {
+		t.Fatalf("GetAlumniByID() returned error: %v", err)
+	}
+	if got.Company != "Acme" || !got.Paid || got.PaymentStatus != PaymentApproved {
+		t.Errorf("expected the profile edit and the approval to both stick, got %+v", got)
+	}
+}
+
+func TestApplyPaymentEvent(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+
+	alumni := Alumni{FirstName: "Ana", LastName: "Online", Email: "online@example.com"}
+	if err := srv.SaveAlumni(ctx, &alumni); err != nil {
+		t.Fatalf("SaveAlumni() returned error: %v", err)
+	}
+	payment := Payment{AlumniID: alumni.ID, Provider: "fake", SessionID: "cs_1", Amount: 150000, Currency: "PHP"}
+	if err := srv.CreatePayment(ctx, &payment); err != nil {
+		t.Fatalf("CreatePayment() returned error: %v", err)
+	}
+
+	mismatched, changed, err := srv.ApplyPaymentEvent(ctx, "fake", "cs_1", "evt_0", CheckoutPaid, 100, "PHP")
+	if err != nil || !changed || mismatched.Status != CheckoutMismatched || mismatched.PaidAt != nil {
+		t.Fatalf("expected the payment to be marked mismatched, got %+v, %v", mismatched, err)
+	}
+	if got, err := srv.GetAlumniByID(ctx, alumni.ID); err != nil || got.Paid {
+		t.Fatalf("expected a mismatched payment to leave the alumni unpaid, got %+v, %v", got, err)
+	}
+	if _, _, err := srv.ApplyPaymentEvent(ctx, "fake", "cs_missing", "evt_0", CheckoutPaid, 150000, "PHP"); err != ErrPaymentNotFound {
+		t.Fatalf("expected ErrPaymentNotFound, got %v", err)
+	}
+
+	paid, changed, err := srv.ApplyPaymentEvent(ctx, "fake", "cs_1", "evt_1", CheckoutPaid, 150000, "PHP")
+	if err != nil {
+		t.Fatalf("ApplyPaymentEvent() returned error: %v", err)
+	}
+	if !changed || paid.Status != CheckoutPaid || paid.PaidAt == nil {
+		t.Errorf("expected the payment to be paid, got %+v", paid)
+	}
+
+	// Retried and late events leave a paid payment alone
+	for _, status := range []string{CheckoutPaid, CheckoutExpired} {
+		if _, changed, err := srv.ApplyPaymentEvent(ctx, "fake", "cs_1", "evt_2", status, 150000, "PHP"); err != nil || changed {
+			t.Errorf("expected %s event to be a no-op, got changed=%v err=%v", status, changed, err)
+		}
+	}
+
+	updated, err := srv.GetAlumniByID(ctx, alumni.ID)
+	if err != nil {
+		t.Fatalf("GetAlumniByID() returned error: %v", err)
+	}
+	if !updated.Paid || updated.PaymentStatus != PaymentApproved {
+		t.Errorf("expected alumni to be approved and paid, got %+v", updated)
+	}
+
+	reviews, err := srv.GetPaymentReviews(ctx, alumni.ID)
+	if err != nil || len(reviews) != 1 || reviews[0].ReviewerName != "fake" {
+		t.Errorf("expected one review recorded for the provider, got %+v (%v)", reviews, err)
+	}
+
+	totals, err := srv.GetPaymentTotals(ctx)
+	if err != nil {
+		t.Fatalf("GetPaymentTotals() returned error: %v", err)
+	}
+	if len(totals) != 1 || totals[0].Status != CheckoutPaid || totals[0].Count != 1 || totals[0].Amount != 150000 {
+		t.Errorf("unexpected totals %+v", totals)
+	}
+}
+
+func TestGeocodeCache(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+
+	if entry, err := srv.GetGeocodeCache(ctx, "PH", "silay"); err != nil || entry != nil {
+		t.Fatalf("expected no cache entry, got %+v, %v", entry, err)
+	}
+
+	if err := srv.SaveGeocodeCache(ctx, &GeocodeCache{CountryCode: "PH", City: "silay", Provider: "offline"}); err != nil {
+		t.Fatalf("SaveGeocodeCache() returned error: %v", err)
+	}
+	if err := srv.SaveGeocodeCache(ctx, &GeocodeCache{CountryCode: "PH", City: "silay", Found: true, Latitude: 10.7967, Longitude: 122.9756, Provider: "offline"}); err != nil {
+		t.Fatalf("SaveGeocodeCache() returned error: %v", err)
+	}
+	entry, err := srv.GetGeocodeCache(ctx, "PH", "silay")
+	if err != nil {
+		t.Fatalf("GetGeocodeCache() returned error: %v", err)
+	}
+	if entry == nil || !entry.Found || entry.Latitude != 10.7967 {
+		t.Errorf("expected the later answer to replace the miss, got %+v", entry)
+	}
+
+	alumni := Alumni{FirstName: "Geo", LastName: "Code", Email: "geo.code@example.com", City: "Silay", Country: "Philippines"}
+	if err := srv.SaveAlumni(ctx, &alumni); err != nil {
+		t.Fatalf("SaveAlumni() returned error: %v", err)
+	}
+	missing, err := srv.GetAlumniMissingLocation(ctx)
+	if err != nil {
+		t.Fatalf("GetAlumniMissingLocation() returned error: %v", err)
+	}
+	found := false
+	for _, a := range missing {
+		found = found || a.ID == alumni.ID
+	}
+	if !found {
+		t.Fatalf("expected alumni %d to be missing a location", alumni.ID)
+	}
+
+	// The alumnus moved after the lookup, so Silay's coordinates do not apply
+	if err := srv.SetAlumniLocation(ctx, alumni.ID, "Philippines", "Bacolod", entry.Latitude, entry.Longitude); err != nil {
+		t.Fatalf("SetAlumniLocation() returned error: %v", err)
+	}
+	moved, err := srv.GetAlumniByID(ctx, alumni.ID)
+	if err != nil {
+		t.Fatalf("GetAlumniByID() returned error: %v", err)
+	}
+	if moved.Latitude != 0 || moved.Longitude != 0 {
+		t.Errorf("expected another city's coordinates to be ignored, got %f, %f", moved.Latitude, moved.Longitude)
+	}
+
+	if err := srv.SetAlumniLocation(ctx, alumni.ID, alumni.Country, alumni.City, entry.Latitude, entry.Longitude); err != nil {
+		t.Fatalf("SetAlumniLocation() returned error: %v", err)
+	}
+	located, err := srv.GetAlumniByID(ctx, alumni.ID)
+	if err != nil {
+		t.Fatalf("GetAlumniByID() returned error: %v", err)
+	}
+	if located.Latitude != 10.7967 || located.Longitude != 122.9756 {
+		t.Errorf("expected coordinates to be set, got %f, %f", located.Latitude, located.Longitude)
+	}
+}
+
+func TestMapClusters(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+
+	for _, a := range []Alumni{
+		{FirstName: "Map", LastName: "One", Email: "map.one@example.com", Year: 1999, Course: "BSME", Country: "Philippines", City: "Bacolod", Latitude: 10.67, Longitude: 122.95, MapPrecision: MapPrecisionExact},
+		{FirstName: "Map", LastName: "Two", Email: "map.two@example.com", Year: 1999, Course: "BSME", Country: "Philippines", City: "Silay", Latitude: 10.79, Longitude: 122.97, MapPrecision: MapPrecisionCity},
+		{FirstName: "Map", LastName: "Three", Email: "map.three@example.com", Year: 1999, Course: "BSME", Country: "Japan", City: "Tokyo", Latitude: 35.67, Longitude: 139.65, MapPrecision: MapPrecisionCountry},
+		{FirstName: "Map", LastName: "Four", Email: "map.four@example.com", Year: 1999, Course: "BSME", Country: "Japan", City: "Osaka", Latitude: 34.69, Longitude: 135.50, MapPrecision: MapPrecisionHidden},
+	} {
+		a := a
+		if err := srv.SaveAlumni(ctx, &a); err != nil {
+			t.Fatalf("SaveAlumni() returned error: %v", err)
+		}
+	}
+
+	world := MapQuery{MinLat: -90, MinLng: -180, MaxLat: 90, MaxLng: 180, CellSize: 22.5, Year: 1999, Course: "BSME"}
+	clusters, err := srv.GetMapClusters(ctx, world)
+	if err != nil {
+		t.Fatalf("GetMapClusters() returned error: %v", err)
+	}
+	if len(clusters) != 1 || clusters[0].Count != 2 {
+		t.Errorf("expected the two Negros alumni in one cluster, got %+v", clusters)
+	}
+
+	zoomed := world
+	zoomed.CellSize = 0.05
+	if clusters, err = srv.GetMapClusters(ctx, zoomed); err != nil {
+		t.Fatalf("GetMapClusters() returned error: %v", err)
+	}
+	if len(clusters) != 2 {
+		t.Errorf("expected separate clusters when zoomed in, got %+v", clusters)
+	}
+
+	outside := world
+	outside.MinLng, outside.MaxLng = 130, 150
+	if clusters, err = srv.GetMapClusters(ctx, outside); err != nil {
+		t.Fatalf("GetMapClusters() returned error: %v", err)
+	}
+	if len(clusters) != 0 {
+		t.Errorf("expected alumni who hide their coordinates to be left out, got %+v", clusters)
+	}
+
+	countries, err := srv.GetCountryCounts(ctx, world)
+	if err != nil {
+		t.Fatalf("GetCountryCounts() returned error: %v", err)
+	}
+	if len(countries) != 2 || countries[0].Country != "Philippines" || countries[0].Count != 2 || countries[1].Count != 1 {
+		t.Errorf("unexpected country counts %+v", countries)
+	}
+
+	cities, err := srv.GetCityCounts(ctx, world)
+	if err != nil {
+		t.Fatalf("GetCityCounts() returned error: %v", err)
+	}
+	if len(cities) != 2 {
+		t.Errorf("expected only Bacolod and Silay to be counted, got %+v", cities)
+	}
+}
+
+func TestNominationCampaign(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+
+	nomination := Nomination{FirstName: "Ana", LastName: "Nominee", NominatorEmail: "campaign.nominator@example.com", Year: 2000, Category: "Lifetime Achievement Award"}
+	if err := srv.SaveNomination(ctx, &nomination); !errors.Is(err, ErrNominationsClosed) {
+		t.Fatalf("expected ErrNominationsClosed without a campaign, got %v", err)
+	}
+
+	now := time.Now()
+	campaign := NominationCampaign{
+		Name:     "Homecoming",
+		OpensAt:  now.Add(-time.Hour),
+		ClosesAt: now.Add(time.Hour),
+		Categories: []CampaignCategory{
+			{Name: "Young Achiever Award", MaxYearsSinceGraduation: 10},
+			{Name: "Lifetime Achievement Award", MinYearsSinceGraduation: 20},
+		},
+	}
+	if err := srv.SaveNominationCampaign(ctx, &campaign); err != nil {
+		t.Fatalf("SaveNominationCampaign() returned error: %v", err)
+	}
+
+	overlapping := campaign
+	overlapping.ID = 0
+	overlapping.Categories = []CampaignCategory{{Name: "Young Achiever Award"}}
+	if err := srv.SaveNominationCampaign(ctx, &overlapping); !errors.Is(err, ErrCampaignOverlap) {
+		t.Errorf("expected ErrCampaignOverlap, got %v", err)
+	}
+
+	if err := srv.SaveNomination(ctx, &nomination); err != nil {
+		t.Fatalf("SaveNomination() returned error: %v", err)
+	}
+	if nomination.CampaignID == nil || *nomination.CampaignID != campaign.ID {
+		t.Errorf("expected the nomination to be filed under campaign %d", campaign.ID)
+	}
+
+	// A nominator changing the case of their address is still the same nominator
+	again := Nomination{FirstName: "Ben", LastName: "Nominee", NominatorEmail: " Campaign.Nominator@Example.com", Year: 1995, Category: nomination.Category}
+	if err := srv.SaveNomination(ctx, &again); !errors.Is(err, ErrDuplicateNomination) {
+		t.Errorf("expected ErrDuplicateNomination, got %v", err)
+	}
+
+	unknown := Nomination{FirstName: "Cai", LastName: "Nominee", NominatorEmail: nomination.NominatorEmail, Year: 2000, Category: "Best Dressed"}
+	if err := srv.SaveNomination(ctx, &unknown); !errors.Is(err, ErrUnknownCategory) {
+		t.Errorf("expected ErrUnknownCategory, got %v", err)
+	}
+
+	young := Nomination{FirstName: "Dee", LastName: "Nominee", NominatorEmail: nomination.NominatorEmail, Year: 1990, Category: "Young Achiever Award"}
+	if err := srv.SaveNomination(ctx, &young); !errors.Is(err, ErrNominationRule) {
+		t.Errorf("expected ErrNominationRule, got %v", err)
+	}
+
+	if err := srv.DeleteNominationCampaign(ctx, campaign.ID); !errors.Is(err, ErrCampaignInUse) {
+		t.Errorf("expected ErrCampaignInUse, got %v", err)
+	}
+
+	// Close the campaign so later tests see nominations closed
+	campaign.ClosesAt = now.Add(-time.Minute)
+	campaign.OpensAt = now.Add(-time.Hour)
+	if err := srv.SaveNominationCampaign(ctx, &campaign); err != nil {
+		t.Fatalf("SaveNominationCampaign() returned error: %v", err)
+	}
+	saved, err := srv.GetNominationCampaign(ctx, campaign.ID)
+	if err != nil {
+		t.Fatalf("GetNominationCampaign() returned error: %v", err)
+	}
+	if len(saved.Categories) != 2 || saved.Categories[0].Name != "Young Achiever Award" {
+		t.Errorf("expected categories to be kept in order, got %+v", saved.Categories)
+	}
+	if open, err := srv.GetOpenNominationCampaign(ctx, now); err != nil || open != nil {
+		t.Errorf("expected no open campaign, got %+v, %v", open, err)
+	}
+}
+
+func TestNominees(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+
+	alumnus := Alumni{FirstName: "Juan", LastName: "Dela Cruz", Email: "Juan.DelaCruz@example.com", Year: 2015, Course: "BSIT"}
+	if err := srv.SaveAlumni(ctx, &alumnus); err != nil {
+		t.Fatalf("SaveAlumni() returned error: %v", err)
+	}
+
+	now := time.Now()
+	campaign := NominationCampaign{
+		Name:       "Nominee linking",
+		OpensAt:    now.Add(-30 * time.Second),
+		ClosesAt:   now.Add(time.Hour),
+		Categories: []CampaignCategory{{Name: "Technology", RequireNomineeEmail: true}},
+	}
+	if err := srv.SaveNominationCampaign(ctx, &campaign); err != nil {
+		t.Fatalf("SaveNominationCampaign() returned error: %v", err)
+	}
+
+	candidates, err := srv.SearchNomineeCandidates(ctx, "juan dela", 5)
+	if err != nil {
+		t.Fatalf("SearchNomineeCandidates() returned error: %v", err)
+	}
+	if len(candidates) != 1 || candidates[0].ID != alumnus.ID {
+		t.Fatalf("expected to find the alumnus, got %+v", candidates)
+	}
+
+	byEmail := Nomination{FirstName: "J.", LastName: "Cruz", NominatedEmail: "juan.delacruz@example.com", NominatorEmail: "first.nominator@example.com", Year: 2014, Category: "Technology"}
+	if err := srv.SaveNomination(ctx, &byEmail); err != nil {
+		t.Fatalf("SaveNomination() returned error: %v", err)
+	}
+	if byEmail.AlumniID == nil || *byEmail.AlumniID != alumnus.ID || byEmail.LastName != "DELA CRUZ" || byEmail.Year != 2015 {
+		t.Errorf("expected the nomination to be linked by email, got %+v", byEmail)
+	}
+
+	// A chosen alumnus satisfies the nominee email rule
+	byID := Nomination{AlumniID: &candidates[0].ID, FirstName: "Juan", LastName: "Cruz", NominatorEmail: "second.nominator@example.com", Category: "Technology"}
+	if err := srv.SaveNomination(ctx, &byID); err != nil {
+		t.Fatalf("SaveNomination() returned error: %v", err)
+	}
+
+	missing := 0
+	unknown := Nomination{AlumniID: &missing, FirstName: "No", LastName: "One", NominatorEmail: "third.nominator@example.com", Category: "Technology"}
+	if err := srv.SaveNomination(ctx, &unknown); !errors.Is(err, ErrNomineeNotFound) {
+		t.Errorf("expected ErrNomineeNotFound, got %v", err)
+	}
+
+	typed := Nomination{FirstName: "Juan D.", LastName: "Cruz", NominatedEmail: "juan.work@example.com", NominatorEmail: "third.nominator@example.com", Year: 2015, Category: "Technology"}
+	if err := srv.SaveNomination(ctx, &typed); err != nil {
+		t.Fatalf("SaveNomination() returned error: %v", err)
+	}
+	if typed.AlumniID != nil {
+		t.Errorf("expected an unknown email to leave the nomination unlinked")
+	}
+
+	suggestions, err := srv.SuggestNomineeMerges(ctx, "Technology")
+	if err != nil {
+		t.Fatalf("SuggestNomineeMerges() returned error: %v", err)
+	}
+	if len(suggestions) != 1 || suggestions[0].Duplicate.NominationIDs[0] != typed.ID {
+		t.Fatalf("expected the typed nomination to be suggested for merging, got %+v", suggestions)
+	}
+
+	if _, err := srv.MergeNominees(ctx, []int{typed.ID}, MergeTarget{}, "merger"); !errors.Is(err, ErrInvalidMerge) {
+		t.Errorf("expected ErrInvalidMerge, got %v", err)
+	}
+	merged, err := srv.MergeNominees(ctx, suggestions[0].Duplicate.NominationIDs, MergeTarget{NominationID: byEmail.ID}, "merger")
+	if err != nil || merged != 1 {
+		t.Fatalf("MergeNominees() returned %d, %v", merged, err)
+	}
+
+	groups, err := srv.FindNominationsByCategoryGrouped(ctx, "Technology")
+	if err != nil {
+		t.Fatalf("FindNominationsByCategoryGrouped() returned error: %v", err)
+	}
+	if len(groups) != 1 || groups[0].Count != 3 || groups[0].AlumniID == nil {
+		t.Errorf("expected one linked nominee with 3 nominations, got %+v", groups)
+	}
+	history, err := srv.GetNominationHistory(ctx, typed.ID, "")
+	if err != nil {
+		t.Fatalf("GetNominationHistory() returned error: %v", err)
+	}
+	if last := history[len(history)-1]; last.Action != NominationUpdated || last.ChangedBy != "merger" || last.AlumniID == nil {
+		t.Errorf("expected the merge to be recorded, got %+v", last)
+	}
+
+	// Nominations in a campaign with final results stay as they are
+	db := srv.(*service).db
+	if err := db.Model(&campaign).Update("results_locked_at", now).Error; err != nil {
+		t.Fatalf("failed to lock results: %v", err)
+	}
+	if _, err := srv.MergeNominees(ctx, []int{typed.ID}, MergeTarget{NominationID: byEmail.ID}, "merger"); !errors.Is(err, ErrResultsLocked) {
+		t.Errorf("expected ErrResultsLocked, got %v", err)
+	}
+	if err := db.Model(&campaign).Update("results_locked_at", nil).Error; err != nil {
+		t.Fatalf("failed to unlock results: %v", err)
+	}
+
+	// Close the campaign so later tests see nominations closed
+	campaign.ClosesAt = now.Add(-10 * time.Second)
+	if err := srv.SaveNominationCampaign(ctx, &campaign); err != nil {
+		t.Fatalf("SaveNominationCampaign() returned error: %v", err)
+	}
+}
+
+func TestJudging(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+
+	judge, err := srv.CreateAdmin(ctx, "judging.judge", "judging-password")
+	if err != nil {
+		t.Fatalf("CreateAdmin() returned error: %v", err)
+	}
+	if err := srv.SetAdminRoles(ctx, judge.ID, []string{RoleJudge}); err != nil {
+		t.Fatalf("SetAdminRoles() returned error: %v", err)
+	}
+	outsider, err := srv.CreateAdmin(ctx, "judging.outsider", "judging-password")
+	if err != nil {
+		t.Fatalf("CreateAdmin() returned error: %v", err)
+	}
+	if err := srv.SetAdminRoles(ctx, outsider.ID, []string{RoleViewer}); err != nil {
+		t.Fatalf("SetAdminRoles() returned error: %v", err)
+	}
+
+	now := time.Now()
+	campaign := NominationCampaign{
+		Name:       "Judging",
+		OpensAt:    now.Add(-5 * time.Second),
+		ClosesAt:   now.Add(time.Hour),
+		Categories: []CampaignCategory{{Name: "Service"}},
+	}
+	if err := srv.SaveNominationCampaign(ctx, &campaign); err != nil {
+		t.Fatalf("SaveNominationCampaign() returned error: %v", err)
+	}
+	for i, nominator := range []string{"judging.a@example.com", "judging.b@example.com", "judging.c@example.com"} {
+		last := "Leading"
+		if i == 2 {
+			last = "Trailing"
+		}
+		n := Nomination{FirstName: "Nia", LastName: last, NominatorEmail: nominator, Year: 2001, Category: "Service"}
+		if err := srv.SaveNomination(ctx, &n); err != nil {
+			t.Fatalf("SaveNomination() returned error: %v", err)
+		}
+	}
+
+	tally, err := srv.GetCampaignTally(ctx, campaign.ID, "Service")
+	if err != nil || len(tally) != 2 || tally[0].Count != 2 {
+		t.Fatalf("expected a tally of 2 nominees led by 2 nominations, got %+v, %v", tally, err)
+	}
+
+	leading := ShortlistEntry{CampaignID: campaign.ID, Category: "Service", FirstName: "nia", LastName: "leading", Year: 2001}
+	if err := srv.AddToShortlist(ctx, &leading); err != nil || leading.Nominations != 2 {
+		t.Fatalf("AddToShortlist() returned %v with %d nominations", err, leading.Nominations)
+	}
+	trailing := ShortlistEntry{CampaignID: campaign.ID, Category: "Service", FirstName: "Nia", LastName: "Trailing", Year: 2001}
+	if err := srv.AddToShortlist(ctx, &trailing); err != nil {
+		t.Fatalf("AddToShortlist() returned error: %v", err)
+	}
+	again := ShortlistEntry{CampaignID: campaign.ID, Category: "Service", FirstName: "Nia", LastName: "Leading", Year: 2001}
+	if err := srv.AddToShortlist(ctx, &again); !errors.Is(err, ErrAlreadyShortlisted) {
+		t.Errorf("expected ErrAlreadyShortlisted, got %v", err)
+	}
+	stranger := ShortlistEntry{CampaignID: campaign.ID, Category: "Service", FirstName: "No", LastName: "Body", Year: 2001}
+	if err := srv.AddToShortlist(ctx, &stranger); !errors.Is(err, ErrNotNominated) {
+		t.Errorf("expected ErrNotNominated, got %v", err)
+	}
+
+	if err := srv.SetJudgingCriteria(ctx, campaign.ID, []JudgingCriterion{{Name: "Impact", Weight: 2}, {Name: "Service", Weight: 1}}); err != nil {
+		t.Fatalf("SetJudgingCriteria() returned error: %v", err)
+	}
+	criteria, err := srv.GetJudgingCriteria(ctx, campaign.ID)
+	if err != nil || len(criteria) != 2 {
+		t.Fatalf("expected 2 criteria, got %+v, %v", criteria, err)
+	}
+
+	if err := srv.SetCampaignJudges(ctx, campaign.ID, []int{outsider.ID}); !errors.Is(err, ErrInvalidJudge) {
+		t.Errorf("expected ErrInvalidJudge for an admin without the judging permission, got %v", err)
+	}
+	if err := srv.SetCampaignJudges(ctx, campaign.ID, []int{judge.ID}); err != nil {
+		t.Fatalf("SetCampaignJudges() returned error: %v", err)
+	}
+
+	impact, service := criteria[0].ID, criteria[1].ID
+	if err := srv.SaveJudgeScores(ctx, campaign.ID, outsider.ID, leading.ID, []JudgeScore{{CriterionID: impact, Score: 5}}); !errors.Is(err, ErrNotJudge) {
+		t.Errorf("expected ErrNotJudge, got %v", err)
+	}
+	if err := srv.SaveJudgeScores(ctx, campaign.ID, judge.ID, leading.ID, []JudgeScore{{CriterionID: impact, Score: 11}}); !errors.Is(err, ErrInvalidScore) {
+		t.Errorf("expected ErrInvalidScore, got %v", err)
+	}
+	if err := srv.SaveJudgeScores(ctx, campaign.ID, judge.ID, leading.ID, []JudgeScore{{CriterionID: impact, Score: 4}, {CriterionID: service, Score: 9}}); err != nil {
+		t.Fatalf("SaveJudgeScores() returned error: %v", err)
+	}
+	// Scoring again replaces the earlier score
+	if err := srv.SaveJudgeScores(ctx, campaign.ID, judge.ID, leading.ID, []JudgeScore{{CriterionID: impact, Score: 9}}); err != nil {
+		t.Fatalf("SaveJudgeScores() returned error: %v", err)
+	}
+	if err := srv.SaveJudgeScores(ctx, campaign.ID, judge.ID, trailing.ID, []JudgeScore{{CriterionID: impact, Score: 5}, {CriterionID: service, Score: 5}}); err != nil {
+		t.Fatalf("SaveJudgeScores() returned error: %v", err)
+	}
+
+	if err := srv.LockResults(ctx, campaign.ID); !errors.Is(err, ErrCampaignStillOpen) {
+		t.Errorf("expected ErrCampaignStillOpen, got %v", err)
+	}
+	if err := srv.PublishResults(ctx, campaign.ID); !errors.Is(err, ErrResultsNotLocked) {
+		t.Errorf("expected ErrResultsNotLocked, got %v", err)
+	}
+
+	campaign.ClosesAt = time.Now()
+	if err := srv.SaveNominationCampaign(ctx, &campaign); err != nil {
+		t.Fatalf("SaveNominationCampaign() returned error: %v", err)
+	}
+	if err := srv.LockResults(ctx, campaign.ID); err != nil {
+		t.Fatalf("LockResults() returned error: %v", err)
+	}
+	if err := srv.SaveJudgeScores(ctx, campaign.ID, judge.ID, trailing.ID, []JudgeScore{{CriterionID: impact, Score: 10}}); !errors.Is(err, ErrResultsLocked) {
+		t.Errorf("expected ErrResultsLocked, got %v", err)
+	}
+
+	winners, err := srv.GetPublishedWinners(ctx)
+	if err != nil || len(winners) != 0 {
+		t.Errorf("expected no winners before publishing, got %+v, %v", winners, err)
+	}
+	if err := srv.PublishResults(ctx, campaign.ID); err != nil {
+		t.Fatalf("PublishResults() returned error: %v", err)
+	}
+	winners, err = srv.GetPublishedWinners(ctx)
+	if err != nil {
+		t.Fatalf("GetPublishedWinners() returned error: %v", err)
+	}
+	if len(winners) != 1 || winners[0].LastName != "LEADING" || winners[0].CampaignName != "Judging" {
+		t.Errorf("expected NIA LEADING to win, got %+v", winners)
+	}
+}
+
+func TestNominatorChanges(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+
+	now := time.Now()
+	campaign := NominationCampaign{
+		Name:       "Nominator Changes",
+		OpensAt:    now, // TestJudging's campaign closed just before
+		ClosesAt:   now.Add(time.Hour),
+		Categories: []CampaignCategory{{Name: "Service"}, {Name: "Mentoring"}},
+	}
+	if err := srv.SaveNominationCampaign(ctx, &campaign); err != nil {
+		t.Fatalf("SaveNominationCampaign() returned error: %v", err)
+	}
+
+	nominator := "changes.nominator@example.com"
+	n := Nomination{FirstName: "Typo", LastName: "Nominee", NominatorEmail: nominator, Year: 2005, Category: "Service"}
+	if err := srv.SaveNomination(ctx, &n); err != nil {
+		t.Fatalf("SaveNomination() returned error: %v", err)
+	}
+
+	edit := Nomination{ID: n.ID, FirstName: "Fixed", LastName: "Nominee", Year: 2006, Category: "Mentoring"}
+	if err := srv.UpdateOwnNomination(ctx, "someone.else@example.com", &edit); !errors.Is(err, ErrNominationNotFound) {
+		t.Errorf("expected ErrNominationNotFound for another nominator, got %v", err)
+	}
+	if err := srv.UpdateOwnNomination(ctx, "Changes.Nominator@example.com", &edit); err != nil {
+		t.Fatalf("UpdateOwnNomination() returned error: %v", err)
+	}
+	if edit.FirstName != "FIXED" || edit.Category != "Mentoring" || edit.NominatorEmail != nominator {
+		t.Errorf("expected the saved nomination back, got %+v", edit)
+	}
+
+	// The edit freed Service, so the nominator can nominate there again
+	second := Nomination{FirstName: "Second", LastName: "Nominee", NominatorEmail: nominator, Year: 2007, Category: "Service"}
+	if err := srv.SaveNomination(ctx, &second); err != nil {
+		t.Fatalf("SaveNomination() returned error: %v", err)
+	}
+	clash := Nomination{ID: second.ID, FirstName: "Second", LastName: "Nominee", Year: 2007, Category: "Mentoring"}
+	if err := srv.UpdateOwnNomination(ctx, nominator, &clash); !errors.Is(err, ErrDuplicateNomination) {
+		t.Errorf("expected ErrDuplicateNomination, got %v", err)
+	}
+
+	if err := srv.WithdrawNomination(ctx, "someone.else@example.com", second.ID); !errors.Is(err, ErrNominationNotFound) {
+		t.Errorf("expected ErrNominationNotFound for another nominator, got %v", err)
+	}
+	if err := srv.WithdrawNomination(ctx, nominator, second.ID); err != nil {
+		t.Fatalf("WithdrawNomination() returned error: %v", err)
+	}
+
+	mine, err := srv.FindNominationsByNominator(ctx, nominator)
+	if err != nil || len(mine) != 1 || mine[0].ID != n.ID {
+		t.Errorf("expected only the edited nomination, got %+v, %v", mine, err)
+	}
+
+	history, err := srv.GetNominationHistory(ctx, n.ID, nominator)
+	if err != nil || len(history) != 2 || history[0].Action != NominationCreated || history[1].Action != NominationUpdated {
+		t.Fatalf("expected created and updated changes, got %+v, %v", history, err)
+	}
+	if history[0].FirstName != "TYPO" || history[1].FirstName != "FIXED" {
+		t.Errorf("expected the history to keep both versions, got %+v", history)
+	}
+	history, err = srv.GetNominationHistory(ctx, second.ID, nominator)
+	if err != nil || len(history) != 2 || history[1].Action != NominationWithdrawn {
+		t.Errorf("expected the withdrawal to be kept, got %+v, %v", history, err)
+	}
+	if history, err := srv.GetNominationHistory(ctx, n.ID, "someone.else@example.com"); err != nil || len(history) != 0 {
+		t.Errorf("expected no history for another nominator, got %+v, %v", history, err)
+	}
+
+	campaign.ClosesAt = time.Now()
+	if err := srv.SaveNominationCampaign(ctx, &campaign); err != nil {
+		t.Fatalf("SaveNominationCampaign() returned error: %v", err)
+	}
+	if err := srv.UpdateOwnNomination(ctx, nominator, &edit); !errors.Is(err, ErrNominationsClosed) {
+		t.Errorf("expected ErrNominationsClosed once the campaign closed, got %v", err)
+	}
+	if err := srv.WithdrawNomination(ctx, nominator, n.ID); !errors.Is(err, ErrNominationsClosed) {
+		t.Errorf("expected ErrNominationsClosed for a withdrawal once the campaign closed, got %v", err)
+	}
+	if err := srv.DeleteNomination(ctx, n.ID, "changes.admin"); err != nil {
+		t.Fatalf("DeleteNomination() returned error: %v", err)
+	}
+	history, err = srv.GetNominationHistory(ctx, n.ID, "")
+	if err != nil || len(history) != 3 || history[2].Action != NominationDeleted || history[2].ChangedBy != "changes.admin" {
+		t.Errorf("expected the admin deletion to be recorded, got %+v, %v", history, err)
+	}
+}
+
+func TestEmailOptOut(t *testing.T) {
+	srv := New()
+	ctx := context.Background()
+
+	if optedOut, err := srv.IsEmailOptedOut(ctx, "opt.out@example.com"); err != nil || optedOut {
+		t.Fatalf("expected the email not to be opted out, got %v, %v", optedOut, err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := srv.OptOutEmail(ctx, " Opt.Out@Example.com"); err != nil {
+			t.Fatalf("OptOutEmail() returned error: %v", err)
+		}
+	}
+	if optedOut, err := srv.IsEmailOptedOut(ctx, "OPT.OUT@example.com"); err != nil || !optedOut {
+		t.Errorf("expected the email to be opted out regardless of case, got %v, %v", optedOut, err)
+	}
+}
+
 func TestClose(t *testing.T) {
 	srv := New()
 
@@ -0,0 +1,52 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// EmailOptOut records an address that no longer wants nomination emails.
+// Codes, sign-in links and payment decisions are still sent to it.
+type EmailOptOut struct {
+	ID        int       `gorm:"column:id;primaryKey"`
+	Email     string    `gorm:"column:email;uniqueIndex;not null"` // lowercase
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (EmailOptOut) TableName() string {
+	return "email_opt_outs"
+}
+
+type EmailOptOutService interface {
+	OptOutEmail(ctx context.Context, email string) error
+	IsEmailOptedOut(ctx context.Context, email string) (bool, error)
+}
+
+// OptOutEmail stops nomination emails to email. Opting out twice is not an
+// error.
+func (s *service) OptOutEmail(ctx context.Context, email string) error {
+	optOut := EmailOptOut{Email: strings.ToLower(strings.TrimSpace(email))}
+	result := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "email"}},
+		DoNothing: true,
+	}).Create(&optOut)
+	if result.Error != nil {
+		return fmt.Errorf("failed to opt out email: %w", result.Error)
+	}
+	return nil
+}
+
+func (s *service) IsEmailOptedOut(ctx context.Context, email string) (bool, error) {
+	var count int64
+	result := s.db.WithContext(ctx).Model(&EmailOptOut{}).
+		Where("email = ?", strings.ToLower(strings.TrimSpace(email))).
+		Count(&count)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to check email opt-out: %w", result.Error)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GeocodeCache remembers a geocoder's answer for a city, including that it
+// had none, so each city is looked up once.
+type GeocodeCache struct {
+	ID          int       `gorm:"column:id;primaryKey"`
+	CountryCode string    `gorm:"column:country_code;uniqueIndex:idx_geocode_city;not null"`
+	City        string    `gorm:"column:city;uniqueIndex:idx_geocode_city;not null"` // normalized, see geocode.NormalizeCity
+	Found       bool      `gorm:"column:found"`
+	Latitude    float64   `gorm:"column:latitude"`
+	Longitude   float64   `gorm:"column:longitude"`
+	Provider    string    `gorm:"column:provider"`
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (GeocodeCache) TableName() string {
+	return "geocode_cache"
+}
+
+type GeocodeService interface {
+	GetGeocodeCache(ctx context.Context, countryCode, city string) (*GeocodeCache, error)
+	SaveGeocodeCache(ctx context.Context, entry *GeocodeCache) error
+	GetAlumniMissingLocation(ctx context.Context) ([]Alumni, error)
+	SetAlumniLocation(ctx context.Context, id int, country, city string, latitude, longitude float64) error
+}
+
+// GetGeocodeCache returns the cached answer for the city, or nil if it has
+// not been looked up yet.
+func (s *service) GetGeocodeCache(ctx context.Context, countryCode, city string) (*GeocodeCache, error) {
+	var entry GeocodeCache
+	result := s.db.WithContext(ctx).Where("country_code = ? AND city = ?", countryCode, city).First(&entry)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read geocode cache: %w", result.Error)
+	}
+	return &entry, nil
+}
+
+// SaveGeocodeCache stores entry, replacing any earlier answer for its city.
+func (s *service) SaveGeocodeCache(ctx context.Context, entry *GeocodeCache) error {
+	result := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "country_code"}, {Name: "city"}},
+		DoUpdates: clause.AssignmentColumns([]string{"found", "latitude", "longitude", "provider", "created_at"}),
+	}).Create(entry)
+	if result.Error != nil {
+		return fmt.Errorf("failed to save geocode cache: %w", result.Error)
+	}
+	return nil
+}
+
+// GetAlumniMissingLocation returns the alumni who gave a city and country
+// but no coordinates.
+func (s *service) GetAlumniMissingLocation(ctx context.Context) ([]Alumni, error) {
+	var alumni []Alumni
+	result := s.db.WithContext(ctx).
+		Where("latitude = 0 AND longitude = 0 AND city <> '' AND country <> ''").
+		Order("id").
+		Find(&alumni)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch alumni without a location: %w", result.Error)
+	}
+	return alumni, nil
+}
+
+// SetAlumniLocation fills in the coordinates geocoded for country and city.
+// Nothing changes if the alumnus has coordinates by now or has moved to
+// another city since it was looked up.
+func (s *service) SetAlumniLocation(ctx context.Context, id int, country, city string, latitude, longitude float64) error {
+	result := s.db.WithContext(ctx).Model(&Alumni{}).
+		Where("id = ? AND latitude = 0 AND longitude = 0 AND country = ? AND city = ?", id, country, city).
+		Updates(map[string]interface{}{"latitude": latitude, "longitude": longitude})
+	if result.Error != nil {
+		return fmt.Errorf("failed to set alumni location: %w", result.Error)
+	}
+	return nil
+}
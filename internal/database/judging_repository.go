@@ -0,0 +1,745 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MaxJudgeScore is the best score a judge can give for one criterion.
+const MaxJudgeScore = 10
+
+var (
+	ErrResultsLocked          = errors.New("the campaign's results are locked")
+	ErrResultsNotLocked       = errors.New("the campaign's results must be locked first")
+	ErrCampaignStillOpen      = errors.New("the campaign is still accepting nominations")
+	ErrNothingToJudge         = errors.New("the campaign needs a shortlist, judging criteria and, in each category, a judge who has scored every nominee")
+	ErrNotJudge               = errors.New("not a judge for this campaign")
+	ErrInvalidJudge           = errors.New("judges must be active admins with the judging permission")
+	ErrShortlistEntryNotFound = errors.New("shortlisted nominee not found")
+	ErrNotNominated           = errors.New("nominee has no nominations in this category")
+	ErrAlreadyShortlisted     = errors.New("nominee is already shortlisted")
+	ErrInvalidCriteria        = errors.New("invalid judging criteria")
+	ErrInvalidScore           = errors.New("invalid score")
+)
+
+// JudgingCriterion is one thing judges score shortlisted nominees on.
+// Criteria count towards a nominee's score in proportion to their weights.
+type JudgingCriterion struct {
+	ID          int     `gorm:"column:id;primaryKey"`
+	CampaignID  int     `gorm:"column:campaign_id;not null;index"`
+	Name        string  `gorm:"column:name;not null"`
+	Description string  `gorm:"column:description"`
+	Weight      float64 `gorm:"column:weight;not null"`
+	Position    int     `gorm:"column:position"` // display order
+}
+
+func (JudgingCriterion) TableName() string {
+	return "nomination_judging_criteria"
+}
+
+// CampaignJudge assigns an admin to judge a campaign's shortlist.
+type CampaignJudge struct {
+	CampaignID int       `gorm:"column:campaign_id;primaryKey"`
+	AdminID    int       `gorm:"column:admin_id;primaryKey"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (CampaignJudge) TableName() string {
+	return "nomination_judges"
+}
+
+// JudgeAccount is an admin as shown in judging, without credentials.
+type JudgeAccount struct {
+	ID       int
+	Username string
+}
+
+// ShortlistEntry is a nominee the committee put forward for judging in one
+// of a campaign's categories. Score, Rank and Winner are set when the
+// results are locked.
+type ShortlistEntry struct {
+	ID          int       `gorm:"column:id;primaryKey"`
+	CampaignID  int       `gorm:"column:campaign_id;not null;uniqueIndex:idx_shortlist_nominee"`
+	Category    string    `gorm:"column:category;not null;uniqueIndex:idx_shortlist_nominee"`
+	AlumniID    *int      `gorm:"column:alumni_id"`
+	FirstName   string    `gorm:"column:first_name;uniqueIndex:idx_shortlist_nominee"`
+	LastName    string    `gorm:"column:last_name;uniqueIndex:idx_shortlist_nominee"`
+	Year        int       `gorm:"column:year;uniqueIndex:idx_shortlist_nominee"`
+	Nominations int64     `gorm:"column:nominations"` // when shortlisted
+	Score       *float64  `gorm:"column:score"`
+	Rank        *int      `gorm:"column:rank"`
+	Winner      bool      `gorm:"column:winner;default:false"`
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (ShortlistEntry) TableName() string {
+	return "nomination_shortlist"
+}
+
+// JudgeScore is one judge's score for a shortlisted nominee on one
+// criterion, from 0 to MaxJudgeScore.
+type JudgeScore struct {
+	ID          int       `gorm:"column:id;primaryKey"`
+	EntryID     int       `gorm:"column:entry_id;not null;uniqueIndex:idx_judge_score"`
+	AdminID     int       `gorm:"column:admin_id;not null;uniqueIndex:idx_judge_score"`
+	CriterionID int       `gorm:"column:criterion_id;not null;uniqueIndex:idx_judge_score"`
+	Score       int       `gorm:"column:score;not null"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (JudgeScore) TableName() string {
+	return "nomination_judge_scores"
+}
+
+// Ranking is a shortlisted nominee's standing in its category. Score is
+// the weighted average of each criterion's mean score, with unscored
+// criteria counting as 0; nominees with equal scores share a rank.
+type Ranking struct {
+	Entry          ShortlistEntry
+	Score          float64
+	Rank           int
+	CompleteJudges int // judges who scored every criterion
+}
+
+// AwardWinner is a winner of a published campaign.
+type AwardWinner struct {
+	CampaignID   int
+	CampaignName string
+	Category     string
+	AlumniID     *int
+	FirstName    string
+	LastName     string
+	Year         int
+}
+
+type JudgingService interface {
+	GetCampaignTally(ctx context.Context, campaignID int, category string) ([]NomineeGroup, error)
+	GetShortlist(ctx context.Context, campaignID int) ([]ShortlistEntry, error)
+	AddToShortlist(ctx context.Context, e *ShortlistEntry) error
+	RemoveFromShortlist(ctx context.Context, campaignID, entryID int) error
+	GetJudgingCriteria(ctx context.Context, campaignID int) ([]JudgingCriterion, error)
+	SetJudgingCriteria(ctx context.Context, campaignID int, criteria []JudgingCriterion) error
+	GetJudgeCandidates(ctx context.Context) ([]JudgeAccount, error)
+	GetCampaignJudges(ctx context.Context, campaignID int) ([]JudgeAccount, error)
+	SetCampaignJudges(ctx context.Context, campaignID int, adminIDs []int) error
+	GetJudgeCampaigns(ctx context.Context, adminID int) ([]NominationCampaign, error)
+	IsCampaignJudge(ctx context.Context, campaignID, adminID int) (bool, error)
+	GetJudgeScores(ctx context.Context, campaignID, adminID int) ([]JudgeScore, error)
+	SaveJudgeScores(ctx context.Context, campaignID, adminID, entryID int, scores []JudgeScore) error
+	GetRankings(ctx context.Context, campaignID int) ([]Ranking, error)
+	LockResults(ctx context.Context, campaignID int) error
+	PublishResults(ctx context.Context, campaignID int) error
+	GetPublishedWinners(ctx context.Context) ([]AwardWinner, error)
+}
+
+// GetCampaignTally counts a campaign's nominations per nominee, as
+// FindNominationsByCategoryGrouped does for all nominations.
+func (s *service) GetCampaignTally(ctx context.Context, campaignID int, category string) ([]NomineeGroup, error) {
+	query := s.db.WithContext(ctx).Model(&Nomination{}).Where("campaign_id = ?", campaignID)
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+	return groupNominations(query)
+}
+
+func (s *service) GetShortlist(ctx context.Context, campaignID int) ([]ShortlistEntry, error) {
+	var entries []ShortlistEntry
+	result := s.db.WithContext(ctx).
+		Where("campaign_id = ?", campaignID).
+		Order("category ASC, nominations DESC, id ASC").
+		Find(&entries)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch shortlist: %w", result.Error)
+	}
+	return entries, nil
+}
+
+// AddToShortlist puts forward a nominee from the campaign's tally, named
+// by e.AlumniID or else by e's name and year.
+func (s *service) AddToShortlist(ctx context.Context, e *ShortlistEntry) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		campaign, err := unlockedCampaign(tx, e.CampaignID)
+		if err != nil {
+			return err
+		}
+		if campaign.Category(e.Category) == nil {
+			return ErrUnknownCategory
+		}
+
+		// The nominee's nominations, grouped as in the tally
+		nominations := func() *gorm.DB {
+			query := tx.Model(&Nomination{}).Where("campaign_id = ? AND category = ?", e.CampaignID, e.Category)
+			if e.AlumniID != nil {
+				return query.Where("alumni_id = ?", *e.AlumniID)
+			}
+			return query.Where("alumni_id IS NULL AND first_name = ? AND last_name = ? AND year = ?", e.FirstName, e.LastName, e.Year)
+		}
+
+		if e.AlumniID != nil {
+			var linked Nomination
+			if err := nominations().First(&linked).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return ErrNotNominated
+				}
+				return err
+			}
+			e.FirstName, e.LastName, e.Year = linked.FirstName, linked.LastName, linked.Year
+		} else {
+			e.FirstName = strings.ToUpper(strings.TrimSpace(e.FirstName))
+			e.LastName = strings.ToUpper(strings.TrimSpace(e.LastName))
+		}
+		if err := nominations().Count(&e.Nominations).Error; err != nil {
+			return err
+		}
+		if e.Nominations == 0 {
+			return ErrNotNominated
+		}
+
+		e.ID, e.Score, e.Rank, e.Winner = 0, nil, nil, false
+		if err := tx.Create(e).Error; err != nil {
+			if isUniqueConstraintError(err) {
+				return ErrAlreadyShortlisted
+			}
+			return err
+		}
+		return nil
+	})
+	return judgingError(err, "failed to shortlist nominee")
+}
+
+// RemoveFromShortlist drops a nominee from the shortlist along with their
+// scores.
+func (s *service) RemoveFromShortlist(ctx context.Context, campaignID, entryID int) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := unlockedCampaign(tx, campaignID); err != nil {
+			return err
+		}
+		if err := tx.Where("entry_id = ?", entryID).Delete(&JudgeScore{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("campaign_id = ?", campaignID).Delete(&ShortlistEntry{}, entryID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrShortlistEntryNotFound
+		}
+		return nil
+	})
+	return judgingError(err, "failed to remove nominee from shortlist")
+}
+
+func (s *service) GetJudgingCriteria(ctx context.Context, campaignID int) ([]JudgingCriterion, error) {
+	var criteria []JudgingCriterion
+	result := s.db.WithContext(ctx).Where("campaign_id = ?", campaignID).Order("position ASC, id ASC").Find(&criteria)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch judging criteria: %w", result.Error)
+	}
+	return criteria, nil
+}
+
+// SetJudgingCriteria replaces the campaign's criteria. Criteria keep their
+// scores when passed back with their IDs; criteria left out are deleted
+// with their scores.
+func (s *service) SetJudgingCriteria(ctx context.Context, campaignID int, criteria []JudgingCriterion) error {
+	if err := validateCriteria(criteria); err != nil {
+		return err
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := unlockedCampaign(tx, campaignID); err != nil {
+			return err
+		}
+
+		var existing []int
+		if err := tx.Model(&JudgingCriterion{}).Where("campaign_id = ?", campaignID).Pluck("id", &existing).Error; err != nil {
+			return err
+		}
+
+		kept := make(map[int]bool)
+		for i := range criteria {
+			c := &criteria[i]
+			c.CampaignID = campaignID
+			c.Position = i
+			if c.ID == 0 {
+				if err := tx.Create(c).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			result := tx.Model(c).Where("campaign_id = ?", campaignID).
+				Select("name", "description", "weight", "position").Updates(c)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("%w: criterion %d is not part of this campaign", ErrInvalidCriteria, c.ID)
+			}
+			kept[c.ID] = true
+		}
+
+		var removed []int
+		for _, id := range existing {
+			if !kept[id] {
+				removed = append(removed, id)
+			}
+		}
+		if len(removed) > 0 {
+			if err := tx.Where("criterion_id IN ?", removed).Delete(&JudgeScore{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Delete(&JudgingCriterion{}, removed).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return judgingError(err, "failed to save judging criteria")
+}
+
+func validateCriteria(criteria []JudgingCriterion) error {
+	if len(criteria) == 0 {
+		return fmt.Errorf("%w: at least one criterion is required", ErrInvalidCriteria)
+	}
+	seen := make(map[string]bool)
+	for i := range criteria {
+		c := &criteria[i]
+		c.Name = strings.TrimSpace(c.Name)
+		if c.Name == "" {
+			return fmt.Errorf("%w: every criterion needs a name", ErrInvalidCriteria)
+		}
+		if seen[c.Name] {
+			return fmt.Errorf("%w: criterion %q is listed twice", ErrInvalidCriteria, c.Name)
+		}
+		seen[c.Name] = true
+		if c.Weight <= 0 {
+			return fmt.Errorf("%w: %s needs a positive weight", ErrInvalidCriteria, c.Name)
+		}
+	}
+	return nil
+}
+
+// judgeAccounts selects active admins holding the judging permission.
+func judgeAccounts(db *gorm.DB) *gorm.DB {
+	return db.Model(&Admin{}).
+		Distinct("admins.id", "admins.username").
+		Joins("JOIN admin_roles ON admin_roles.admin_id = admins.id").
+		Joins("JOIN role_permissions ON role_permissions.role_id = admin_roles.role_id").
+		Joins("JOIN permissions ON permissions.id = role_permissions.permission_id").
+		Where("permissions.name = ? AND NOT admins.disabled", PermNominationJudge)
+}
+
+// GetJudgeCandidates lists the admins who may be assigned as judges.
+func (s *service) GetJudgeCandidates(ctx context.Context) ([]JudgeAccount, error) {
+	var judges []JudgeAccount
+	if err := judgeAccounts(s.db.WithContext(ctx)).Order("admins.username ASC").Scan(&judges).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch judge candidates: %w", err)
+	}
+	return judges, nil
+}
+
+func (s *service) GetCampaignJudges(ctx context.Context, campaignID int) ([]JudgeAccount, error) {
+	var judges []JudgeAccount
+	result := s.db.WithContext(ctx).Model(&Admin{}).
+		Select("admins.id, admins.username").
+		Joins("JOIN nomination_judges ON nomination_judges.admin_id = admins.id").
+		Where("nomination_judges.campaign_id = ?", campaignID).
+		Order("admins.username ASC").
+		Scan(&judges)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch judges: %w", result.Error)
+	}
+	return judges, nil
+}
+
+// SetCampaignJudges replaces the campaign's judges. Scores by judges who
+// are removed are kept but no longer count.
+func (s *service) SetCampaignJudges(ctx context.Context, campaignID int, adminIDs []int) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := unlockedCampaign(tx, campaignID); err != nil {
+			return err
+		}
+
+		judges := make([]CampaignJudge, 0, len(adminIDs))
+		seen := make(map[int]bool)
+		for _, id := range adminIDs {
+			if !seen[id] {
+				seen[id] = true
+				judges = append(judges, CampaignJudge{CampaignID: campaignID, AdminID: id})
+			}
+		}
+
+		if len(judges) > 0 {
+			var eligible int64
+			if err := tx.Table("(?) AS judges", judgeAccounts(tx).Where("admins.id IN ?", adminIDs)).Count(&eligible).Error; err != nil {
+				return err
+			}
+			if eligible != int64(len(judges)) {
+				return ErrInvalidJudge
+			}
+		}
+
+		if err := tx.Where("campaign_id = ?", campaignID).Delete(&CampaignJudge{}).Error; err != nil {
+			return err
+		}
+		if len(judges) > 0 {
+			return tx.Create(&judges).Error
+		}
+		return nil
+	})
+	return judgingError(err, "failed to assign judges")
+}
+
+// GetJudgeCampaigns lists the campaigns an admin judges, newest first.
+func (s *service) GetJudgeCampaigns(ctx context.Context, adminID int) ([]NominationCampaign, error) {
+	var campaigns []NominationCampaign
+	result := s.db.WithContext(ctx).
+		Select("nomination_campaigns.*").
+		Preload("Categories", orderCategories).
+		Joins("JOIN nomination_judges ON nomination_judges.campaign_id = nomination_campaigns.id").
+		Where("nomination_judges.admin_id = ?", adminID).
+		Order("opens_at DESC").
+		Find(&campaigns)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch judged campaigns: %w", result.Error)
+	}
+	return campaigns, nil
+}
+
+func (s *service) IsCampaignJudge(ctx context.Context, campaignID, adminID int) (bool, error) {
+	return isCampaignJudge(s.db.WithContext(ctx), campaignID, adminID)
+}
+
+func isCampaignJudge(db *gorm.DB, campaignID, adminID int) (bool, error) {
+	var count int64
+	err := db.Model(&CampaignJudge{}).Where("campaign_id = ? AND admin_id = ?", campaignID, adminID).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check judge: %w", err)
+	}
+	return count > 0, nil
+}
+
+// GetJudgeScores returns the scores one judge gave in a campaign.
+func (s *service) GetJudgeScores(ctx context.Context, campaignID, adminID int) ([]JudgeScore, error) {
+	var scores []JudgeScore
+	result := s.db.WithContext(ctx).
+		Select("nomination_judge_scores.*").
+		Joins("JOIN nomination_shortlist ON nomination_shortlist.id = nomination_judge_scores.entry_id").
+		Where("nomination_shortlist.campaign_id = ? AND nomination_judge_scores.admin_id = ?", campaignID, adminID).
+		Find(&scores)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch scores: %w", result.Error)
+	}
+	return scores, nil
+}
+
+// SaveJudgeScores records a judge's scores for one shortlisted nominee,
+// replacing any they gave before for the same criteria.
+func (s *service) SaveJudgeScores(ctx context.Context, campaignID, adminID, entryID int, scores []JudgeScore) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := unlockedCampaign(tx, campaignID); err != nil {
+			return err
+		}
+		judge, err := isCampaignJudge(tx, campaignID, adminID)
+		if err != nil {
+			return err
+		}
+		if !judge {
+			return ErrNotJudge
+		}
+
+		var entries int64
+		if err := tx.Model(&ShortlistEntry{}).Where("id = ? AND campaign_id = ?", entryID, campaignID).Count(&entries).Error; err != nil {
+			return err
+		}
+		if entries == 0 {
+			return ErrShortlistEntryNotFound
+		}
+
+		var criteria []int
+		if err := tx.Model(&JudgingCriterion{}).Where("campaign_id = ?", campaignID).Pluck("id", &criteria).Error; err != nil {
+			return err
+		}
+		for i := range scores {
+			score := &scores[i]
+			if !slices.Contains(criteria, score.CriterionID) {
+				return fmt.Errorf("%w: criterion %d is not part of this campaign", ErrInvalidScore, score.CriterionID)
+			}
+			if score.Score < 0 || score.Score > MaxJudgeScore {
+				return fmt.Errorf("%w: scores must be between 0 and %d", ErrInvalidScore, MaxJudgeScore)
+			}
+			score.ID, score.EntryID, score.AdminID = 0, entryID, adminID
+		}
+		if len(scores) == 0 {
+			return nil
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "entry_id"}, {Name: "admin_id"}, {Name: "criterion_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"score", "updated_at"}),
+		}).Create(&scores).Error
+	})
+	return judgingError(err, "failed to save scores")
+}
+
+// GetRankings ranks the shortlist on the current judges' scores.
+func (s *service) GetRankings(ctx context.Context, campaignID int) ([]Ranking, error) {
+	rankings, err := campaignRankings(s.db.WithContext(ctx), campaignID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rank shortlist: %w", err)
+	}
+	return rankings, nil
+}
+
+func campaignRankings(db *gorm.DB, campaignID int) ([]Ranking, error) {
+	entries, criteria, scores, err := campaignJudging(db, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	return rankShortlist(entries, criteria, scores), nil
+}
+
+// campaignJudging loads a campaign's shortlist, criteria and the scores of
+// its current judges.
+func campaignJudging(db *gorm.DB, campaignID int) ([]ShortlistEntry, []JudgingCriterion, []JudgeScore, error) {
+	var entries []ShortlistEntry
+	if err := db.Where("campaign_id = ?", campaignID).Order("id ASC").Find(&entries).Error; err != nil {
+		return nil, nil, nil, err
+	}
+	var criteria []JudgingCriterion
+	if err := db.Where("campaign_id = ?", campaignID).Find(&criteria).Error; err != nil {
+		return nil, nil, nil, err
+	}
+	var scores []JudgeScore
+	err := db.
+		Select("nomination_judge_scores.*").
+		Joins("JOIN nomination_shortlist ON nomination_shortlist.id = nomination_judge_scores.entry_id").
+		Joins("JOIN nomination_judges ON nomination_judges.campaign_id = nomination_shortlist.campaign_id AND nomination_judges.admin_id = nomination_judge_scores.admin_id").
+		Where("nomination_shortlist.campaign_id = ?", campaignID).
+		Find(&scores).Error
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return entries, criteria, scores, nil
+}
+
+// everyCategoryJudged reports whether each category on the shortlist has a
+// judge who scored all of its entries on every criterion.
+func everyCategoryJudged(entries []ShortlistEntry, criteria []JudgingCriterion, scores []JudgeScore) bool {
+	if len(entries) == 0 || len(criteria) == 0 {
+		return false
+	}
+
+	// entry -> judge -> criteria scored
+	scored := make(map[int]map[int]int)
+	for _, score := range scores {
+		if scored[score.EntryID] == nil {
+			scored[score.EntryID] = make(map[int]int)
+		}
+		scored[score.EntryID][score.AdminID]++
+	}
+
+	// category -> judges complete on every entry seen so far
+	complete := make(map[string]map[int]bool)
+	for _, entry := range entries {
+		judges := make(map[int]bool)
+		for judge, count := range scored[entry.ID] {
+			if count == len(criteria) && (complete[entry.Category] == nil || complete[entry.Category][judge]) {
+				judges[judge] = true
+			}
+		}
+		if len(judges) == 0 {
+			return false
+		}
+		complete[entry.Category] = judges
+	}
+	return true
+}
+
+// rankShortlist scores and ranks each category's entries, ordered by
+// category and then rank.
+func rankShortlist(entries []ShortlistEntry, criteria []JudgingCriterion, scores []JudgeScore) []Ranking {
+	var totalWeight float64
+	for _, c := range criteria {
+		totalWeight += c.Weight
+	}
+
+	// entry -> criterion -> scores, and entry -> judge -> criteria scored
+	byCriterion := make(map[int]map[int][]int)
+	byJudge := make(map[int]map[int]int)
+	for _, score := range scores {
+		if byCriterion[score.EntryID] == nil {
+			byCriterion[score.EntryID] = make(map[int][]int)
+			byJudge[score.EntryID] = make(map[int]int)
+		}
+		byCriterion[score.EntryID][score.CriterionID] = append(byCriterion[score.EntryID][score.CriterionID], score.Score)
+		byJudge[score.EntryID][score.AdminID]++
+	}
+
+	rankings := make([]Ranking, 0, len(entries))
+	for _, entry := range entries {
+		r := Ranking{Entry: entry}
+		if totalWeight > 0 {
+			var weighted float64
+			for _, c := range criteria {
+				if given := byCriterion[entry.ID][c.ID]; len(given) > 0 {
+					weighted += c.Weight * mean(given)
+				}
+			}
+			r.Score = weighted / totalWeight
+		}
+		for _, scored := range byJudge[entry.ID] {
+			if scored == len(criteria) {
+				r.CompleteJudges++
+			}
+		}
+		rankings = append(rankings, r)
+	}
+
+	sort.SliceStable(rankings, func(i, j int) bool {
+		if rankings[i].Entry.Category != rankings[j].Entry.Category {
+			return rankings[i].Entry.Category < rankings[j].Entry.Category
+		}
+		return rankings[i].Score > rankings[j].Score
+	})
+	position := 0
+	for i := range rankings {
+		if i == 0 || rankings[i].Entry.Category != rankings[i-1].Entry.Category {
+			position = 0
+		}
+		position++
+		if position > 1 && rankings[i].Score == rankings[i-1].Score {
+			rankings[i].Rank = rankings[i-1].Rank
+		} else {
+			rankings[i].Rank = position
+		}
+	}
+	return rankings
+}
+
+func mean(values []int) float64 {
+	var sum int
+	for _, v := range values {
+		sum += v
+	}
+	return float64(sum) / float64(len(values))
+}
+
+// LockResults freezes a closed campaign's scores and records each
+// shortlisted nominee's final score and rank. The top-ranked nominees in
+// each category win; tied nominees win jointly.
+func (s *service) LockResults(ctx context.Context, campaignID int) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		campaign, err := unlockedCampaign(tx, campaignID)
+		if err != nil {
+			return err
+		}
+		if time.Now().Before(campaign.ClosesAt) {
+			return ErrCampaignStillOpen
+		}
+
+		entries, criteria, scores, err := campaignJudging(tx, campaignID)
+		if err != nil {
+			return err
+		}
+		if !everyCategoryJudged(entries, criteria, scores) {
+			return ErrNothingToJudge
+		}
+
+		for _, r := range rankShortlist(entries, criteria, scores) {
+			err := tx.Model(&ShortlistEntry{}).Where("id = ?", r.Entry.ID).Updates(map[string]interface{}{
+				"score":  r.Score,
+				"rank":   r.Rank,
+				"winner": r.Rank == 1,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Model(&NominationCampaign{}).Where("id = ?", campaignID).Update("results_locked_at", time.Now()).Error
+	})
+	return judgingError(err, "failed to lock results")
+}
+
+// PublishResults makes a locked campaign's winners public.
+func (s *service) PublishResults(ctx context.Context, campaignID int) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var campaign NominationCampaign
+		if err := tx.First(&campaign, campaignID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCampaignNotFound
+			}
+			return err
+		}
+		if campaign.ResultsLockedAt == nil {
+			return ErrResultsNotLocked
+		}
+		if campaign.ResultsPublishedAt != nil {
+			return nil
+		}
+		return tx.Model(&campaign).Update("results_published_at", time.Now()).Error
+	})
+	return judgingError(err, "failed to publish results")
+}
+
+// GetPublishedWinners lists the winners of every published campaign, the
+// most recently published first and then in category order.
+func (s *service) GetPublishedWinners(ctx context.Context) ([]AwardWinner, error) {
+	var winners []AwardWinner
+	result := s.db.WithContext(ctx).Model(&ShortlistEntry{}).
+		Select("nomination_shortlist.campaign_id, nomination_campaigns.name AS campaign_name, nomination_shortlist.category, " +
+			"nomination_shortlist.alumni_id, nomination_shortlist.first_name, nomination_shortlist.last_name, nomination_shortlist.year").
+		Joins("JOIN nomination_campaigns ON nomination_campaigns.id = nomination_shortlist.campaign_id").
+		Joins("LEFT JOIN nomination_campaign_categories ON nomination_campaign_categories.campaign_id = nomination_shortlist.campaign_id AND nomination_campaign_categories.name = nomination_shortlist.category").
+		Where("nomination_shortlist.winner AND nomination_campaigns.results_published_at IS NOT NULL").
+		Order("nomination_campaigns.results_published_at DESC, nomination_campaign_categories.position ASC, nomination_shortlist.last_name ASC").
+		Scan(&winners)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch award winners: %w", result.Error)
+	}
+	return winners, nil
+}
+
+// unlockedCampaign loads a campaign whose results can still change.
+func unlockedCampaign(tx *gorm.DB, campaignID int) (*NominationCampaign, error) {
+	var campaign NominationCampaign
+	// Lock the row so a concurrent LockResults waits for this transaction
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Categories").First(&campaign, campaignID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCampaignNotFound
+		}
+		return nil, err
+	}
+	if campaign.ResultsLockedAt != nil {
+		return nil, ErrResultsLocked
+	}
+	return &campaign, nil
+}
+
+// judgingError passes the judging sentinel errors through and wraps the
+// rest with msg.
+func judgingError(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	for _, sentinel := range []error{
+		ErrCampaignNotFound, ErrUnknownCategory, ErrResultsLocked, ErrResultsNotLocked,
+		ErrCampaignStillOpen, ErrNothingToJudge, ErrNotJudge, ErrInvalidJudge,
+		ErrShortlistEntryNotFound, ErrNotNominated, ErrAlreadyShortlisted,
+		ErrInvalidCriteria, ErrInvalidScore,
+	} {
+		if errors.Is(err, sentinel) {
+			return err
+		}
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
@@ -0,0 +1,120 @@
+package database
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRankShortlist(t *testing.T) {
+	entries := []ShortlistEntry{
+		{ID: 1, Category: "Technology"},
+		{ID: 2, Category: "Technology"},
+		{ID: 3, Category: "Technology"},
+		{ID: 4, Category: "Business"},
+	}
+	criteria := []JudgingCriterion{
+		{ID: 10, Name: "Impact", Weight: 3},
+		{ID: 11, Name: "Service", Weight: 1},
+	}
+	scores := []JudgeScore{
+		// Entry 1: impact 8 and 6, service 4 -> (3*7 + 4) / 4
+		{EntryID: 1, AdminID: 100, CriterionID: 10, Score: 8},
+		{EntryID: 1, AdminID: 100, CriterionID: 11, Score: 4},
+		{EntryID: 1, AdminID: 101, CriterionID: 10, Score: 6},
+		// Entry 2: impact 7, service 4 -> the same score
+		{EntryID: 2, AdminID: 100, CriterionID: 10, Score: 7},
+		{EntryID: 2, AdminID: 100, CriterionID: 11, Score: 4},
+		// Entry 3: service only, impact counts as 0
+		{EntryID: 3, AdminID: 100, CriterionID: 11, Score: 10},
+		{EntryID: 4, AdminID: 100, CriterionID: 10, Score: 1},
+	}
+
+	rankings := rankShortlist(entries, criteria, scores)
+	if len(rankings) != 4 {
+		t.Fatalf("expected 4 rankings, got %d", len(rankings))
+	}
+
+	want := []struct {
+		entry    int
+		score    float64
+		rank     int
+		complete int
+	}{
+		{4, 0.75, 1, 0},
+		{1, 6.25, 1, 1},
+		{2, 6.25, 1, 1},
+		{3, 2.5, 3, 0},
+	}
+	for i, w := range want {
+		got := rankings[i]
+		if got.Entry.ID != w.entry || got.Score != w.score || got.Rank != w.rank || got.CompleteJudges != w.complete {
+			t.Errorf("ranking %d: expected entry %d score %.2f rank %d complete %d, got entry %d score %.2f rank %d complete %d",
+				i, w.entry, w.score, w.rank, w.complete, got.Entry.ID, got.Score, got.Rank, got.CompleteJudges)
+		}
+	}
+}
+
+func TestValidateCriteria(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria []JudgingCriterion
+		wantErr  bool
+	}{
+		{"valid", []JudgingCriterion{{Name: "Impact", Weight: 2}, {Name: "Service", Weight: 1}}, false},
+		{"empty", nil, true},
+		{"no name", []JudgingCriterion{{Name: " ", Weight: 1}}, true},
+		{"duplicate", []JudgingCriterion{{Name: "Impact", Weight: 1}, {Name: "Impact ", Weight: 1}}, true},
+		{"zero weight", []JudgingCriterion{{Name: "Impact"}}, true},
+	}
+	for _, tt := range tests {
+		err := validateCriteria(tt.criteria)
+		if tt.wantErr != (err != nil) {
+			t.Errorf("%s: validateCriteria() returned %v", tt.name, err)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidCriteria) {
+			t.Errorf("%s: expected ErrInvalidCriteria, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestEveryCategoryJudged(t *testing.T) {
+	entries := []ShortlistEntry{
+		{ID: 1, Category: "Technology"},
+		{ID: 2, Category: "Technology"},
+		{ID: 3, Category: "Business"},
+	}
+	criteria := []JudgingCriterion{{ID: 10}, {ID: 11}}
+	full := func(entry, judge int) []JudgeScore {
+		return []JudgeScore{
+			{EntryID: entry, AdminID: judge, CriterionID: 10, Score: 5},
+			{EntryID: entry, AdminID: judge, CriterionID: 11, Score: 5},
+		}
+	}
+	join := func(parts ...[]JudgeScore) []JudgeScore {
+		var scores []JudgeScore
+		for _, p := range parts {
+			scores = append(scores, p...)
+		}
+		return scores
+	}
+
+	tests := []struct {
+		name   string
+		scores []JudgeScore
+		want   bool
+	}{
+		{"one judge scored everything", join(full(1, 100), full(2, 100), full(3, 100)), true},
+		{"a judge per category", join(full(1, 100), full(2, 100), full(3, 101)), true},
+		{"category unscored", join(full(1, 100), full(2, 100)), false},
+		{"category split between judges", join(full(1, 100), full(2, 101), full(3, 100)), false},
+		{"criterion missing", join(full(1, 100), full(2, 100)[:1], full(3, 100)), false},
+	}
+	for _, tt := range tests {
+		if got := everyCategoryJudged(entries, criteria, tt.scores); got != tt.want {
+			t.Errorf("%s: everyCategoryJudged() = %v; want %v", tt.name, got, tt.want)
+		}
+	}
+	if everyCategoryJudged(nil, criteria, nil) || everyCategoryJudged(entries, nil, nil) {
+		t.Errorf("expected an empty shortlist or no criteria to be unjudged")
+	}
+}
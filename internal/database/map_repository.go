@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var ErrInvalidCellSize = errors.New("cell size must be positive")
+
+// publicCoordinates selects each alumnus's coordinates as the public map
+// shows them, rounded unless they chose exact precision.
+var publicCoordinates = fmt.Sprintf(
+	"CASE WHEN map_precision = '%[1]s' THEN latitude ELSE ROUND(latitude::numeric, %[2]d)::float8 END AS lat, "+
+		"CASE WHEN map_precision = '%[1]s' THEN longitude ELSE ROUND(longitude::numeric, %[2]d)::float8 END AS lng",
+	MapPrecisionExact, CityPrecisionDecimals,
+)
+
+// MapQuery selects the alumni shown on the map. Clusters are limited to the
+// bounding box; counts cover every matching alumnus wherever they are. A
+// box with MinLng > MaxLng crosses the antimeridian.
+type MapQuery struct {
+	MinLat, MinLng float64
+	MaxLat, MaxLng float64
+	CellSize       float64 // grid cell in degrees; alumni in a cell form one cluster
+	Year           int
+	Course         string
+}
+
+// MapCluster is a group of alumni placed at their average position.
+type MapCluster struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Count     int64   `json:"count"`
+}
+
+// LocationCount counts the alumni in a country, or in a city when City is
+// set.
+type LocationCount struct {
+	Country string `json:"country"`
+	City    string `json:"city,omitempty"`
+	Count   int64  `json:"count"`
+}
+
+type AlumniMapService interface {
+	GetMapClusters(ctx context.Context, q MapQuery) ([]MapCluster, error)
+	GetCountryCounts(ctx context.Context, q MapQuery) ([]LocationCount, error)
+	GetCityCounts(ctx context.Context, q MapQuery) ([]LocationCount, error)
+}
+
+// GetMapClusters groups the located alumni in q's bounding box on a grid of
+// q.CellSize degrees. Alumni who show only their country, or nothing, are
+// left out.
+func (s *service) GetMapClusters(ctx context.Context, q MapQuery) ([]MapCluster, error) {
+	if q.CellSize <= 0 {
+		return nil, ErrInvalidCellSize
+	}
+
+	located := s.filterAlumni(s.db.WithContext(ctx).Model(&Alumni{}), AlumniQuery{Year: q.Year, Course: q.Course}).
+		Select(publicCoordinates).
+		Where("latitude != 0 AND longitude != 0").
+		Where("map_precision NOT IN ?", []string{MapPrecisionCountry, MapPrecisionHidden})
+
+	query := s.db.WithContext(ctx).Table("(?) AS located", located).
+		Where("lat BETWEEN ? AND ?", q.MinLat, q.MaxLat)
+	if q.MinLng <= q.MaxLng {
+		query = query.Where("lng BETWEEN ? AND ?", q.MinLng, q.MaxLng)
+	} else {
+		query = query.Where("(lng >= ? OR lng <= ?)", q.MinLng, q.MaxLng)
+	}
+
+	cell := strconv.FormatFloat(q.CellSize, 'f', -1, 64)
+	var clusters []MapCluster
+	result := query.
+		Select("AVG(lat) AS latitude, AVG(lng) AS longitude, COUNT(*) AS count").
+		Group(fmt.Sprintf("FLOOR(lat / %s), FLOOR(lng / %s)", cell, cell)).
+		Order("count DESC").
+		Scan(&clusters)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to cluster alumni: %w", result.Error)
+	}
+	return clusters, nil
+}
+
+// GetCountryCounts counts alumni per country, leaving out those hidden from
+// the map.
+func (s *service) GetCountryCounts(ctx context.Context, q MapQuery) ([]LocationCount, error) {
+	var counts []LocationCount
+	result := s.filterAlumni(s.db.WithContext(ctx).Model(&Alumni{}), AlumniQuery{Year: q.Year, Course: q.Course}).
+		Select("country, COUNT(*) AS count").
+		Where("country <> '' AND map_precision <> ?", MapPrecisionHidden).
+		Group("country").
+		Order("count DESC, country").
+		Scan(&counts)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to count alumni per country: %w", result.Error)
+	}
+	return counts, nil
+}
+
+// GetCityCounts counts alumni per city, leaving out those who show only
+// their country or nothing.
+func (s *service) GetCityCounts(ctx context.Context, q MapQuery) ([]LocationCount, error) {
+	var counts []LocationCount
+	result := s.filterAlumni(s.db.WithContext(ctx).Model(&Alumni{}), AlumniQuery{Year: q.Year, Course: q.Course}).
+		Select("country, city, COUNT(*) AS count").
+		Where("city <> '' AND map_precision NOT IN ?", []string{MapPrecisionCountry, MapPrecisionHidden}).
+		Group("country, city").
+		Order("count DESC, country, city").
+		Scan(&counts)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to count alumni per city: %w", result.Error)
+	}
+	return counts, nil
+}
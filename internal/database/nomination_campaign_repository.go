@@ -0,0 +1,282 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCampaignNotFound    = errors.New("nomination campaign not found")
+	ErrCampaignOverlap     = errors.New("nomination campaign overlaps another campaign")
+	ErrCampaignInUse       = errors.New("nomination campaign already has nominations")
+	ErrInvalidCampaign     = errors.New("invalid nomination campaign")
+	ErrNominationsClosed   = errors.New("nominations are closed")
+	ErrUnknownCategory     = errors.New("unknown award category")
+	ErrNominationRule      = errors.New("nomination does not meet the category rules")
+	ErrDuplicateNomination = errors.New("you have already submitted a nomination for this category")
+)
+
+// NominationCampaign is one awards cycle. Nominations are accepted from
+// OpensAt until ClosesAt, only for the campaign's categories. Campaigns may
+// not overlap, so at most one is open at a time.
+type NominationCampaign struct {
+	ID         int                `gorm:"column:id;primaryKey"`
+	Name       string             `gorm:"column:name;not null"`
+	OpensAt    time.Time          `gorm:"column:opens_at;not null;index"`
+	ClosesAt   time.Time          `gorm:"column:closes_at;not null"`
+	Categories []CampaignCategory `gorm:"foreignKey:CampaignID;constraint:OnDelete:CASCADE"`
+
+	// Judging: once locked the shortlist, criteria, judges and scores are
+	// final; once published the winners are public
+	ResultsLockedAt    *time.Time `gorm:"column:results_locked_at"`
+	ResultsPublishedAt *time.Time `gorm:"column:results_published_at"`
+
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (NominationCampaign) TableName() string {
+	return "nomination_campaigns"
+}
+
+// IsOpen reports whether the campaign accepts nominations at t.
+func (c *NominationCampaign) IsOpen(t time.Time) bool {
+	return !t.Before(c.OpensAt) && t.Before(c.ClosesAt)
+}
+
+// Category returns the campaign's category called name, or nil.
+func (c *NominationCampaign) Category(name string) *CampaignCategory {
+	for i := range c.Categories {
+		if c.Categories[i].Name == name {
+			return &c.Categories[i]
+		}
+	}
+	return nil
+}
+
+// CampaignCategory is an award nominations can be made for. Its rules are
+// checked against every nomination; a zero value turns a rule off.
+type CampaignCategory struct {
+	ID          int    `gorm:"column:id;primaryKey"`
+	CampaignID  int    `gorm:"column:campaign_id;not null;uniqueIndex:idx_campaign_category"`
+	Name        string `gorm:"column:name;not null;uniqueIndex:idx_campaign_category"`
+	Description string `gorm:"column:description"`
+	Position    int    `gorm:"column:position"` // display order
+
+	// Years between the nominee's graduation and the campaign opening, e.g.
+	// at most 10 for a young achiever award
+	MinYearsSinceGraduation int  `gorm:"column:min_years_since_graduation"`
+	MaxYearsSinceGraduation int  `gorm:"column:max_years_since_graduation"`
+	RequireNomineeEmail     bool `gorm:"column:require_nominee_email"`
+}
+
+func (CampaignCategory) TableName() string {
+	return "nomination_campaign_categories"
+}
+
+// Check reports why n breaks the category's rules, wrapping
+// ErrNominationRule, or nil if it does not.
+func (cc *CampaignCategory) Check(n *Nomination, campaign *NominationCampaign) error {
+	// A linked alumnus's email is already on record
+	if cc.RequireNomineeEmail && n.AlumniID == nil && strings.TrimSpace(n.NominatedEmail) == "" {
+		return fmt.Errorf("%w: %s requires the nominee's email", ErrNominationRule, cc.Name)
+	}
+
+	years := campaign.OpensAt.Year() - n.Year
+	if cc.MinYearsSinceGraduation > 0 && years < cc.MinYearsSinceGraduation {
+		return fmt.Errorf("%w: %s is for alumni who graduated at least %d years ago", ErrNominationRule, cc.Name, cc.MinYearsSinceGraduation)
+	}
+	if cc.MaxYearsSinceGraduation > 0 && years > cc.MaxYearsSinceGraduation {
+		return fmt.Errorf("%w: %s is for alumni who graduated at most %d years ago", ErrNominationRule, cc.Name, cc.MaxYearsSinceGraduation)
+	}
+	return nil
+}
+
+type NominationCampaignService interface {
+	GetNominationCampaigns(ctx context.Context) ([]NominationCampaign, error)
+	GetNominationCampaign(ctx context.Context, id int) (*NominationCampaign, error)
+	GetOpenNominationCampaign(ctx context.Context, at time.Time) (*NominationCampaign, error)
+	SaveNominationCampaign(ctx context.Context, c *NominationCampaign) error
+	DeleteNominationCampaign(ctx context.Context, id int) error
+}
+
+func (s *service) GetNominationCampaigns(ctx context.Context) ([]NominationCampaign, error) {
+	var campaigns []NominationCampaign
+	result := s.db.WithContext(ctx).Preload("Categories", orderCategories).Order("opens_at DESC").Find(&campaigns)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch nomination campaigns: %w", result.Error)
+	}
+	return campaigns, nil
+}
+
+func (s *service) GetNominationCampaign(ctx context.Context, id int) (*NominationCampaign, error) {
+	var campaign NominationCampaign
+	result := s.db.WithContext(ctx).Preload("Categories", orderCategories).First(&campaign, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrCampaignNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch nomination campaign: %w", result.Error)
+	}
+	return &campaign, nil
+}
+
+// GetOpenNominationCampaign returns the campaign open at the given time, or
+// nil when nominations are closed.
+func (s *service) GetOpenNominationCampaign(ctx context.Context, at time.Time) (*NominationCampaign, error) {
+	return openCampaign(s.db.WithContext(ctx), at)
+}
+
+func openCampaign(db *gorm.DB, at time.Time) (*NominationCampaign, error) {
+	var campaign NominationCampaign
+	result := db.Preload("Categories", orderCategories).
+		Where("opens_at <= ? AND closes_at > ?", at, at).
+		First(&campaign)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch open nomination campaign: %w", result.Error)
+	}
+	return &campaign, nil
+}
+
+func orderCategories(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC, id ASC")
+}
+
+// SaveNominationCampaign creates or updates c, replacing its categories
+// with c.Categories.
+func (s *service) SaveNominationCampaign(ctx context.Context, c *NominationCampaign) error {
+	if err := validateCampaign(c); err != nil {
+		return err
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var overlapping int64
+		if err := tx.Model(&NominationCampaign{}).
+			Where("id <> ? AND opens_at < ? AND closes_at > ?", c.ID, c.ClosesAt, c.OpensAt).
+			Count(&overlapping).Error; err != nil {
+			return err
+		}
+		if overlapping > 0 {
+			return ErrCampaignOverlap
+		}
+
+		categories := c.Categories
+		for i := range categories {
+			categories[i].ID = 0
+			categories[i].Position = i
+		}
+
+		if c.ID == 0 {
+			c.ResultsLockedAt, c.ResultsPublishedAt = nil, nil
+			return tx.Create(c).Error
+		}
+		if _, err := unlockedCampaign(tx, c.ID); err != nil {
+			return err
+		}
+
+		result := tx.Omit("Categories").Model(c).Select("name", "opens_at", "closes_at").Updates(c)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrCampaignNotFound
+		}
+		if err := tx.Where("campaign_id = ?", c.ID).Delete(&CampaignCategory{}).Error; err != nil {
+			return err
+		}
+		for i := range categories {
+			categories[i].CampaignID = c.ID
+		}
+		if len(categories) > 0 {
+			return tx.Create(&categories).Error
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrCampaignOverlap) || errors.Is(err, ErrCampaignNotFound) || errors.Is(err, ErrResultsLocked) {
+			return err
+		}
+		return fmt.Errorf("failed to save nomination campaign: %w", err)
+	}
+	return nil
+}
+
+func validateCampaign(c *NominationCampaign) error {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return fmt.Errorf("%w: a name is required", ErrInvalidCampaign)
+	}
+	if !c.ClosesAt.After(c.OpensAt) {
+		return fmt.Errorf("%w: it must close after it opens", ErrInvalidCampaign)
+	}
+	if len(c.Categories) == 0 {
+		return fmt.Errorf("%w: at least one category is required", ErrInvalidCampaign)
+	}
+
+	seen := make(map[string]bool)
+	for i := range c.Categories {
+		cc := &c.Categories[i]
+		cc.Name = strings.TrimSpace(cc.Name)
+		if cc.Name == "" {
+			return fmt.Errorf("%w: every category needs a name", ErrInvalidCampaign)
+		}
+		if seen[cc.Name] {
+			return fmt.Errorf("%w: category %q is listed twice", ErrInvalidCampaign, cc.Name)
+		}
+		seen[cc.Name] = true
+
+		if cc.MinYearsSinceGraduation < 0 || cc.MaxYearsSinceGraduation < 0 {
+			return fmt.Errorf("%w: %s: years since graduation cannot be negative", ErrInvalidCampaign, cc.Name)
+		}
+		if cc.MaxYearsSinceGraduation > 0 && cc.MinYearsSinceGraduation > cc.MaxYearsSinceGraduation {
+			return fmt.Errorf("%w: %s: minimum years since graduation is above the maximum", ErrInvalidCampaign, cc.Name)
+		}
+	}
+	return nil
+}
+
+// DeleteNominationCampaign deletes a campaign that has no nominations yet.
+func (s *service) DeleteNominationCampaign(ctx context.Context, id int) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var nominations int64
+		if err := tx.Model(&Nomination{}).Where("campaign_id = ?", id).Count(&nominations).Error; err != nil {
+			return err
+		}
+		if nominations > 0 {
+			return ErrCampaignInUse
+		}
+		if err := tx.Where("campaign_id = ?", id).Delete(&ShortlistEntry{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("campaign_id = ?", id).Delete(&JudgingCriterion{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("campaign_id = ?", id).Delete(&CampaignJudge{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&NominationCampaign{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrCampaignNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrCampaignInUse) || errors.Is(err, ErrCampaignNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete nomination campaign: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCampaignCategoryCheck(t *testing.T) {
+	campaign := &NominationCampaign{OpensAt: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)}
+	young := CampaignCategory{Name: "Young Achiever Award", MaxYearsSinceGraduation: 10, RequireNomineeEmail: true}
+	lifetime := CampaignCategory{Name: "Lifetime Achievement Award", MinYearsSinceGraduation: 25}
+
+	tests := []struct {
+		name     string
+		category CampaignCategory
+		n        Nomination
+		wantErr  bool
+	}{
+		{"young", young, Nomination{Year: 2020, NominatedEmail: "ana@example.com"}, false},
+		{"too old for young", young, Nomination{Year: 2010, NominatedEmail: "ana@example.com"}, true},
+		{"missing email", young, Nomination{Year: 2020}, true},
+		{"lifetime", lifetime, Nomination{Year: 1990}, false},
+		{"too recent for lifetime", lifetime, Nomination{Year: 2005}, true},
+	}
+	for _, tt := range tests {
+		err := tt.category.Check(&tt.n, campaign)
+		if tt.wantErr != (err != nil) {
+			t.Errorf("%s: Check() returned %v", tt.name, err)
+		}
+		if err != nil && !errors.Is(err, ErrNominationRule) {
+			t.Errorf("%s: expected ErrNominationRule, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestValidateCampaign(t *testing.T) {
+	opens := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	valid := func() NominationCampaign {
+		return NominationCampaign{
+			Name:       "Homecoming 2026",
+			OpensAt:    opens,
+			ClosesAt:   opens.AddDate(0, 1, 0),
+			Categories: []CampaignCategory{{Name: "Young Achiever Award", MaxYearsSinceGraduation: 10}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *NominationCampaign)
+	}{
+		{"no name", func(c *NominationCampaign) { c.Name = " " }},
+		{"closes before opening", func(c *NominationCampaign) { c.ClosesAt = c.OpensAt }},
+		{"no categories", func(c *NominationCampaign) { c.Categories = nil }},
+		{"duplicate category", func(c *NominationCampaign) { c.Categories = append(c.Categories, c.Categories[0]) }},
+		{"min above max", func(c *NominationCampaign) { c.Categories[0].MinYearsSinceGraduation = 20 }},
+	}
+
+	campaign := valid()
+	if err := validateCampaign(&campaign); err != nil {
+		t.Fatalf("validateCampaign() returned error for a valid campaign: %v", err)
+	}
+	for _, tt := range tests {
+		campaign := valid()
+		tt.modify(&campaign)
+		if err := validateCampaign(&campaign); !errors.Is(err, ErrInvalidCampaign) {
+			t.Errorf("%s: expected ErrInvalidCampaign, got %v", tt.name, err)
+		}
+	}
+}
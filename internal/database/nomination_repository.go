@@ -2,24 +2,30 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Nomination struct {
 	ID             int       `gorm:"column:id;primaryKey;autoIncrement"`
+	CampaignID     *int      `gorm:"column:campaign_id;index:idx_campaign_nominator_category,unique"` // nil for nominations made before campaigns
+	AlumniID       *int      `gorm:"column:alumni_id;index"`                                          // the nominee, when in the alumni records
 	FirstName      string    `gorm:"column:first_name"`
 	LastName       string    `gorm:"column:last_name"`
 	NominatedEmail string    `gorm:"column:nominated_email"` // optional
-	NominatorEmail string    `gorm:"column:nominator_email;not null;index:idx_nominator_category,unique"`
+	NominatorEmail string    `gorm:"column:nominator_email;not null;index:idx_campaign_nominator_category,unique"`
 	Year           int       `gorm:"column:year"`
-	Category       string    `gorm:"column:category;index:idx_nominator_category,unique"`
+	Category       string    `gorm:"column:category;index:idx_campaign_nominator_category,unique"`
 	CreatedAt      time.Time `gorm:"column:created_at;autoCreateTime"` // auto on insert
 	UpdatedAt      time.Time `gorm:"column:updated_at;autoUpdateTime"` // auto on update
 }
 
 type NomineeGroup struct {
+	AlumniID  *int
 	FirstName string
 	LastName  string
 	Year      int
@@ -33,35 +39,77 @@ func (Nomination) TableName() string {
 
 type NominationService interface {
 	SaveNomination(ctx context.Context, n *Nomination) error
-	DeleteNomination(ctx context.Context, id int) error
+	DeleteNomination(ctx context.Context, id int, deletedBy string) error
 	FindNominationsByCategory(ctx context.Context, category string) ([]Nomination, error)
 	FindNominationsByCategoryGrouped(ctx context.Context, category string) ([]NomineeGroup, error)
+	EachNomination(ctx context.Context, category string, fn func(Nomination) error) error
 }
 
+// SaveNomination files n under the campaign open now. It returns
+// ErrNominationsClosed when no campaign is open, ErrUnknownCategory when the
+// campaign has no such category and an ErrNominationRule error when n
+// breaks the category's rules. The nominee is linked to their alumni record
+// when one is chosen or matches NominatedEmail.
 func (s *service) SaveNomination(ctx context.Context, n *Nomination) error {
 	// Normalize names to uppercase
 	n.FirstName = strings.ToUpper(n.FirstName)
 	n.LastName = strings.ToUpper(n.LastName)
+	// and the nominator's email to lowercase, so the unique index sees one
+	// nominator however they typed their address
+	n.NominatorEmail = normalizeNominatorEmail(n.NominatorEmail)
 
-	err := s.db.WithContext(ctx).Create(n).Error
+	campaign, err := openCampaign(s.db.WithContext(ctx), time.Now())
 	if err != nil {
-		if isUniqueConstraintError(err) {
-			return fmt.Errorf("you have already submitted a nomination for this category")
-		}
-		return fmt.Errorf("failed to save nomination: %w", err)
+		return err
 	}
-	return nil
-}
-
-func (s *service) DeleteNomination(ctx context.Context, id int) error {
-	result := s.db.WithContext(ctx).Delete(&Nomination{}, id)
-	if result.Error != nil {
-		return fmt.Errorf("failed to delete nomination: %w", result.Error)
+	if campaign == nil {
+		return ErrNominationsClosed
 	}
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("nomination not found")
+	category := campaign.Category(n.Category)
+	if category == nil {
+		return ErrUnknownCategory
 	}
-	return nil
+	if err := linkNominee(s.db.WithContext(ctx), n); err != nil {
+		return err
+	}
+	if err := category.Check(n, campaign); err != nil {
+		return err
+	}
+	n.CampaignID = &campaign.ID
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(n).Error; err != nil {
+			if isUniqueConstraintError(err) {
+				return ErrDuplicateNomination
+			}
+			return err
+		}
+		return recordNominationChange(tx, n, NominationCreated, n.NominatorEmail)
+	})
+	return nominationError(err, "failed to save nomination")
+}
+
+func normalizeNominatorEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// DeleteNomination removes nomination id, recording deletedBy, an admin's
+// username, in its history.
+func (s *service) DeleteNomination(ctx context.Context, id int, deletedBy string) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var n Nomination
+		if err := tx.First(&n, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNominationNotFound
+			}
+			return err
+		}
+		if err := tx.Delete(&n).Error; err != nil {
+			return err
+		}
+		return recordNominationChange(tx, &n, NominationDeleted, deletedBy)
+	})
+	return nominationError(err, "failed to delete nomination")
 }
 
 func (s *service) FindNominationsByCategory(ctx context.Context, category string) ([]Nomination, error) {
@@ -76,19 +124,35 @@ func (s *service) FindNominationsByCategory(ctx context.Context, category string
 	return nominations, result.Error
 }
 
-func (s *service) FindNominationsByCategoryGrouped(ctx context.Context, category string) ([]NomineeGroup, error) {
-	var results []NomineeGroup
+// EachNomination streams the nominations in category (all when empty) to fn.
+func (s *service) EachNomination(ctx context.Context, category string, fn func(Nomination) error) error {
+	query := s.db.WithContext(ctx).Model(&Nomination{})
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
 
-	query := s.db.WithContext(ctx).
-		Model(&Nomination{}).
-		Select("first_name, last_name, year, category, COUNT(*) as count")
+	if err := eachRow(query.Order("id ASC"), fn); err != nil {
+		return fmt.Errorf("failed to stream nominations: %w", err)
+	}
+	return nil
+}
 
+// FindNominationsByCategoryGrouped counts nominations per nominee, most
+// nominated first. Nominations linked to the same alumnus count together
+// whatever name they were made under.
+func (s *service) FindNominationsByCategoryGrouped(ctx context.Context, category string) ([]NomineeGroup, error) {
+	query := s.db.WithContext(ctx).Model(&Nomination{})
 	if category != "" {
 		query = query.Where("category = ?", category)
 	}
+	return groupNominations(query)
+}
 
+func groupNominations(query *gorm.DB) ([]NomineeGroup, error) {
+	var results []NomineeGroup
 	err := query.
-		Group("first_name, last_name, year, category").
+		Select("MAX(alumni_id) AS alumni_id, MAX(first_name) AS first_name, MAX(last_name) AS last_name, MAX(year) AS year, category, COUNT(*) as count").
+		Group("category, COALESCE('alumni:' || alumni_id::text, first_name || '|' || last_name || '|' || year::text)").
 		Order("count DESC").
 		Scan(&results).Error
 
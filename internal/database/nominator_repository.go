@@ -0,0 +1,214 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Nomination change actions
+const (
+	NominationCreated   = "created"
+	NominationUpdated   = "updated"
+	NominationWithdrawn = "withdrawn"
+	NominationDeleted   = "deleted"
+)
+
+// NominationChange is the audit record of one change to a nomination. It
+// keeps a copy of the nomination as it stood after the change, or before it
+// for withdrawals and deletions, so history outlives the nomination.
+type NominationChange struct {
+	ID             int       `gorm:"column:id;primaryKey"`
+	NominationID   int       `gorm:"column:nomination_id;index;not null"`
+	Action         string    `gorm:"column:action;not null"`
+	ChangedBy      string    `gorm:"column:changed_by"` // the nominator's email or the admin's username
+	CampaignID     *int      `gorm:"column:campaign_id"`
+	AlumniID       *int      `gorm:"column:alumni_id"`
+	FirstName      string    `gorm:"column:first_name"`
+	LastName       string    `gorm:"column:last_name"`
+	NominatedEmail string    `gorm:"column:nominated_email"`
+	NominatorEmail string    `gorm:"column:nominator_email;index"`
+	Year           int       `gorm:"column:year"`
+	Category       string    `gorm:"column:category"`
+	CreatedAt      time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (NominationChange) TableName() string {
+	return "nomination_changes"
+}
+
+// NominatorService lets nominators, identified by a verified email, manage
+// the nominations they made.
+type NominatorService interface {
+	FindNominationsByNominator(ctx context.Context, nominatorEmail string) ([]Nomination, error)
+	UpdateOwnNomination(ctx context.Context, nominatorEmail string, n *Nomination) error
+	WithdrawNomination(ctx context.Context, nominatorEmail string, id int) error
+	GetNominationHistory(ctx context.Context, id int, nominatorEmail string) ([]NominationChange, error)
+}
+
+func recordNominationChange(tx *gorm.DB, n *Nomination, action, changedBy string) error {
+	change := NominationChange{
+		NominationID:   n.ID,
+		Action:         action,
+		ChangedBy:      changedBy,
+		CampaignID:     n.CampaignID,
+		AlumniID:       n.AlumniID,
+		FirstName:      n.FirstName,
+		LastName:       n.LastName,
+		NominatedEmail: n.NominatedEmail,
+		NominatorEmail: n.NominatorEmail,
+		Year:           n.Year,
+		Category:       n.Category,
+	}
+	if err := tx.Create(&change).Error; err != nil {
+		return fmt.Errorf("failed to record nomination change: %w", err)
+	}
+	return nil
+}
+
+// ownNomination loads nomination id if nominatorEmail made it. Someone
+// else's nomination is reported as not found.
+func ownNomination(tx *gorm.DB, nominatorEmail string, id int) (*Nomination, error) {
+	var n Nomination
+	err := tx.Where("id = ? AND LOWER(nominator_email) = LOWER(?)", id, strings.TrimSpace(nominatorEmail)).First(&n).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNominationNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch nomination: %w", err)
+	}
+	return &n, nil
+}
+
+func (s *service) FindNominationsByNominator(ctx context.Context, nominatorEmail string) ([]Nomination, error) {
+	var nominations []Nomination
+	result := s.db.WithContext(ctx).
+		Where("LOWER(nominator_email) = LOWER(?)", strings.TrimSpace(nominatorEmail)).
+		Order("created_at DESC, id DESC").
+		Find(&nominations)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch nominations: %w", result.Error)
+	}
+	return nominations, nil
+}
+
+// UpdateOwnNomination replaces the nominee and category of nomination n.ID
+// with those of n, checked against the category's rules as a new
+// nomination would be. It returns ErrNominationsClosed unless the
+// nomination's campaign is open now. On success n holds the saved
+// nomination.
+func (s *service) UpdateOwnNomination(ctx context.Context, nominatorEmail string, n *Nomination) error {
+	nominatorEmail = normalizeNominatorEmail(nominatorEmail)
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current, err := ownNomination(tx, nominatorEmail, n.ID)
+		if err != nil {
+			return err
+		}
+
+		campaign, err := openCampaign(tx, time.Now())
+		if err != nil {
+			return err
+		}
+		if campaign == nil || current.CampaignID == nil || *current.CampaignID != campaign.ID {
+			return ErrNominationsClosed
+		}
+
+		current.NominatorEmail = nominatorEmail
+		current.AlumniID = n.AlumniID
+		current.FirstName = strings.ToUpper(n.FirstName)
+		current.LastName = strings.ToUpper(n.LastName)
+		current.NominatedEmail = n.NominatedEmail
+		current.Year = n.Year
+		current.Category = n.Category
+
+		category := campaign.Category(current.Category)
+		if category == nil {
+			return ErrUnknownCategory
+		}
+		if err := linkNominee(tx, current); err != nil {
+			return err
+		}
+		if err := category.Check(current, campaign); err != nil {
+			return err
+		}
+
+		if err := tx.Save(current).Error; err != nil {
+			if isUniqueConstraintError(err) {
+				return ErrDuplicateNomination
+			}
+			return err
+		}
+		if err := recordNominationChange(tx, current, NominationUpdated, nominatorEmail); err != nil {
+			return err
+		}
+
+		*n = *current
+		return nil
+	})
+	return nominationError(err, "failed to update nomination")
+}
+
+// WithdrawNomination deletes nomination id on behalf of the nominator who
+// made it, which also frees its category for a new nomination. Like edits,
+// withdrawals are only allowed while the nomination's campaign is open, so
+// nominations that judges shortlisted or scored stay in place; otherwise it
+// returns ErrNominationsClosed.
+func (s *service) WithdrawNomination(ctx context.Context, nominatorEmail string, id int) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		n, err := ownNomination(tx, nominatorEmail, id)
+		if err != nil {
+			return err
+		}
+
+		campaign, err := openCampaign(tx, time.Now())
+		if err != nil {
+			return err
+		}
+		if campaign == nil || n.CampaignID == nil || *n.CampaignID != campaign.ID {
+			return ErrNominationsClosed
+		}
+
+		if err := tx.Delete(n).Error; err != nil {
+			return err
+		}
+		return recordNominationChange(tx, n, NominationWithdrawn, nominatorEmail)
+	})
+	return nominationError(err, "failed to withdraw nomination")
+}
+
+// GetNominationHistory lists the changes to nomination id, oldest first.
+// When nominatorEmail is set only that nominator's changes are returned, so
+// nominators cannot read the history of others' nominations.
+func (s *service) GetNominationHistory(ctx context.Context, id int, nominatorEmail string) ([]NominationChange, error) {
+	query := s.db.WithContext(ctx).Where("nomination_id = ?", id)
+	if nominatorEmail != "" {
+		query = query.Where("LOWER(nominator_email) = LOWER(?)", strings.TrimSpace(nominatorEmail))
+	}
+
+	var changes []NominationChange
+	if err := query.Order("created_at ASC, id ASC").Find(&changes).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch nomination history: %w", err)
+	}
+	return changes, nil
+}
+
+// nominationError passes the nomination sentinel errors through and wraps
+// the rest with msg.
+func nominationError(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	for _, sentinel := range []error{
+		ErrNominationNotFound, ErrNominationsClosed, ErrUnknownCategory, ErrNomineeNotFound,
+		ErrNominationRule, ErrDuplicateNomination, ErrResultsLocked,
+	} {
+		if errors.Is(err, sentinel) {
+			return err
+		}
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
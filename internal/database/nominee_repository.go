@@ -0,0 +1,368 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrNomineeNotFound    = errors.New("nominee is not in the alumni records")
+	ErrNominationNotFound = errors.New("nomination not found")
+	ErrInvalidMerge       = errors.New("a merge needs nominations and exactly one target")
+)
+
+const (
+	MaxNomineeCandidates = 20
+
+	// MergeSuggestionThreshold is the lowest name similarity, from 0 to 1,
+	// at which two nominees are suggested as the same person.
+	MergeSuggestionThreshold = 0.8
+)
+
+// NomineeCandidate is an alumnus offered when searching for a nominee. It
+// only carries fields every alumnus shows publicly.
+type NomineeCandidate struct {
+	ID        int
+	FirstName string
+	LastName  string
+	Year      int
+	Course    string
+}
+
+// Nominee is one person as nominated in a category: either a linked
+// alumnus or, for unlinked nominations, a name and year.
+type Nominee struct {
+	AlumniID      *int
+	FirstName     string
+	LastName      string
+	Year          int
+	Category      string
+	NominationIDs []int
+}
+
+// NomineeMergeSuggestion pairs a nominee with another that is probably the
+// same person. Duplicate's nominations would be merged into Target.
+type NomineeMergeSuggestion struct {
+	Target    Nominee
+	Duplicate Nominee
+	Score     float64
+}
+
+// MergeTarget is the nominee nominations are merged into: an alumnus, or
+// the nominee of an existing nomination. Set exactly one field.
+type MergeTarget struct {
+	AlumniID     int
+	NominationID int
+}
+
+type NomineeService interface {
+	SearchNomineeCandidates(ctx context.Context, search string, limit int) ([]NomineeCandidate, error)
+	SuggestNomineeMerges(ctx context.Context, category string) ([]NomineeMergeSuggestion, error)
+	MergeNominees(ctx context.Context, nominationIDs []int, target MergeTarget, mergedBy string) (int64, error)
+}
+
+// SearchNomineeCandidates finds alumni whose names contain every word of
+// search, for the nomination form's autocomplete.
+func (s *service) SearchNomineeCandidates(ctx context.Context, search string, limit int) ([]NomineeCandidate, error) {
+	words := strings.Fields(search)
+	if len(words) == 0 {
+		return []NomineeCandidate{}, nil
+	}
+	if limit < 1 || limit > MaxNomineeCandidates {
+		limit = MaxNomineeCandidates
+	}
+
+	query := s.db.WithContext(ctx).Model(&Alumni{})
+	for _, word := range words {
+		pattern := "%" + escapeLike(word) + "%"
+		query = query.Where("first_name ILIKE ? OR last_name ILIKE ?", pattern, pattern)
+	}
+
+	var candidates []NomineeCandidate
+	result := query.
+		Select("id, first_name, last_name, year, course").
+		Order("last_name ASC, first_name ASC, id ASC").
+		Limit(limit).
+		Scan(&candidates)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to search nominees: %w", result.Error)
+	}
+	return candidates, nil
+}
+
+// linkNominee ties n to the alumnus it names, chosen by n.AlumniID or else
+// matched on n.NominatedEmail, and copies the alumnus's name and year so
+// every nomination of that person reads the same. An unmatched email
+// leaves n unlinked.
+func linkNominee(db *gorm.DB, n *Nomination) error {
+	var alumni Alumni
+	switch email := strings.TrimSpace(n.NominatedEmail); {
+	case n.AlumniID != nil:
+		if err := db.First(&alumni, *n.AlumniID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNomineeNotFound
+			}
+			return fmt.Errorf("failed to find nominee: %w", err)
+		}
+	case email != "":
+		if err := db.Where("LOWER(email) = LOWER(?)", email).First(&alumni).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return fmt.Errorf("failed to find nominee: %w", err)
+		}
+	default:
+		return nil
+	}
+
+	n.AlumniID = &alumni.ID
+	n.FirstName = strings.ToUpper(alumni.FirstName)
+	n.LastName = strings.ToUpper(alumni.LastName)
+	n.Year = alumni.Year
+	return nil
+}
+
+// SuggestNomineeMerges lists nominees in category (all when empty) whose
+// names are close enough to be the same person, most similar first.
+func (s *service) SuggestNomineeMerges(ctx context.Context, category string) ([]NomineeMergeSuggestion, error) {
+	query := s.db.WithContext(ctx).Model(&Nomination{})
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+
+	var nominations []Nomination
+	if err := query.Order("id ASC").Find(&nominations).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch nominations: %w", err)
+	}
+	return suggestMerges(groupNominees(nominations)), nil
+}
+
+// groupNominees collects nominations into nominees, in order of first
+// nomination.
+func groupNominees(nominations []Nomination) []Nominee {
+	var nominees []Nominee
+	index := make(map[string]int)
+	for _, n := range nominations {
+		key := fmt.Sprintf("%s|%s|%s|%d", n.Category, n.FirstName, n.LastName, n.Year)
+		if n.AlumniID != nil {
+			key = fmt.Sprintf("%s|alumni:%d", n.Category, *n.AlumniID)
+		}
+
+		i, ok := index[key]
+		if !ok {
+			i = len(nominees)
+			index[key] = i
+			nominees = append(nominees, Nominee{
+				AlumniID:  n.AlumniID,
+				FirstName: n.FirstName,
+				LastName:  n.LastName,
+				Year:      n.Year,
+				Category:  n.Category,
+			})
+		}
+		nominees[i].NominationIDs = append(nominees[i].NominationIDs, n.ID)
+	}
+	return nominees
+}
+
+func suggestMerges(nominees []Nominee) []NomineeMergeSuggestion {
+	suggestions := []NomineeMergeSuggestion{}
+	for i := range nominees {
+		for j := i + 1; j < len(nominees); j++ {
+			a, b := nominees[i], nominees[j]
+			if a.Category != b.Category || (a.AlumniID != nil && b.AlumniID != nil) {
+				continue
+			}
+			// Allow for a mistyped graduation year, but no more
+			if a.Year-b.Year > 1 || b.Year-a.Year > 1 {
+				continue
+			}
+
+			score := nameSimilarity(a.FirstName+" "+a.LastName, b.FirstName+" "+b.LastName)
+			if score < MergeSuggestionThreshold {
+				continue
+			}
+			if preferNominee(b, a) {
+				a, b = b, a
+			}
+			suggestions = append(suggestions, NomineeMergeSuggestion{Target: a, Duplicate: b, Score: score})
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Score > suggestions[j].Score
+	})
+	return suggestions
+}
+
+// preferNominee reports whether a makes a better merge target than b: a
+// linked alumnus first, then the nominee with more nominations.
+func preferNominee(a, b Nominee) bool {
+	if (a.AlumniID != nil) != (b.AlumniID != nil) {
+		return a.AlumniID != nil
+	}
+	return len(a.NominationIDs) > len(b.NominationIDs)
+}
+
+// nameSimilarity scores how alike two names are, from 0 to 1, ignoring
+// case, accents and punctuation. Names where one leaves out or abbreviates
+// middle words of the other, as "JUAN D. CRUZ" does "JUAN DELA CRUZ",
+// score at least 0.9; names with different initials score 0.
+func nameSimilarity(a, b string) float64 {
+	wa, wb := nameWords(a), nameWords(b)
+	if len(wa) == 0 || len(wb) == 0 || initialsConflict(wa, wb) {
+		return 0
+	}
+
+	ra, rb := []rune(strings.Join(wa, " ")), []rune(strings.Join(wb, " "))
+	score := 1 - float64(levenshtein(ra, rb))/float64(max(len(ra), len(rb)))
+	if abbreviates(wa, wb) || abbreviates(wb, wa) {
+		score = max(score, 0.9)
+	}
+	return score
+}
+
+func nameWords(name string) []string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(name) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return strings.FieldsFunc(b.String(), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// abbreviates reports whether short has the first and last words of long
+// and each of its middle words starts one of long's, in order.
+func abbreviates(short, long []string) bool {
+	if len(short) < 2 || len(short) > len(long) {
+		return false
+	}
+	if short[0] != long[0] || short[len(short)-1] != long[len(long)-1] {
+		return false
+	}
+
+	j := 1
+	for _, word := range short[1 : len(short)-1] {
+		for j < len(long)-1 && !strings.HasPrefix(long[j], word) {
+			j++
+		}
+		if j == len(long)-1 {
+			return false
+		}
+		j++
+	}
+	return true
+}
+
+// initialsConflict reports whether two names of as many words give
+// different initials for one of them, as "JUAN D CRUZ" and "JUAN R CRUZ" do.
+func initialsConflict(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		ra, rb := []rune(a[i]), []rune(b[i])
+		if (len(ra) == 1 || len(rb) == 1) && ra[0] != rb[0] {
+			return true
+		}
+	}
+	return false
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// MergeNominees makes the given nominations name target's nominee and
+// returns how many were changed. Each change is recorded in the nomination's
+// history as made by mergedBy, an admin's username. It returns
+// ErrResultsLocked if any of the nominations is in a campaign whose results
+// are final.
+func (s *service) MergeNominees(ctx context.Context, nominationIDs []int, target MergeTarget, mergedBy string) (int64, error) {
+	if len(nominationIDs) == 0 || (target.AlumniID == 0) == (target.NominationID == 0) {
+		return 0, ErrInvalidMerge
+	}
+
+	var merged int64
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var into Nomination
+		if target.AlumniID != 0 {
+			into.AlumniID = &target.AlumniID
+			if err := linkNominee(tx, &into); err != nil {
+				return err
+			}
+		} else if err := tx.First(&into, target.NominationID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNominationNotFound
+			}
+			return err
+		}
+
+		var nominations []Nomination
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", nominationIDs).Order("id").Find(&nominations).Error; err != nil {
+			return err
+		}
+		checked := make(map[int]bool)
+		for _, n := range nominations {
+			if n.CampaignID == nil || checked[*n.CampaignID] {
+				continue
+			}
+			// Holds the campaign row so its results cannot be locked mid-merge
+			if _, err := unlockedCampaign(tx, *n.CampaignID); err != nil {
+				return err
+			}
+			checked[*n.CampaignID] = true
+		}
+
+		for i := range nominations {
+			n := &nominations[i]
+			n.AlumniID = into.AlumniID
+			n.FirstName = into.FirstName
+			n.LastName = into.LastName
+			n.Year = into.Year
+			if err := tx.Model(n).Select("alumni_id", "first_name", "last_name", "year").Updates(n).Error; err != nil {
+				return err
+			}
+			if err := recordNominationChange(tx, n, NominationUpdated, mergedBy); err != nil {
+				return err
+			}
+		}
+		merged = int64(len(nominations))
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrNomineeNotFound) || errors.Is(err, ErrNominationNotFound) || errors.Is(err, ErrResultsLocked) {
+			return 0, err
+		}
+		return 0, fmt.Errorf("failed to merge nominees: %w", err)
+	}
+	return merged, nil
+}
@@ -0,0 +1,54 @@
+package database
+
+import "testing"
+
+func TestNameSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		same bool
+	}{
+		{"JUAN DELA CRUZ", "Juan dela Cruz", true},
+		{"JUAN DELA CRUZ", "JUAN D. CRUZ", true},
+		{"JUAN DELA CRUZ", "JUAN CRUZ", true},
+		{"JOSÉ RIZAL", "JOSE RIZAL", true},
+		{"MARIA SANTOS", "MARIA SANTOZ", true},
+		{"JUAN DELA CRUZ", "PEDRO DELA CRUZ", false},
+		{"MARIA SANTOS", "MARIO REYES", false},
+		{"JUAN D. CRUZ", "JUAN R. CRUZ", false},
+	}
+	for _, tt := range tests {
+		score := nameSimilarity(tt.a, tt.b)
+		if same := score >= MergeSuggestionThreshold; same != tt.same {
+			t.Errorf("nameSimilarity(%q, %q) = %.2f, expected same person: %v", tt.a, tt.b, score, tt.same)
+		}
+	}
+}
+
+func TestSuggestMerges(t *testing.T) {
+	alumniID := 7
+	nominations := []Nomination{
+		{ID: 1, FirstName: "JUAN", LastName: "DELA CRUZ", Year: 2010, Category: "Technology", AlumniID: &alumniID},
+		{ID: 2, FirstName: "JUAN D.", LastName: "CRUZ", Year: 2010, Category: "Technology"},
+		{ID: 3, FirstName: "JUAN D.", LastName: "CRUZ", Year: 2010, Category: "Technology"},
+		{ID: 4, FirstName: "JUAN D.", LastName: "CRUZ", Year: 2010, Category: "Business"},
+		{ID: 5, FirstName: "JUAN", LastName: "DELA CRUZ", Year: 1995, Category: "Technology"},
+		{ID: 6, FirstName: "ANA", LastName: "REYES", Year: 2010, Category: "Technology"},
+	}
+
+	nominees := groupNominees(nominations)
+	if len(nominees) != 5 {
+		t.Fatalf("expected 5 nominees, got %d: %+v", len(nominees), nominees)
+	}
+
+	suggestions := suggestMerges(nominees)
+	if len(suggestions) != 1 {
+		t.Fatalf("expected 1 suggestion, got %+v", suggestions)
+	}
+	got := suggestions[0]
+	if got.Target.AlumniID == nil || *got.Target.AlumniID != alumniID {
+		t.Errorf("expected the linked alumnus as the target, got %+v", got.Target)
+	}
+	if len(got.Duplicate.NominationIDs) != 2 || got.Duplicate.NominationIDs[0] != 2 {
+		t.Errorf("expected nominations 2 and 3 as the duplicate, got %+v", got.Duplicate)
+	}
+}
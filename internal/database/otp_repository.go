@@ -0,0 +1,199 @@
+package database
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OTP struct {
+	ID        int       `gorm:"column:id;primaryKey"`
+	Email     string    `gorm:"column:email;index"`
+	Code      string    `gorm:"-"` // plaintext, only set on the value returned by CreateOTP
+	CodeHash  string    `gorm:"column:code_hash"`
+	CodeSalt  string    `gorm:"column:code_salt"`
+	Purpose   string    `gorm:"column:purpose"` // "registration", "nomination", "sponsorship" or LoginPurpose
+	Attempts  int       `gorm:"column:attempts;default:0"`
+	ExpiresAt time.Time `gorm:"column:expires_at"`
+	Used      bool      `gorm:"column:used;default:false"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (OTP) TableName() string {
+	return "otp"
+}
+
+var (
+	ErrOTPInvalid         = errors.New("invalid or expired OTP")
+	ErrOTPTooManyAttempts = errors.New("too many failed attempts, please request a new code")
+)
+
+var (
+	otpLength      = envInt("OTP_LENGTH", 4)
+	otpMaxAttempts = envInt("OTP_MAX_ATTEMPTS", 5)
+	otpTTL         = 2 * time.Minute
+)
+
+// LoginPurpose marks the OTPs sent to alumni as magic login links. Their
+// code is a long random token instead of a short numeric code, since it is
+// clicked rather than typed.
+const LoginPurpose = "login"
+
+const loginLinkTTL = 15 * time.Minute
+
+type OTPService interface {
+	CreateOTP(ctx context.Context, email, purpose string) (*OTP, error)
+	CreateLoginLink(ctx context.Context, email string) (*OTP, error)
+	VerifyOTP(ctx context.Context, email, code, purpose string) (*OTP, error)
+	CleanupExpiredOTPs(ctx context.Context) error
+}
+
+// CreateOTP issues a new code for email/purpose and invalidates any earlier
+// unused codes for the same pair. Only a salted hash of the code is stored.
+func (s *service) CreateOTP(ctx context.Context, email, purpose string) (*OTP, error) {
+	code, err := generateOTPCode(otpLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate OTP: %w", err)
+	}
+	return s.createOTP(ctx, email, purpose, code, otpTTL)
+}
+
+// CreateLoginLink issues the token for an alumni magic login link. It is
+// checked with VerifyOTP using LoginPurpose.
+func (s *service) CreateLoginLink(ctx context.Context, email string) (*OTP, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return nil, fmt.Errorf("failed to generate login link: %w", err)
+	}
+	return s.createOTP(ctx, email, LoginPurpose, hex.EncodeToString(token), loginLinkTTL)
+}
+
+func (s *service) createOTP(ctx context.Context, email, purpose, code string, ttl time.Duration) (*OTP, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate OTP salt: %w", err)
+	}
+
+	otp := &OTP{
+		Email:     email,
+		Code:      code,
+		CodeSalt:  hex.EncodeToString(salt),
+		Purpose:   purpose,
+		ExpiresAt: time.Now().Add(ttl),
+		Used:      false,
+	}
+	otp.CodeHash = hashOTPCode(otp.CodeSalt, code)
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&OTP{}).
+			Where("email = ? AND purpose = ? AND used = false", email, purpose).
+			Update("used", true).Error; err != nil {
+			return err
+		}
+		return tx.Create(otp).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTP: %w", err)
+	}
+
+	return otp, nil
+}
+
+// VerifyOTP checks code against the active OTP for email/purpose. Every
+// guess claims one of otpMaxAttempts attempts before the code is compared,
+// in a single conditional update, so concurrent guesses cannot exceed the
+// limit. The OTP is invalidated once its attempts run out.
+func (s *service) VerifyOTP(ctx context.Context, email, code, purpose string) (*OTP, error) {
+	db := s.db.WithContext(ctx)
+
+	var otp OTP
+	result := db.Where(
+		"email = ? AND purpose = ? AND used = false AND expires_at > ?",
+		email, purpose, time.Now(),
+	).Order("created_at DESC").First(&otp)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, ErrOTPInvalid
+		}
+		return nil, fmt.Errorf("failed to verify OTP: %w", result.Error)
+	}
+
+	claim := db.Model(&otp).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "attempts"}}}).
+		Where("used = false AND attempts < ?", otpMaxAttempts).
+		UpdateColumn("attempts", gorm.Expr("attempts + 1"))
+	if claim.Error != nil {
+		return nil, fmt.Errorf("failed to record OTP attempt: %w", claim.Error)
+	}
+	if claim.RowsAffected == 0 {
+		// Used by a concurrent verification, or out of attempts
+		db.Model(&OTP{}).Where("id = ?", otp.ID).Update("used", true)
+		return nil, ErrOTPTooManyAttempts
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashOTPCode(otp.CodeSalt, code)), []byte(otp.CodeHash)) != 1 {
+		if otp.Attempts >= otpMaxAttempts {
+			if err := db.Model(&OTP{}).Where("id = ?", otp.ID).Update("used", true).Error; err != nil {
+				return nil, fmt.Errorf("failed to invalidate OTP: %w", err)
+			}
+			return nil, ErrOTPTooManyAttempts
+		}
+		return nil, ErrOTPInvalid
+	}
+
+	// Mark OTP as used; the used = false guard stops two concurrent
+	// verifications from both succeeding.
+	update := db.Model(&OTP{}).Where("id = ? AND used = false", otp.ID).Update("used", true)
+	if update.Error != nil {
+		return nil, fmt.Errorf("failed to mark OTP as used: %w", update.Error)
+	}
+	if update.RowsAffected == 0 {
+		return nil, ErrOTPInvalid
+	}
+	otp.Used = true
+
+	return &otp, nil
+}
+
+func (s *service) CleanupExpiredOTPs(ctx context.Context) error {
+	result := s.db.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&OTP{})
+	return result.Error
+}
+
+// generateOTPCode returns a uniformly random numeric code of the given length.
+func generateOTPCode(length int) (string, error) {
+	var b strings.Builder
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(byte('0' + n.Int64()))
+	}
+	return b.String(), nil
+}
+
+func hashOTPCode(salt, code string) string {
+	sum := sha256.Sum256([]byte(salt + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
+
+func envInt(key string, fallback int) int {
+	if v, err := strconv.Atoi(os.Getenv(key)); err == nil && v > 0 {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,168 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Online checkout statuses. A Payment starts pending and is settled by the
+// provider's webhook. A payment the provider reports paid with a different
+// amount or currency is marked mismatched and left for an admin to sort out.
+const (
+	CheckoutPending    = "pending"
+	CheckoutPaid       = "paid"
+	CheckoutFailed     = "failed"
+	CheckoutExpired    = "expired"
+	CheckoutMismatched = "mismatched"
+)
+
+var ErrPaymentNotFound = errors.New("payment not found")
+
+// Payment is an online checkout session started by an alumnus.
+type Payment struct {
+	ID          int        `gorm:"column:id;primaryKey"`
+	AlumniID    int        `gorm:"column:alumni_id;index;not null"`
+	Alumni      *Alumni    `gorm:"foreignKey:AlumniID;constraint:OnDelete:CASCADE" json:"-"`
+	Provider    string     `gorm:"column:provider;uniqueIndex:idx_payment_session;not null"`
+	SessionID   string     `gorm:"column:session_id;uniqueIndex:idx_payment_session;not null"`
+	Amount      int64      `gorm:"column:amount"` // in the currency's minor unit
+	Currency    string     `gorm:"column:currency"`
+	Status      string     `gorm:"column:status;default:pending;index"`
+	CheckoutURL string     `gorm:"column:checkout_url"`
+	EventID     string     `gorm:"column:event_id"` // last webhook event applied
+	PaidAt      *time.Time `gorm:"column:paid_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (Payment) TableName() string {
+	return "payments"
+}
+
+// PaymentTotal sums the payments sharing a provider, status and currency.
+type PaymentTotal struct {
+	Provider string `json:"provider"`
+	Status   string `json:"status"`
+	Currency string `json:"currency"`
+	Count    int64  `json:"count"`
+	Amount   int64  `json:"amount"`
+}
+
+type PaymentService interface {
+	CreatePayment(ctx context.Context, p *Payment) error
+	GetPayments(ctx context.Context, alumniID int) ([]Payment, error)
+	ApplyPaymentEvent(ctx context.Context, provider, sessionID, eventID, status string, amount int64, currency string) (*Payment, bool, error)
+	GetPaymentTotals(ctx context.Context) ([]PaymentTotal, error)
+}
+
+func (s *service) CreatePayment(ctx context.Context, p *Payment) error {
+	if err := s.db.WithContext(ctx).Create(p).Error; err != nil {
+		return fmt.Errorf("failed to create payment: %w", err)
+	}
+	return nil
+}
+
+func (s *service) GetPayments(ctx context.Context, alumniID int) ([]Payment, error) {
+	var payments []Payment
+	result := s.db.WithContext(ctx).Where("alumni_id = ?", alumniID).Order("created_at DESC").Find(&payments)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch payments: %w", result.Error)
+	}
+	return payments, nil
+}
+
+// ApplyPaymentEvent settles the provider's checkout session with status and
+// reports whether anything changed. It is idempotent: once a payment is paid
+// later events for it are ignored, so webhook retries are harmless. Paying
+// approves the alumnus's payment and records it in the review history,
+// unless amount and currency differ from the checkout's: the payment is then
+// marked CheckoutMismatched and the alumnus stays unapproved.
+func (s *service) ApplyPaymentEvent(ctx context.Context, provider, sessionID, eventID, status string, amount int64, currency string) (*Payment, bool, error) {
+	var payment Payment
+	changed := false
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("provider = ? AND session_id = ?", provider, sessionID).
+			First(&payment).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrPaymentNotFound
+			}
+			return err
+		}
+		if status == CheckoutPaid && (amount != payment.Amount || currency != payment.Currency) {
+			status = CheckoutMismatched
+		}
+		if payment.Status == CheckoutPaid || payment.Status == status {
+			return nil
+		}
+
+		if status == CheckoutPaid {
+			now := time.Now()
+			payment.PaidAt = &now
+			if err := approveOnlinePayment(tx, &payment); err != nil {
+				return err
+			}
+		}
+
+		payment.Status = status
+		payment.EventID = eventID
+		changed = true
+		return tx.Model(&payment).Select("status", "event_id", "paid_at").Updates(&payment).Error
+	})
+	if err != nil {
+		if errors.Is(err, ErrPaymentNotFound) {
+			return nil, false, err
+		}
+		return nil, false, fmt.Errorf("failed to apply payment event: %w", err)
+	}
+
+	return &payment, changed, nil
+}
+
+// approveOnlinePayment marks the payment's alumnus as paid, skipping the
+// manual review a proof upload needs.
+func approveOnlinePayment(tx *gorm.DB, payment *Payment) error {
+	var alumni Alumni
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&alumni, payment.AlumniID).Error; err != nil {
+		return err
+	}
+	if alumni.PaymentStatus == PaymentApproved {
+		return nil
+	}
+
+	review := PaymentReview{
+		AlumniID:     alumni.ID,
+		FromStatus:   alumni.PaymentStatus,
+		ToStatus:     PaymentApproved,
+		Reason:       fmt.Sprintf("Paid online, checkout %s", payment.SessionID),
+		ReviewerName: payment.Provider,
+	}
+	if err := tx.Create(&review).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&alumni).Select("payment_status", "payment_rejection_reason", "paid").Updates(&Alumni{
+		PaymentStatus: PaymentApproved,
+		Paid:          true,
+	}).Error
+}
+
+func (s *service) GetPaymentTotals(ctx context.Context) ([]PaymentTotal, error) {
+	var totals []PaymentTotal
+	result := s.db.WithContext(ctx).Model(&Payment{}).
+		Select("provider, status, currency, COUNT(*) AS count, COALESCE(SUM(amount), 0) AS amount").
+		Group("provider, status, currency").
+		Order("provider, status, currency").
+		Scan(&totals)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to total payments: %w", result.Error)
+	}
+	return totals, nil
+}
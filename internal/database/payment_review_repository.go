@@ -0,0 +1,156 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Payment statuses. An alumnus starts at PaymentNone, moves to
+// PaymentSubmitted when a proof is uploaded, and only PaymentApproved marks
+// them as paid.
+const (
+	PaymentNone        = "none"
+	PaymentSubmitted   = "submitted"
+	PaymentUnderReview = "under_review"
+	PaymentApproved    = "approved"
+	PaymentRejected    = "rejected"
+)
+
+// paymentTransitions lists the statuses reachable from each status.
+// Uploading a new proof moves back to submitted unless already approved.
+var paymentTransitions = map[string][]string{
+	PaymentNone:        {PaymentSubmitted},
+	PaymentSubmitted:   {PaymentSubmitted, PaymentUnderReview, PaymentApproved, PaymentRejected},
+	PaymentUnderReview: {PaymentSubmitted, PaymentApproved, PaymentRejected},
+	PaymentRejected:    {PaymentSubmitted},
+	PaymentApproved:    {},
+}
+
+var (
+	ErrInvalidPaymentTransition = errors.New("invalid payment status change")
+	ErrRejectionReasonRequired  = errors.New("a reason is required to reject a payment")
+)
+
+// CanTransitionPayment reports whether a payment may move from one status to another.
+func CanTransitionPayment(from, to string) bool {
+	if from == "" {
+		from = PaymentNone
+	}
+	for _, next := range paymentTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// PaymentReview is the audit record of one payment status change made by an admin.
+type PaymentReview struct {
+	ID            int       `gorm:"column:id;primaryKey"`
+	AlumniID      int       `gorm:"column:alumni_id;index;not null"`
+	Alumni        *Alumni   `gorm:"foreignKey:AlumniID;constraint:OnDelete:CASCADE" json:"-"`
+	FromStatus    string    `gorm:"column:from_status"`
+	ToStatus      string    `gorm:"column:to_status"`
+	Reason        string    `gorm:"column:reason"`
+	ReviewerID    int       `gorm:"column:reviewer_id"`
+	ReviewerName  string    `gorm:"column:reviewer_name"` // kept in case the admin is deleted
+	AttachmentKey string    `gorm:"column:attachment_key"`
+	CreatedAt     time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (PaymentReview) TableName() string {
+	return "payment_reviews"
+}
+
+type PaymentReviewService interface {
+	ReviewPayment(ctx context.Context, alumniID int, reviewer *Admin, status, reason string) (*Alumni, error)
+	GetPaymentReviews(ctx context.Context, alumniID int) ([]PaymentReview, error)
+	BackfillPaymentStatus(ctx context.Context) error
+}
+
+// ReviewPayment moves an alumnus's payment to status on behalf of reviewer
+// and records the change. Rejections need a reason, which is shown to the
+// alumnus; approving sets Paid.
+func (s *service) ReviewPayment(ctx context.Context, alumniID int, reviewer *Admin, status, reason string) (*Alumni, error) {
+	if status == PaymentRejected && reason == "" {
+		return nil, ErrRejectionReasonRequired
+	}
+	if status != PaymentUnderReview && status != PaymentApproved && status != PaymentRejected {
+		return nil, ErrInvalidPaymentTransition
+	}
+
+	var alumni Alumni
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&alumni, alumniID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("alumni not found")
+			}
+			return err
+		}
+		if !CanTransitionPayment(alumni.PaymentStatus, status) {
+			return ErrInvalidPaymentTransition
+		}
+
+		var attachmentKey string
+		tx.Model(&Attachment{}).
+			Where("alumni_id = ? AND kind = ?", alumniID, AttachmentPaymentProof).
+			Order("created_at DESC").
+			Limit(1).
+			Pluck("storage_key", &attachmentKey)
+
+		review := PaymentReview{
+			AlumniID:      alumniID,
+			FromStatus:    alumni.PaymentStatus,
+			ToStatus:      status,
+			Reason:        reason,
+			ReviewerID:    reviewer.ID,
+			ReviewerName:  reviewer.Username,
+			AttachmentKey: attachmentKey,
+		}
+		if err := tx.Create(&review).Error; err != nil {
+			return err
+		}
+
+		alumni.PaymentStatus = status
+		alumni.PaymentRejectionReason = ""
+		if status == PaymentRejected {
+			alumni.PaymentRejectionReason = reason
+		}
+		alumni.Paid = status == PaymentApproved
+		return tx.Model(&alumni).Select("payment_status", "payment_rejection_reason", "paid").Updates(&alumni).Error
+	})
+	if err != nil {
+		if errors.Is(err, ErrInvalidPaymentTransition) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to review payment: %w", err)
+	}
+
+	return &alumni, nil
+}
+
+func (s *service) GetPaymentReviews(ctx context.Context, alumniID int) ([]PaymentReview, error) {
+	var reviews []PaymentReview
+	result := s.db.WithContext(ctx).Where("alumni_id = ?", alumniID).Order("created_at ASC").Find(&reviews)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch payment reviews: %w", result.Error)
+	}
+	return reviews, nil
+}
+
+// BackfillPaymentStatus puts alumni that were marked paid on upload, before
+// payments were reviewed, into the review queue.
+func (s *service) BackfillPaymentStatus(ctx context.Context) error {
+	result := s.db.WithContext(ctx).Model(&Alumni{}).
+		Where("paid = true AND (payment_status IS NULL OR payment_status = ?)", PaymentNone).
+		Updates(map[string]interface{}{"payment_status": PaymentSubmitted, "paid": false})
+	if result.Error != nil {
+		return fmt.Errorf("failed to backfill payment status: %w", result.Error)
+	}
+	return nil
+}
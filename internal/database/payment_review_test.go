@@ -0,0 +1,23 @@
+package database
+
+import "testing"
+
+func TestCanTransitionPayment(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{"", PaymentSubmitted, true},
+		{PaymentNone, PaymentApproved, false},
+		{PaymentSubmitted, PaymentUnderReview, true},
+		{PaymentUnderReview, PaymentRejected, true},
+		{PaymentRejected, PaymentApproved, false},
+		{PaymentRejected, PaymentSubmitted, true},
+		{PaymentApproved, PaymentSubmitted, false},
+	}
+	for _, tt := range tests {
+		if got := CanTransitionPayment(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransitionPayment(%q, %q) = %v; want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
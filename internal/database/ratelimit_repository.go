@@ -0,0 +1,43 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type RateLimitCounter struct {
+	Key         string    `gorm:"column:key;primaryKey"`
+	WindowStart time.Time `gorm:"column:window_start;primaryKey"`
+	Count       int64     `gorm:"column:count"`
+	ExpiresAt   time.Time `gorm:"column:expires_at;index"`
+}
+
+func (RateLimitCounter) TableName() string {
+	return "rate_limits"
+}
+
+type RateLimitService interface {
+	IncrementRateLimit(ctx context.Context, key string, windowStart, expiresAt time.Time) (int64, error)
+	CleanupExpiredRateLimits(ctx context.Context) error
+}
+
+func (s *service) IncrementRateLimit(ctx context.Context, key string, windowStart, expiresAt time.Time) (int64, error) {
+	var count int64
+	err := s.db.WithContext(ctx).Raw(`
+		INSERT INTO rate_limits (key, window_start, count, expires_at)
+		VALUES (?, ?, 1, ?)
+		ON CONFLICT (key, window_start) DO UPDATE SET count = rate_limits.count + 1
+		RETURNING count`,
+		key, windowStart, expiresAt,
+	).Scan(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment rate limit: %w", err)
+	}
+	return count, nil
+}
+
+func (s *service) CleanupExpiredRateLimits(ctx context.Context) error {
+	result := s.db.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&RateLimitCounter{})
+	return result.Error
+}
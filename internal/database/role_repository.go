@@ -0,0 +1,298 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Permission names checked by the admin route middleware.
+const (
+	PermAlumniRead         = "alumni.read"
+	PermAlumniWrite        = "alumni.write"
+	PermAlumniDelete       = "alumni.delete"
+	PermPaymentReview      = "payment.review"
+	PermPaymentRead        = "payment.read"
+	PermNominationRead     = "nomination.read"
+	PermNominationExport   = "nomination.export"
+	PermNominationDelete   = "nomination.delete"
+	PermNominationManage   = "nomination.manage"
+	PermNominationJudge    = "nomination.judge"
+	PermSponsorshipRead    = "sponsorship.read"
+	PermSponsorshipConfirm = "sponsorship.confirm"
+	PermSponsorshipDelete  = "sponsorship.delete"
+	PermAdminManage        = "admin.manage"
+)
+
+// Built-in role names.
+const (
+	RoleSuperuser       = "superuser"
+	RoleRegistrar       = "registrar"
+	RoleFinance         = "finance"
+	RoleAwardsCommittee = "awards_committee"
+	RoleJudge           = "judge"
+	RoleViewer          = "viewer"
+)
+
+type Permission struct {
+	ID          int       `gorm:"column:id;primaryKey"`
+	Name        string    `gorm:"column:name;unique"`
+	Description string    `gorm:"column:description"`
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (Permission) TableName() string {
+	return "permissions"
+}
+
+type Role struct {
+	ID          int          `gorm:"column:id;primaryKey"`
+	Name        string       `gorm:"column:name;unique"`
+	Description string       `gorm:"column:description"`
+	Permissions []Permission `gorm:"many2many:role_permissions;"`
+	CreatedAt   time.Time    `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time    `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (Role) TableName() string {
+	return "roles"
+}
+
+var defaultPermissions = []Permission{
+	{Name: PermAlumniRead, Description: "View alumni records"},
+	{Name: PermAlumniWrite, Description: "Edit alumni records"},
+	{Name: PermAlumniDelete, Description: "Delete alumni records"},
+	{Name: PermPaymentReview, Description: "Approve or reject payment proofs"},
+	{Name: PermPaymentRead, Description: "View online payments and totals"},
+	{Name: PermNominationRead, Description: "View nominations"},
+	{Name: PermNominationExport, Description: "Export nominations"},
+	{Name: PermNominationDelete, Description: "Delete nominations"},
+	{Name: PermNominationManage, Description: "Run nomination campaigns and their categories"},
+	{Name: PermNominationJudge, Description: "Score shortlisted nominees in campaigns one judges"},
+	{Name: PermSponsorshipRead, Description: "View sponsorships"},
+	{Name: PermSponsorshipConfirm, Description: "Confirm sponsorships"},
+	{Name: PermSponsorshipDelete, Description: "Delete sponsorships"},
+	{Name: PermAdminManage, Description: "Manage admin accounts and roles"},
+}
+
+var defaultRoles = []struct {
+	Name        string
+	Description string
+	Permissions []string
+}{
+	{
+		Name:        RoleSuperuser,
+		Description: "Full access to every admin feature",
+		Permissions: []string{
+			PermAlumniRead, PermAlumniWrite, PermAlumniDelete, PermPaymentReview, PermPaymentRead,
+			PermNominationRead, PermNominationExport, PermNominationDelete, PermNominationManage, PermNominationJudge,
+			PermSponsorshipRead, PermSponsorshipConfirm, PermSponsorshipDelete,
+			PermAdminManage,
+		},
+	},
+	{
+		Name:        RoleRegistrar,
+		Description: "Manages alumni registrations",
+		Permissions: []string{PermAlumniRead, PermAlumniWrite, PermNominationRead, PermSponsorshipRead},
+	},
+	{
+		Name:        RoleFinance,
+		Description: "Reviews payments and confirms sponsorships",
+		Permissions: []string{PermAlumniRead, PermPaymentReview, PermPaymentRead, PermSponsorshipRead, PermSponsorshipConfirm},
+	},
+	{
+		Name:        RoleAwardsCommittee,
+		Description: "Runs nomination campaigns, reviews and exports nominations, and judges",
+		Permissions: []string{PermAlumniRead, PermNominationRead, PermNominationExport, PermNominationManage, PermNominationJudge},
+	},
+	{
+		Name:        RoleJudge,
+		Description: "Scores shortlisted nominees in the campaigns they judge",
+		Permissions: []string{PermNominationJudge},
+	},
+	{
+		Name:        RoleViewer,
+		Description: "Read-only access to the dashboard",
+		Permissions: []string{PermAlumniRead, PermNominationRead, PermSponsorshipRead},
+	},
+}
+
+type RoleService interface {
+	GetAllRoles(ctx context.Context) ([]Role, error)
+	GetAdminRoles(ctx context.Context, adminID int) ([]Role, error)
+	GetAdminPermissions(ctx context.Context, adminID int) ([]string, error)
+	SetAdminRoles(ctx context.Context, adminID int, roleNames []string) error
+	SeedRoles(ctx context.Context) error
+}
+
+func (s *service) GetAllRoles(ctx context.Context) ([]Role, error) {
+	var roles []Role
+	result := s.db.WithContext(ctx).Preload("Permissions").Order("name ASC").Find(&roles)
+	return roles, result.Error
+}
+
+func (s *service) GetAdminRoles(ctx context.Context, adminID int) ([]Role, error) {
+	var roles []Role
+	err := s.db.WithContext(ctx).
+		Joins("JOIN admin_roles ON admin_roles.role_id = roles.id").
+		Where("admin_roles.admin_id = ?", adminID).
+		Order("roles.name ASC").
+		Find(&roles).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch admin roles: %w", err)
+	}
+	return roles, nil
+}
+
+func (s *service) GetAdminPermissions(ctx context.Context, adminID int) ([]string, error) {
+	var permissions []string
+	err := s.db.WithContext(ctx).
+		Model(&Permission{}).
+		Distinct("permissions.name").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN admin_roles ON admin_roles.role_id = role_permissions.role_id").
+		Where("admin_roles.admin_id = ?", adminID).
+		Pluck("permissions.name", &permissions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch admin permissions: %w", err)
+	}
+	return permissions, nil
+}
+
+func (s *service) SetAdminRoles(ctx context.Context, adminID int, roleNames []string) error {
+	return s.keepingAdminManager(ctx, func(tx *gorm.DB) error {
+		return s.setAdminRoles(tx, adminID, roleNames)
+	})
+}
+
+// setAdminRoles replaces an admin's roles. Every admin keeps at least one
+// role, so the legacy backfill in SeedRoles never mistakes them for an
+// account from before roles existed.
+func (s *service) setAdminRoles(db *gorm.DB, adminID int, roleNames []string) error {
+	if len(roleNames) == 0 {
+		return ErrNoRoles
+	}
+	seen := make(map[string]bool, len(roleNames))
+	for _, name := range roleNames {
+		if seen[name] {
+			return ErrRoleRepeated
+		}
+		seen[name] = true
+	}
+
+	var roles []Role
+	if err := db.Where("name IN ?", roleNames).Find(&roles).Error; err != nil {
+		return fmt.Errorf("failed to fetch roles: %w", err)
+	}
+	if len(roles) != len(roleNames) {
+		return ErrUnknownRole
+	}
+
+	admin := &Admin{ID: adminID}
+	if err := db.Model(admin).Association("Roles").Replace(roles); err != nil {
+		return fmt.Errorf("failed to assign roles: %w", err)
+	}
+	return nil
+}
+
+// keepingAdminManager runs change in a transaction and rolls it back with
+// ErrLastAdminManager if it leaves no enabled admin able to manage admins.
+func (s *service) keepingAdminManager(ctx context.Context, change func(tx *gorm.DB) error) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Serialize changes so two admins cannot remove each other at once
+		if err := tx.Exec("LOCK TABLE admin_roles IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+			return fmt.Errorf("failed to lock admin roles: %w", err)
+		}
+
+		before, err := countAdminManagers(tx)
+		if err != nil {
+			return err
+		}
+		if err := change(tx); err != nil {
+			return err
+		}
+		after, err := countAdminManagers(tx)
+		if err != nil {
+			return err
+		}
+		if before > 0 && after == 0 {
+			return ErrLastAdminManager
+		}
+		return nil
+	})
+}
+
+func countAdminManagers(tx *gorm.DB) (int64, error) {
+	var count int64
+	err := tx.Model(&Admin{}).
+		Distinct("admins.id").
+		Joins("JOIN admin_roles ON admin_roles.admin_id = admins.id").
+		Joins("JOIN role_permissions ON role_permissions.role_id = admin_roles.role_id").
+		Joins("JOIN permissions ON permissions.id = role_permissions.permission_id").
+		Where("permissions.name = ? AND admins.disabled = ?", PermAdminManage, false).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count admin managers: %w", err)
+	}
+	return count, nil
+}
+
+// SeedRoles creates the built-in permissions and roles and keeps their
+// permission sets in sync with the defaults above. The first time it runs
+// against a database from before roles existed, it gives each admin a role
+// matching their old IsSuperuser flag.
+func (s *service) SeedRoles(ctx context.Context) error {
+	db := s.db.WithContext(ctx)
+
+	permissionsByName := make(map[string]Permission)
+	for _, p := range defaultPermissions {
+		permission := p
+		if err := db.Where(Permission{Name: permission.Name}).Attrs(permission).FirstOrCreate(&permission).Error; err != nil {
+			return fmt.Errorf("failed to seed permission %s: %w", p.Name, err)
+		}
+		permissionsByName[permission.Name] = permission
+	}
+
+	for _, r := range defaultRoles {
+		role := Role{Name: r.Name, Description: r.Description}
+		if err := db.Where(Role{Name: r.Name}).Attrs(role).FirstOrCreate(&role).Error; err != nil {
+			return fmt.Errorf("failed to seed role %s: %w", r.Name, err)
+		}
+
+		permissions := make([]Permission, 0, len(r.Permissions))
+		for _, name := range r.Permissions {
+			permissions = append(permissions, permissionsByName[name])
+		}
+		if err := db.Model(&role).Association("Permissions").Replace(permissions); err != nil {
+			return fmt.Errorf("failed to seed permissions for role %s: %w", r.Name, err)
+		}
+	}
+
+	// Once any admin has a role the backfill has run, and an admin without
+	// roles after that had them removed on purpose
+	var assigned int64
+	if err := db.Table("admin_roles").Count(&assigned).Error; err != nil {
+		return fmt.Errorf("failed to count admin roles: %w", err)
+	}
+	if assigned > 0 {
+		return nil
+	}
+
+	var legacyAdmins []Admin
+	if err := db.Find(&legacyAdmins).Error; err != nil {
+		return fmt.Errorf("failed to find admins without roles: %w", err)
+	}
+	for _, admin := range legacyAdmins {
+		role := RoleRegistrar
+		if admin.IsSuperuser {
+			role = RoleSuperuser
+		}
+		if err := s.setAdminRoles(db, admin.ID, []string{role}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
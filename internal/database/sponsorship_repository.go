@@ -25,7 +25,9 @@ type Sponsorship struct {
 type SponsorshipService interface {
 	CreateSponsorship(ctx context.Context, sponsorship *Sponsorship) error
 	GetAllSponsorships(ctx context.Context) ([]Sponsorship, error)
+	EachSponsorship(ctx context.Context, fn func(Sponsorship) error) error
 	GetSponsorshipByEmail(ctx context.Context, email string) (*Sponsorship, error)
+	GetSponsorshipByID(ctx context.Context, id uint) (*Sponsorship, error)
 	UpdateSponsorship(ctx context.Context, sponsorship *Sponsorship) error
 	DeleteSponsorship(ctx context.Context, id uint) error
 	UpdateSponsorshipConfirmation(ctx context.Context, id uint, confirmed bool, feedback string) error
@@ -42,6 +44,15 @@ func (s *service) GetAllSponsorships(ctx context.Context) ([]Sponsorship, error)
 	return sponsorships, err
 }
 
+// EachSponsorship streams sponsorships to fn in the same order as GetAllSponsorships.
+func (s *service) EachSponsorship(ctx context.Context, fn func(Sponsorship) error) error {
+	query := s.db.WithContext(ctx).Model(&Sponsorship{}).Order("created_at DESC")
+	if err := eachRow(query, fn); err != nil {
+		return fmt.Errorf("failed to stream sponsorships: %w", err)
+	}
+	return nil
+}
+
 func (s *service) UpdateSponsorshipConfirmation(ctx context.Context, id uint, confirmed bool, feedback string) error {
 	return s.db.WithContext(ctx).Model(&Sponsorship{}).Where("id = ?", id).Updates(map[string]interface{}{
 		"confirmed": confirmed,
@@ -58,6 +69,15 @@ func (s *service) GetSponsorshipByEmail(ctx context.Context, email string) (*Spo
 	return &sponsorship, nil
 }
 
+func (s *service) GetSponsorshipByID(ctx context.Context, id uint) (*Sponsorship, error) {
+	var sponsorship Sponsorship
+	err := s.db.WithContext(ctx).Where("id = ?", id).First(&sponsorship).Error
+	if err != nil {
+		return nil, err
+	}
+	return &sponsorship, nil
+}
+
 func (s *service) UpdateSponsorship(ctx context.Context, sponsorship *Sponsorship) error {
 	return s.db.WithContext(ctx).Save(sponsorship).Error
 }
@@ -2,36 +2,68 @@ package email
 
 import (
 	"fmt"
+	"html"
+	"log"
 	"os"
 	"strconv"
-
-	"gopkg.in/gomail.v2"
 )
 
 type EmailService struct {
-	host     string
-	port     int
-	username string
-	password string
-	from     string
+	from      string
+	transport Transport
+	catcher   *MailCatcher
 }
 
-func NewEmailService() *EmailService {
+// NewEmailService picks the transport from EMAIL_BACKEND: "smtp" (the
+// default) or "catcher", which keeps messages in memory for development.
+// The catcher requires devMode, since it would swallow real users' mail.
+func NewEmailService(devMode bool) *EmailService {
+	from := os.Getenv("SMTP_FROM")
+
+	switch backend := os.Getenv("EMAIL_BACKEND"); backend {
+	case "catcher":
+		if devMode {
+			catcher := NewMailCatcher()
+			return &EmailService{from: from, transport: catcher, catcher: catcher}
+		}
+		log.Println("Warning: EMAIL_BACKEND=catcher is ignored outside development mode, using smtp")
+	case "", "smtp":
+	default:
+		log.Printf("Warning: unknown EMAIL_BACKEND %q, using smtp", backend)
+	}
+
 	port, _ := strconv.Atoi(os.Getenv("SMTP_PORT"))
-	return &EmailService{
+	return NewEmailServiceWithTransport(from, &SMTPTransport{
 		host:     os.Getenv("SMTP_HOST"),
 		port:     port,
 		username: os.Getenv("SMTP_USERNAME"),
 		password: os.Getenv("SMTP_PASSWORD"),
-		from:     os.Getenv("SMTP_FROM"),
+	})
+}
+
+func NewEmailServiceWithTransport(from string, transport Transport) *EmailService {
+	e := &EmailService{from: from, transport: transport}
+	if catcher, ok := transport.(*MailCatcher); ok {
+		e.catcher = catcher
 	}
+	return e
 }
 
-func (e *EmailService) SendOTP(to, code, purpose string) error {
-	m := gomail.NewMessage()
-	m.SetHeader("From", e.from)
-	m.SetHeader("To", to)
+// Catcher returns the in-memory mail catcher, or nil when mail is really sent.
+func (e *EmailService) Catcher() *MailCatcher {
+	return e.catcher
+}
 
+func (e *EmailService) send(to, subject, body string) error {
+	return e.transport.Send(Message{
+		From:    e.from,
+		To:      to,
+		Subject: subject,
+		HTML:    body,
+	})
+}
+
+func (e *EmailService) SendOTP(to, code, purpose string) error {
 	var subject, body string
 
 	switch purpose {
@@ -180,10 +212,104 @@ func (e *EmailService) SendOTP(to, code, purpose string) error {
 		return fmt.Errorf("unknown purpose: %s", purpose)
 	}
 
-	m.SetHeader("Subject", subject)
-	m.SetBody("text/html", body)
+	return e.send(to, subject, body)
+}
+
+// SendPaymentDecision tells an alumnus whether their payment proof was
+// approved or rejected, including the reviewer's reason on rejection.
+func (e *EmailService) SendPaymentDecision(to, name string, approved bool, reason string) error {
+	subject := "UNOR CIT Connect - Payment Confirmed"
+	headline := "Your payment has been confirmed"
+	message := "Thank you! We have reviewed your proof of payment and your registration for the 40th Anniversary Homecoming Celebration is now marked as paid."
+	color := "#10b981"
+	if !approved {
+		subject = "UNOR CIT Connect - Payment Proof Needs Attention"
+		headline = "We could not confirm your payment"
+		message = fmt.Sprintf("We reviewed your proof of payment but could not accept it for the following reason:</p><p style=\"background: #fff; border-left: 4px solid #ef4444; padding: 12px;\">%s</p><p>Please upload a new proof of payment from the registration page.",
+			html.EscapeString(reason))
+		color = "#ef4444"
+	}
+
+	body := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <style>
+        body { font-family: 'JetBrains Mono', monospace; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background: %s; color: white; padding: 30px; text-align: center; border-radius: 10px 10px 0 0; }
+        .content { background: #f8f9fa; padding: 30px; border-radius: 0 0 10px 10px; }
+        .footer { text-align: center; margin-top: 20px; color: #666; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>UNOR CIT Connect</h1>
+            <p>Payment Review</p>
+        </div>
+        <div class="content">
+            <h2>%s</h2>
+            <p>Hi %s,</p>
+            <p>%s</p>
 
-	d := gomail.NewDialer(e.host, e.port, e.username, e.password)
+            <p>Best regards,<br>
+            <strong>UNOR CIT Connect Team</strong><br>
+            University of Negros Occidental - Recoletos</p>
+        </div>
+        <div class="footer">
+            <p>© 2025 UNOR CIT Connect. All rights reserved.</p>
+            <p>Bacolod City, Philippines | unorcitconnect@gmail.com</p>
+        </div>
+    </div>
+</body>
+</html>`, color, headline, html.EscapeString(name), message)
+
+	return e.send(to, subject, body)
+}
+
+// SendLoginLink emails an alumnus the magic link that signs them in to
+// their profile.
+func (e *EmailService) SendLoginLink(to, name, link string) error {
+	subject := "UNOR CIT Connect - Your Sign-in Link"
+	body := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <style>
+        body { font-family: 'JetBrains Mono', monospace; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%); color: white; padding: 30px; text-align: center; border-radius: 10px 10px 0 0; }
+        .content { background: #f8f9fa; padding: 30px; border-radius: 0 0 10px 10px; }
+        .button { display: inline-block; background: #667eea; color: #fff !important; padding: 14px 28px; border-radius: 8px; text-decoration: none; font-weight: bold; }
+        .footer { text-align: center; margin-top: 20px; color: #666; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>UNOR CIT Connect</h1>
+            <p>Alumni Profile Sign-in</p>
+        </div>
+        <div class="content">
+            <p>Hi %s,</p>
+            <p>Use the button below to sign in and update your alumni profile or payment details.</p>
+
+            <p style="text-align: center; margin: 30px 0;"><a class="button" href="%s">Sign in to my profile</a></p>
+
+            <p><strong>Important:</strong> This link expires in 15 minutes and can only be used once. If you didn't request it, please ignore this email.</p>
+
+            <p>Best regards,<br>
+            <strong>UNOR CIT Connect Team</strong><br>
+            University of Negros Occidental - Recoletos</p>
+        </div>
+        <div class="footer">
+            <p>© 2025 UNOR CIT Connect. All rights reserved.</p>
+            <p>Bacolod City, Philippines | unorcitconnect@gmail.com</p>
+        </div>
+    </div>
+</body>
+</html>`, html.EscapeString(name), html.EscapeString(link))
 
-	return d.DialAndSend(m)
+	return e.send(to, subject, body)
 }
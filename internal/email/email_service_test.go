@@ -0,0 +1,89 @@
+package email
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSendOTPWithMailCatcher(t *testing.T) {
+	catcher := NewMailCatcher()
+	e := NewEmailServiceWithTransport("noreply@example.com", catcher)
+
+	if err := e.SendOTP("alumni@example.com", "1234", "registration"); err != nil {
+		t.Fatalf("SendOTP() returned error: %v", err)
+	}
+
+	messages := catcher.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 caught message, got %d", len(messages))
+	}
+	if messages[0].To != "alumni@example.com" {
+		t.Errorf("expected recipient alumni@example.com, got %s", messages[0].To)
+	}
+	if !strings.Contains(messages[0].HTML, "1234") {
+		t.Errorf("expected message body to contain the code")
+	}
+
+	catcher.Clear()
+	if len(catcher.Messages()) != 0 {
+		t.Errorf("expected catcher to be empty after Clear()")
+	}
+}
+
+func TestNominationEmails(t *testing.T) {
+	catcher := NewMailCatcher()
+	e := NewEmailServiceWithTransport("noreply@example.com", catcher)
+
+	n := NominationEmail{
+		NomineeName:  "JUAN <b>DELA CRUZ</b>",
+		NomineeEmail: "juan@example.com",
+		Year:         2010,
+		Category:     "Technology",
+		Campaign:     "2025 Outstanding Alumni Awards",
+		SubmittedAt:  time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC),
+		OptOutURL:    "https://example.com/?opt_out_token=abc&x=1",
+	}
+	if err := e.SendNomineeCongratulations("juan@example.com", n); err != nil {
+		t.Fatalf("SendNomineeCongratulations() returned error: %v", err)
+	}
+	n.NomineeNotified = true
+	if err := e.SendNominationReceipt("nominator@example.com", n); err != nil {
+		t.Fatalf("SendNominationReceipt() returned error: %v", err)
+	}
+
+	messages := catcher.Messages()
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 caught messages, got %d", len(messages))
+	}
+	for _, m := range messages {
+		if strings.Contains(m.HTML, "<b>") {
+			t.Errorf("expected the nominee name to be escaped in the message to %s", m.To)
+		}
+		if !strings.Contains(m.HTML, `href="https://example.com/?opt_out_token=abc&amp;x=1"`) {
+			t.Errorf("expected an opt-out link in the message to %s", m.To)
+		}
+		if strings.Contains(m.HTML, "nominator@example.com") {
+			t.Errorf("expected the nominator not to be named in the message to %s", m.To)
+		}
+	}
+
+	// The catcher lists the newest message first
+	receipt := messages[0].HTML
+	for _, want := range []string{"Technology", "2010", "juan@example.com", "March 1, 2025", "We have let the nominee know"} {
+		if !strings.Contains(receipt, want) {
+			t.Errorf("expected the receipt to contain %q", want)
+		}
+	}
+}
+
+func TestNewEmailServiceCatcherRequiresDevMode(t *testing.T) {
+	t.Setenv("EMAIL_BACKEND", "catcher")
+
+	if NewEmailService(false).Catcher() != nil {
+		t.Errorf("expected the mail catcher to be ignored outside development mode")
+	}
+	if NewEmailService(true).Catcher() == nil {
+		t.Errorf("expected the mail catcher in development mode")
+	}
+}
@@ -0,0 +1,74 @@
+package email
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+)
+
+//go:embed templates/*.html
+var templateFiles embed.FS
+
+// templates holds each message parsed together with the shared layout. A
+// message template defines "subtitle" and "content", and its data may set
+// OptOutURL to show an unsubscribe link in the footer.
+var templates = parseTemplates("nominee_congratulations.html", "nomination_receipt.html")
+
+func parseTemplates(names ...string) map[string]*template.Template {
+	layout := template.Must(template.ParseFS(templateFiles, "templates/layout.html"))
+
+	parsed := make(map[string]*template.Template, len(names))
+	for _, name := range names {
+		t := template.Must(layout.Clone())
+		parsed[name] = template.Must(t.ParseFS(templateFiles, "templates/"+name))
+	}
+	return parsed
+}
+
+func render(name string, data any) (string, error) {
+	t, ok := templates[name]
+	if !ok {
+		return "", fmt.Errorf("unknown email template: %s", name)
+	}
+
+	var body strings.Builder
+	if err := t.ExecuteTemplate(&body, "layout", data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return body.String(), nil
+}
+
+// NominationEmail describes a recorded nomination for the emails sent about
+// it. OptOutURL is the recipient's own unsubscribe link.
+type NominationEmail struct {
+	NomineeName     string
+	NomineeEmail    string
+	Year            int
+	Category        string
+	Campaign        string
+	SubmittedAt     time.Time
+	NomineeNotified bool
+	OptOutURL       string
+}
+
+// SendNomineeCongratulations tells a nominee they were nominated, without
+// saying by whom.
+func (e *EmailService) SendNomineeCongratulations(to string, n NominationEmail) error {
+	body, err := render("nominee_congratulations.html", n)
+	if err != nil {
+		return err
+	}
+	return e.send(to, "UNOR CIT Connect - You Have Been Nominated!", body)
+}
+
+// SendNominationReceipt gives a nominator a summary of the nomination they
+// submitted.
+func (e *EmailService) SendNominationReceipt(to string, n NominationEmail) error {
+	body, err := render("nomination_receipt.html", n)
+	if err != nil {
+		return err
+	}
+	return e.send(to, "UNOR CIT Connect - Nomination Received", body)
+}
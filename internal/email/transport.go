@@ -0,0 +1,86 @@
+package email
+
+import (
+	"sync"
+	"time"
+
+	"gopkg.in/gomail.v2"
+)
+
+// Message is a single outgoing HTML email.
+type Message struct {
+	From    string    `json:"from"`
+	To      string    `json:"to"`
+	Subject string    `json:"subject"`
+	HTML    string    `json:"html"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+// Transport delivers messages built by EmailService.
+type Transport interface {
+	Send(m Message) error
+}
+
+// SMTPTransport sends mail through the configured SMTP server.
+type SMTPTransport struct {
+	host     string
+	port     int
+	username string
+	password string
+}
+
+func (t *SMTPTransport) Send(msg Message) error {
+	m := gomail.NewMessage()
+	m.SetHeader("From", msg.From)
+	m.SetHeader("To", msg.To)
+	m.SetHeader("Subject", msg.Subject)
+	m.SetBody("text/html", msg.HTML)
+
+	d := gomail.NewDialer(t.host, t.port, t.username, t.password)
+
+	return d.DialAndSend(m)
+}
+
+const mailCatcherCapacity = 100
+
+// MailCatcher keeps the most recent messages in memory instead of sending
+// them, so development flows can be tested without a real inbox.
+type MailCatcher struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewMailCatcher() *MailCatcher {
+	return &MailCatcher{}
+}
+
+func (c *MailCatcher) Send(m Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	m.SentAt = time.Now()
+	c.messages = append(c.messages, m)
+	if len(c.messages) > mailCatcherCapacity {
+		c.messages = c.messages[len(c.messages)-mailCatcherCapacity:]
+	}
+	return nil
+}
+
+// Messages returns the caught messages, newest first.
+func (c *MailCatcher) Messages() []Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	messages := make([]Message, len(c.messages))
+	for i, m := range c.messages {
+		messages[len(c.messages)-1-i] = m
+	}
+	return messages
+}
+
+func (c *MailCatcher) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.messages = nil
+}
@@ -0,0 +1,39 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) WriteRow(values []string) error {
+	return c.w.Write(sanitizeCSVRow(values))
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// sanitizeCSVRow prefixes cells that a spreadsheet would evaluate as a formula
+// so user-submitted values cannot run formulas when the export is opened.
+// Leading tabs and carriage returns are included, since some spreadsheets
+// skip them before looking for a formula.
+func sanitizeCSVRow(values []string) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+			v = "'" + v
+		}
+		out[i] = v
+	}
+	return out
+}
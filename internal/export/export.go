@@ -0,0 +1,114 @@
+// Package export writes tabular data as CSV or XLSX, one row at a time, so
+// large tables can be streamed to a client without being held in memory.
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format is a supported output format.
+type Format string
+
+const (
+	CSV  Format = "csv"
+	XLSX Format = "xlsx"
+)
+
+// ParseFormat validates a format name, defaulting to CSV when empty.
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(name))) {
+	case "", CSV:
+		return CSV, nil
+	case XLSX:
+		return XLSX, nil
+	default:
+		return "", fmt.Errorf("unsupported export format %q", name)
+	}
+}
+
+// ContentType returns the MIME type for f.
+func (f Format) ContentType() string {
+	if f == XLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Writer receives a header row followed by data rows.
+type Writer interface {
+	WriteRow(values []string) error
+	// Close flushes any buffered output. It does not close the underlying writer.
+	Close() error
+}
+
+// NewWriter returns a Writer for f that writes to w.
+func NewWriter(f Format, w io.Writer, sheetName string) (Writer, error) {
+	switch f {
+	case CSV:
+		return newCSVWriter(w), nil
+	case XLSX:
+		return newXLSXWriter(w, sheetName)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", f)
+	}
+}
+
+// Column extracts one exported field from a record.
+type Column[T any] struct {
+	Name  string
+	Value func(T) string
+}
+
+// SelectColumns returns the columns named in names, in that order. An empty
+// list selects every column.
+func SelectColumns[T any](columns []Column[T], names []string) ([]Column[T], error) {
+	if len(names) == 0 {
+		return columns, nil
+	}
+
+	byName := make(map[string]Column[T], len(columns))
+	for _, col := range columns {
+		byName[col.Name] = col
+	}
+
+	selected := make([]Column[T], 0, len(names))
+	for _, name := range names {
+		col, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		selected = append(selected, col)
+	}
+	return selected, nil
+}
+
+// ParseColumns splits a comma separated column list, ignoring blanks.
+func ParseColumns(list string) []string {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Header returns the names of columns.
+func Header[T any](columns []Column[T]) []string {
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.Name
+	}
+	return header
+}
+
+// Row returns the values of columns for record.
+func Row[T any](columns []Column[T], record T) []string {
+	row := make([]string, len(columns))
+	for i, col := range columns {
+		row[i] = col.Value(record)
+	}
+	return row
+}
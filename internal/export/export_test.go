@@ -0,0 +1,94 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+type person struct {
+	Name string
+	City string
+}
+
+var personColumns = []Column[person]{
+	{Name: "name", Value: func(p person) string { return p.Name }},
+	{Name: "city", Value: func(p person) string { return p.City }},
+}
+
+func TestSelectColumns(t *testing.T) {
+	cols, err := SelectColumns(personColumns, []string{"city", "name"})
+	if err != nil {
+		t.Fatalf("SelectColumns() returned error: %v", err)
+	}
+	if got := strings.Join(Header(cols), ","); got != "city,name" {
+		t.Errorf("expected columns in requested order, got %q", got)
+	}
+
+	if _, err := SelectColumns(personColumns, []string{"payment_proof_data"}); err == nil {
+		t.Error("expected unknown column to be rejected")
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(CSV, &buf, "")
+	if err != nil {
+		t.Fatalf("NewWriter() returned error: %v", err)
+	}
+	w.WriteRow(Header(personColumns))
+	w.WriteRow(Row(personColumns, person{Name: "=HYPERLINK(1)", City: "Bacolod, PH"}))
+	w.WriteRow(Row(personColumns, person{Name: "\t=1+1", City: "\r@SUM(1)"}))
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() returned error: %v", err)
+	}
+
+	want := "name,city\n'=HYPERLINK(1),\"Bacolod, PH\"\n'\t=1+1,\"'\r@SUM(1)\"\n"
+	if buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(XLSX, &buf, "Alumni")
+	if err != nil {
+		t.Fatalf("NewWriter() returned error: %v", err)
+	}
+	w.WriteRow(Header(personColumns))
+	w.WriteRow(Row(personColumns, person{Name: "Ana & Ben", City: "<Iloilo>"}))
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() returned error: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("output is not a zip archive: %v", err)
+	}
+
+	var sheet string
+	for _, f := range zr.File {
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, _ := f.Open()
+			b, _ := io.ReadAll(rc)
+			rc.Close()
+			sheet = string(b)
+		}
+	}
+	if !strings.Contains(sheet, `<c r="B2" t="inlineStr"><is><t xml:space="preserve">&lt;Iloilo&gt;</t></is></c>`) {
+		t.Errorf("expected escaped cell B2 in sheet, got %s", sheet)
+	}
+	if !strings.HasSuffix(sheet, "</sheetData></worksheet>") {
+		t.Error("expected sheet to be closed")
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := columnName(i); got != want {
+			t.Errorf("columnName(%d) = %q; want %q", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Package geocode turns an alumnus's city and country into map coordinates.
+// Countries are identified by their ISO 3166-1 alpha-2 code, the Code of a
+// database.Country.
+package geocode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var ErrNotFound = errors.New("location not found")
+
+// Location is a point in decimal degrees.
+type Location struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Provider looks up the coordinates of a city. It returns ErrNotFound when
+// the city is unknown.
+type Provider interface {
+	Name() string
+	Geocode(ctx context.Context, city, countryCode string) (*Location, error)
+}
+
+// NewFromEnv builds the Provider selected by GEOCODER: "offline", the
+// default, or "none" to turn geocoding off, in which case it returns nil.
+// GEOCODE_CITIES_FILE replaces the offline provider's bundled dataset.
+func NewFromEnv() (Provider, error) {
+	switch os.Getenv("GEOCODER") {
+	case "", "offline":
+		if path := os.Getenv("GEOCODE_CITIES_FILE"); path != "" {
+			return LoadOfflineFile(path)
+		}
+		return NewOffline()
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown GEOCODER %q", os.Getenv("GEOCODER"))
+	}
+}
+
+// NormalizeCity reduces a city name to the form used for lookups and cache
+// keys: lower case, without accents, extra spaces or a trailing "City", so
+// "Bacolod City" and " bacolod" match.
+func NormalizeCity(city string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(strings.ToLower(city)) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	name := strings.Join(strings.Fields(b.String()), " ")
+	if trimmed, ok := strings.CutSuffix(name, " city"); ok && trimmed != "" {
+		name = trimmed
+	}
+	return name
+}
+
+// NormalizeCountryCode upper-cases and trims a country code.
+func NormalizeCountryCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,66 @@
+package geocode
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeCity(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Bacolod City", "bacolod"},
+		{"  bacolod ", "bacolod"},
+		{"Parañaque", "paranaque"},
+		{"Cagayan  de Oro", "cagayan de oro"},
+		{"City", "city"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeCity(tt.in); got != tt.want {
+			t.Errorf("NormalizeCity(%q) = %q; want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestOfflineBundledDataset(t *testing.T) {
+	o, err := NewOffline()
+	if err != nil {
+		t.Fatalf("NewOffline() returned error: %v", err)
+	}
+
+	location, err := o.Geocode(context.Background(), "Bacolod City", "ph")
+	if err != nil {
+		t.Fatalf("Geocode() returned error: %v", err)
+	}
+	if location.Latitude < 10 || location.Latitude > 11 || location.Longitude < 122 || location.Longitude > 124 {
+		t.Errorf("unexpected location for Bacolod: %+v", location)
+	}
+
+	// Cities are looked up within their country only
+	if _, err := o.Geocode(context.Background(), "Bacolod", "US"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestLoadOffline(t *testing.T) {
+	o, err := LoadOffline(strings.NewReader("# comment\ncountry_code,city,latitude,longitude\nPH,Silay,10.7967,122.9756\n"))
+	if err != nil {
+		t.Fatalf("LoadOffline() returned error: %v", err)
+	}
+	if _, err := o.Geocode(context.Background(), "silay city", "PH"); err != nil {
+		t.Errorf("Geocode() returned error: %v", err)
+	}
+
+	for _, dataset := range []string{
+		"country_code,city,latitude,longitude\nPH,Silay,north,122.9756\n",
+		"country_code,city,latitude,longitude\nPH,Silay,10.7967,222.9756\n",
+		"country_code,city,latitude,longitude\nPH,Silay\n",
+	} {
+		if _, err := LoadOffline(strings.NewReader(dataset)); err == nil {
+			t.Errorf("expected error loading %q", dataset)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package geocode
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+//go:embed cities.csv
+var bundledCities []byte
+
+type cityKey struct {
+	countryCode string
+	city        string
+}
+
+// Offline geocodes from a dataset of cities held in memory, so lookups need
+// no network access. The dataset is a CSV file with the header
+// country_code,city,latitude,longitude.
+type Offline struct {
+	cities map[cityKey]Location
+}
+
+// NewOffline loads the dataset bundled with the binary.
+func NewOffline() (*Offline, error) {
+	return LoadOffline(bytes.NewReader(bundledCities))
+}
+
+func LoadOfflineFile(path string) (*Offline, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cities dataset: %w", err)
+	}
+	defer f.Close()
+	return LoadOffline(f)
+}
+
+func LoadOffline(r io.Reader) (*Offline, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 4
+	reader.Comment = '#'
+
+	if _, err := reader.Read(); err != nil {
+		return nil, fmt.Errorf("failed to read cities dataset header: %w", err)
+	}
+
+	o := &Offline{cities: make(map[cityKey]Location)}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cities dataset: %w", err)
+		}
+
+		lat, err := strconv.ParseFloat(record[2], 64)
+		if err != nil || lat < -90 || lat > 90 {
+			return nil, fmt.Errorf("invalid latitude %q for %s", record[2], record[1])
+		}
+		lng, err := strconv.ParseFloat(record[3], 64)
+		if err != nil || lng < -180 || lng > 180 {
+			return nil, fmt.Errorf("invalid longitude %q for %s", record[3], record[1])
+		}
+
+		key := cityKey{NormalizeCountryCode(record[0]), NormalizeCity(record[1])}
+		o.cities[key] = Location{Latitude: lat, Longitude: lng}
+	}
+	return o, nil
+}
+
+func (o *Offline) Name() string { return "offline" }
+
+func (o *Offline) Geocode(_ context.Context, city, countryCode string) (*Location, error) {
+	location, ok := o.cities[cityKey{NormalizeCountryCode(countryCode), NormalizeCity(city)}]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &location, nil
+}
@@ -0,0 +1,300 @@
+// Package importer loads legacy alumni spreadsheets into the database.
+//
+// An import validates every row before anything is written: if any row is
+// invalid or duplicates an existing alumnus, nothing is imported and the
+// report explains why. Valid files are written in a single transaction.
+package importer
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"unorcitconnect/internal/database"
+)
+
+// MaxRows caps the number of data rows accepted in one file.
+const MaxRows = 5000
+
+// MinYear is the earliest graduation year accepted.
+const MinYear = 1950
+
+// Row statuses reported for each data row.
+const (
+	StatusValid     = "valid"
+	StatusInvalid   = "invalid"
+	StatusDuplicate = "duplicate"
+)
+
+var requiredColumns = []string{"first_name", "last_name", "email", "year", "course"}
+
+var optionalColumns = []string{"phone", "company", "position", "country", "city"}
+
+// Store is the subset of database.Service an import needs.
+type Store interface {
+	GetAllCourses(ctx context.Context) ([]database.Course, error)
+	GetAllCountries(ctx context.Context) ([]database.Country, error)
+	FindAlumniByEmail(ctx context.Context, email string) (*database.Alumni, error)
+	CreateAlumniBatch(ctx context.Context, alumni []database.Alumni) error
+}
+
+// RowResult is the outcome of validating one data row. Row is the line
+// number in the file, counting the header as line 1.
+type RowResult struct {
+	Row    int      `json:"row"`
+	Email  string   `json:"email"`
+	Status string   `json:"status"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Report summarises an import.
+type Report struct {
+	DryRun     bool        `json:"dry_run"`
+	Total      int         `json:"total"`
+	Valid      int         `json:"valid"`
+	Invalid    int         `json:"invalid"`
+	Duplicates int         `json:"duplicates"`
+	Imported   int         `json:"imported"`
+	Rows       []RowResult `json:"rows"`
+}
+
+// OK reports whether every row passed validation.
+func (r *Report) OK() bool {
+	return r.Invalid == 0 && r.Duplicates == 0
+}
+
+// ErrMalformedFile is returned when the CSV itself cannot be used, as opposed
+// to individual rows failing validation.
+var ErrMalformedFile = errors.New("malformed import file")
+
+// ImportAlumniCSV validates the CSV in r and, unless dryRun is set or a row
+// fails validation, creates every row in one transaction.
+func ImportAlumniCSV(ctx context.Context, store Store, r io.Reader, dryRun bool) (*Report, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot read header: %v", ErrMalformedFile, err)
+	}
+	columns, err := mapColumns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := newValidator(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{DryRun: dryRun, Rows: []RowResult{}}
+	var alumni []database.Alumni
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrMalformedFile, line, err)
+		}
+		if isBlank(record) {
+			continue
+		}
+		if report.Total == MaxRows {
+			return nil, fmt.Errorf("%w: more than %d rows", ErrMalformedFile, MaxRows)
+		}
+		report.Total++
+
+		a, result, err := v.validate(ctx, line, func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		switch result.Status {
+		case StatusValid:
+			report.Valid++
+			alumni = append(alumni, a)
+		case StatusDuplicate:
+			report.Duplicates++
+		default:
+			report.Invalid++
+		}
+		report.Rows = append(report.Rows, result)
+	}
+
+	if dryRun || !report.OK() || len(alumni) == 0 {
+		return report, nil
+	}
+
+	if err := store.CreateAlumniBatch(ctx, alumni); err != nil {
+		return nil, err
+	}
+	report.Imported = len(alumni)
+
+	return report, nil
+}
+
+// mapColumns returns the index of each known column in header. Header names
+// are matched case-insensitively and may use spaces instead of underscores.
+func mapColumns(header []string) (map[string]int, error) {
+	known := make(map[string]bool)
+	for _, name := range append(append([]string{}, requiredColumns...), optionalColumns...) {
+		known[name] = true
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		name = strings.ReplaceAll(name, " ", "_")
+		if !known[name] {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrMalformedFile, name)
+		}
+		if _, dup := columns[name]; dup {
+			return nil, fmt.Errorf("%w: duplicate column %q", ErrMalformedFile, name)
+		}
+		columns[name] = i
+	}
+
+	for _, name := range requiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: missing required column %q", ErrMalformedFile, name)
+		}
+	}
+	return columns, nil
+}
+
+func isBlank(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// validator checks rows against the reference tables and earlier rows.
+type validator struct {
+	store     Store
+	courses   map[string]string // lower-case code or name -> course name
+	countries map[string]string // lower-case code or name -> country name
+	seen      map[string]int    // lower-case email -> first line it appeared on
+	maxYear   int
+}
+
+func newValidator(ctx context.Context, store Store) (*validator, error) {
+	courses, err := store.GetAllCourses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load courses: %w", err)
+	}
+	countries, err := store.GetAllCountries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load countries: %w", err)
+	}
+
+	v := &validator{
+		store:     store,
+		courses:   make(map[string]string),
+		countries: make(map[string]string),
+		seen:      make(map[string]int),
+		maxYear:   time.Now().Year(),
+	}
+	// Alumni records store the course and country names, as the registration
+	// form does, but spreadsheets may use either the code or the name.
+	for _, c := range courses {
+		v.courses[strings.ToLower(c.Code)] = c.Name
+		v.courses[strings.ToLower(c.Name)] = c.Name
+	}
+	for _, c := range countries {
+		v.countries[strings.ToLower(c.Code)] = c.Name
+		v.countries[strings.ToLower(c.Name)] = c.Name
+	}
+	return v, nil
+}
+
+func (v *validator) validate(ctx context.Context, line int, field func(string) string) (database.Alumni, RowResult, error) {
+	result := RowResult{Row: line, Email: field("email"), Status: StatusValid}
+	fail := func(format string, args ...any) {
+		result.Status = StatusInvalid
+		result.Errors = append(result.Errors, fmt.Sprintf(format, args...))
+	}
+
+	a := database.Alumni{
+		FirstName: field("first_name"),
+		LastName:  field("last_name"),
+		Email:     result.Email,
+		Phone:     field("phone"),
+		Company:   field("company"),
+		Position:  field("position"),
+		City:      field("city"),
+	}
+
+	if a.FirstName == "" {
+		fail("first_name is required")
+	}
+	if a.LastName == "" {
+		fail("last_name is required")
+	}
+
+	if a.Email == "" {
+		fail("email is required")
+	} else if addr, err := mail.ParseAddress(a.Email); err != nil || addr.Address != a.Email {
+		fail("email %q is not a valid address", a.Email)
+	}
+
+	if year, err := strconv.Atoi(field("year")); err != nil {
+		fail("year %q is not a number", field("year"))
+	} else if year < MinYear || year > v.maxYear {
+		fail("year %d is outside %d-%d", year, MinYear, v.maxYear)
+	} else {
+		a.Year = year
+	}
+
+	if course, ok := v.courses[strings.ToLower(field("course"))]; ok {
+		a.Course = course
+	} else {
+		fail("course %q does not exist", field("course"))
+	}
+
+	if country := field("country"); country != "" {
+		if name, ok := v.countries[strings.ToLower(country)]; ok {
+			a.Country = name
+		} else {
+			fail("country %q does not exist", country)
+		}
+	}
+
+	if result.Status != StatusValid {
+		return a, result, nil
+	}
+
+	key := strings.ToLower(a.Email)
+	if first, ok := v.seen[key]; ok {
+		result.Status = StatusDuplicate
+		result.Errors = append(result.Errors, fmt.Sprintf("email already appears on row %d", first))
+		return a, result, nil
+	}
+	v.seen[key] = line
+
+	existing, err := v.store.FindAlumniByEmail(ctx, a.Email)
+	if err != nil {
+		return a, result, err
+	}
+	if existing != nil {
+		result.Status = StatusDuplicate
+		result.Errors = append(result.Errors, fmt.Sprintf("email is already registered to alumni %d", existing.ID))
+	}
+
+	return a, result, nil
+}
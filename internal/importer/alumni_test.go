@@ -0,0 +1,105 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"unorcitconnect/internal/database"
+)
+
+type fakeStore struct {
+	existing map[string]database.Alumni
+	created  []database.Alumni
+}
+
+func (f *fakeStore) GetAllCourses(ctx context.Context) ([]database.Course, error) {
+	return []database.Course{{Code: "BSIT", Name: "Bachelor of Science in Information Technology"}}, nil
+}
+
+func (f *fakeStore) GetAllCountries(ctx context.Context) ([]database.Country, error) {
+	return []database.Country{{Code: "PH", Name: "Philippines"}}, nil
+}
+
+func (f *fakeStore) FindAlumniByEmail(ctx context.Context, email string) (*database.Alumni, error) {
+	if a, ok := f.existing[email]; ok {
+		return &a, nil
+	}
+	return nil, nil
+}
+
+func (f *fakeStore) CreateAlumniBatch(ctx context.Context, alumni []database.Alumni) error {
+	f.created = append(f.created, alumni...)
+	return nil
+}
+
+const validCSV = `First Name,Last Name,Email,Year,Course,Country
+Ana,Cruz,ana@example.com,2010,BSIT,PH
+Ben,Reyes,ben@example.com,2012,Bachelor of Science in Information Technology,Philippines
+`
+
+func TestImportAlumniCSV(t *testing.T) {
+	store := &fakeStore{}
+	report, err := ImportAlumniCSV(context.Background(), store, strings.NewReader(validCSV), false)
+	if err != nil {
+		t.Fatalf("ImportAlumniCSV() returned error: %v", err)
+	}
+	if !report.OK() || report.Imported != 2 || len(store.created) != 2 {
+		t.Fatalf("expected 2 rows imported, got report %+v", report)
+	}
+	if got := store.created[0]; got.Course != "Bachelor of Science in Information Technology" || got.Country != "Philippines" {
+		t.Errorf("expected course and country codes to resolve to names, got %q and %q", got.Course, got.Country)
+	}
+}
+
+func TestImportAlumniCSVDryRun(t *testing.T) {
+	store := &fakeStore{}
+	report, err := ImportAlumniCSV(context.Background(), store, strings.NewReader(validCSV), true)
+	if err != nil {
+		t.Fatalf("ImportAlumniCSV() returned error: %v", err)
+	}
+	if report.Valid != 2 || report.Imported != 0 || len(store.created) != 0 {
+		t.Errorf("expected dry run to validate without importing, got report %+v", report)
+	}
+}
+
+func TestImportAlumniCSVRejectsWholeFile(t *testing.T) {
+	store := &fakeStore{existing: map[string]database.Alumni{"taken@example.com": {ID: 9}}}
+	input := `first_name,last_name,email,year,course
+Ana,Cruz,ana@example.com,2010,BSIT
+Ben,Reyes,not-an-email,1900,BSXX
+Cai,Lim,taken@example.com,2011,BSIT
+Dan,Tan,ANA@example.com,2011,BSIT
+`
+	report, err := ImportAlumniCSV(context.Background(), store, strings.NewReader(input), false)
+	if err != nil {
+		t.Fatalf("ImportAlumniCSV() returned error: %v", err)
+	}
+	if len(store.created) != 0 {
+		t.Fatalf("expected nothing to be imported, got %d rows", len(store.created))
+	}
+
+	want := []struct {
+		status string
+		errors int
+	}{
+		{StatusValid, 0},
+		{StatusInvalid, 3},
+		{StatusDuplicate, 1},
+		{StatusDuplicate, 1},
+	}
+	for i, w := range want {
+		got := report.Rows[i]
+		if got.Status != w.status || len(got.Errors) != w.errors {
+			t.Errorf("row %d: expected %s with %d errors, got %s %v", got.Row, w.status, w.errors, got.Status, got.Errors)
+		}
+	}
+}
+
+func TestImportAlumniCSVMissingColumn(t *testing.T) {
+	_, err := ImportAlumniCSV(context.Background(), &fakeStore{}, strings.NewReader("first_name,email\n"), true)
+	if !errors.Is(err, ErrMalformedFile) {
+		t.Errorf("expected ErrMalformedFile, got %v", err)
+	}
+}
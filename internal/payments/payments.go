@@ -0,0 +1,160 @@
+// Package payments takes registration fees online through a hosted checkout
+// page. Providers create checkout sessions and report their outcome through
+// signed webhooks.
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event types reported by ParseWebhook. Events a provider sends that are
+// not about a checkout's outcome are returned with an empty Type.
+const (
+	EventPaid    = "paid"
+	EventFailed  = "failed"
+	EventExpired = "expired"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrInvalidPayload   = errors.New("invalid webhook payload")
+)
+
+// signatureTolerance is how far a webhook's timestamp may be from now.
+const signatureTolerance = 5 * time.Minute
+
+// CheckoutRequest describes what a checkout session charges for. Amounts are
+// in the currency's minor unit, e.g. centavos.
+type CheckoutRequest struct {
+	Reference   string // our own reference, shown in the provider dashboard
+	Description string
+	Amount      int64
+	Currency    string
+	Email       string
+	Name        string
+	SuccessURL  string
+	CancelURL   string
+}
+
+// Session is a checkout session the payer is sent to.
+type Session struct {
+	ID  string
+	URL string
+}
+
+// Event is a verified webhook notification about a checkout session.
+type Event struct {
+	ID        string
+	Type      string
+	SessionID string
+	Amount    int64
+	Currency  string
+}
+
+// Provider is a payment gateway offering hosted checkout sessions.
+type Provider interface {
+	Name() string
+	CreateCheckout(ctx context.Context, req CheckoutRequest) (*Session, error)
+	// SignatureHeader names the request header carrying the webhook signature.
+	SignatureHeader() string
+	// ParseWebhook verifies signature against the raw payload and decodes it.
+	ParseWebhook(payload []byte, signature string) (*Event, error)
+}
+
+// Fee is what alumni are charged for registration.
+type Fee struct {
+	Amount      int64
+	Currency    string
+	Description string
+	SuccessURL  string // where the payer returns to; relative URLs are resolved by the caller
+	CancelURL   string
+}
+
+// NewFromEnv builds the Provider selected by PAYMENT_PROVIDER: "paymongo",
+// or "fake" for development and tests. It returns nil when online payment
+// is not configured.
+func NewFromEnv() (Provider, error) {
+	switch os.Getenv("PAYMENT_PROVIDER") {
+	case "":
+		return nil, nil
+	case "fake":
+		return NewFakeProvider(os.Getenv("PAYMENT_WEBHOOK_SECRET"))
+	case "paymongo":
+		return NewPayMongo(os.Getenv("PAYMONGO_SECRET_KEY"), os.Getenv("PAYMENT_WEBHOOK_SECRET"))
+	default:
+		return nil, fmt.Errorf("unknown PAYMENT_PROVIDER %q", os.Getenv("PAYMENT_PROVIDER"))
+	}
+}
+
+// FeeFromEnv reads the registration fee from REGISTRATION_FEE (in minor
+// units) and PAYMENT_CURRENCY, which defaults to PHP.
+func FeeFromEnv() (Fee, error) {
+	fee := Fee{
+		Currency:    strings.ToUpper(os.Getenv("PAYMENT_CURRENCY")),
+		Description: "UNOR CIT Homecoming registration",
+		SuccessURL:  os.Getenv("PAYMENT_SUCCESS_URL"),
+		CancelURL:   os.Getenv("PAYMENT_CANCEL_URL"),
+	}
+	if fee.Currency == "" {
+		fee.Currency = "PHP"
+	}
+	if fee.SuccessURL == "" {
+		fee.SuccessURL = "/?payment=success"
+	}
+	if fee.CancelURL == "" {
+		fee.CancelURL = "/?payment=cancelled"
+	}
+
+	amount, err := strconv.ParseInt(os.Getenv("REGISTRATION_FEE"), 10, 64)
+	if err != nil || amount <= 0 {
+		return fee, fmt.Errorf("REGISTRATION_FEE must be a positive amount in minor units")
+	}
+	fee.Amount = amount
+	return fee, nil
+}
+
+// signPayload returns the hex HMAC-SHA256 of "timestamp.payload", the scheme
+// both Stripe and PayMongo use for webhook signatures.
+func signPayload(secret []byte, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// parseSignatureHeader splits a "t=123,v1=abc" style header into its fields.
+func parseSignatureHeader(header string) map[string]string {
+	fields := make(map[string]string)
+	for _, part := range strings.Split(header, ",") {
+		if key, value, ok := strings.Cut(strings.TrimSpace(part), "="); ok {
+			fields[key] = value
+		}
+	}
+	return fields
+}
+
+// verifySignature checks a signature made by signPayload and that its
+// timestamp is recent, so captured webhooks cannot be replayed later.
+func verifySignature(secret []byte, timestamp, signature string, payload []byte, now time.Time) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || signature == "" {
+		return ErrInvalidSignature
+	}
+	if age := now.Sub(time.Unix(seconds, 0)); age > signatureTolerance || age < -signatureTolerance {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signPayload(secret, timestamp, payload)), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
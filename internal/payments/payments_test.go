@@ -0,0 +1,125 @@
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFakeProviderWebhook(t *testing.T) {
+	p, err := NewFakeProvider("whsec_test")
+	if err != nil {
+		t.Fatalf("NewFakeProvider() returned error: %v", err)
+	}
+
+	session, err := p.CreateCheckout(context.Background(), CheckoutRequest{
+		Amount:     150000,
+		Currency:   "PHP",
+		SuccessURL: "https://example.com/?payment=success",
+	})
+	if err != nil {
+		t.Fatalf("CreateCheckout() returned error: %v", err)
+	}
+	if !strings.Contains(session.URL, "session_id="+session.ID) {
+		t.Errorf("expected checkout URL to carry the session ID, got %s", session.URL)
+	}
+
+	payload, signature, err := p.Event(EventPaid, session.ID, 150000, "PHP")
+	if err != nil {
+		t.Fatalf("Event() returned error: %v", err)
+	}
+
+	event, err := p.ParseWebhook(payload, signature)
+	if err != nil {
+		t.Fatalf("ParseWebhook() returned error: %v", err)
+	}
+	if event.Type != EventPaid || event.SessionID != session.ID || event.Amount != 150000 {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	tampered := []byte(strings.Replace(string(payload), "150000", "1", 1))
+	if _, err := p.ParseWebhook(tampered, signature); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected tampered payload to be rejected, got %v", err)
+	}
+
+	other, _ := NewFakeProvider("whsec_other")
+	if _, err := other.ParseWebhook(payload, signature); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected signature from another secret to be rejected, got %v", err)
+	}
+
+	p.now = func() time.Time { return time.Now().Add(time.Hour) }
+	if _, err := p.ParseWebhook(payload, signature); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected stale signature to be rejected, got %v", err)
+	}
+}
+
+func TestPayMongoCreateCheckout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, _, _ := r.BasicAuth(); user != "sk_test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var body struct {
+			Data struct {
+				Attributes payMongoCheckoutAttributes `json:"attributes"`
+			} `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.Data.Attributes.LineItems) != 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if item := body.Data.Attributes.LineItems[0]; item.Amount != 150000 || item.Currency != "PHP" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"data":{"id":"cs_123","attributes":{"checkout_url":"https://checkout.paymongo.com/cs_123"}}}`))
+	}))
+	defer server.Close()
+
+	p, _ := NewPayMongo("sk_test", "whsk_test")
+	p.baseURL = server.URL
+
+	session, err := p.CreateCheckout(context.Background(), CheckoutRequest{
+		Reference:   "alumni-1",
+		Description: "Registration",
+		Amount:      150000,
+		Currency:    "PHP",
+		SuccessURL:  "https://example.com/ok",
+		CancelURL:   "https://example.com/cancel",
+	})
+	if err != nil {
+		t.Fatalf("CreateCheckout() returned error: %v", err)
+	}
+	if session.ID != "cs_123" || session.URL != "https://checkout.paymongo.com/cs_123" {
+		t.Errorf("unexpected session %+v", session)
+	}
+}
+
+func TestPayMongoParseWebhook(t *testing.T) {
+	p, _ := NewPayMongo("sk_test", "whsk_test")
+	payload := []byte(`{"data":{"id":"evt_1","attributes":{"type":"checkout_session.payment.paid","livemode":false,
+		"data":{"id":"cs_123","attributes":{"payments":[{"attributes":{"amount":150000,"currency":"PHP"}}]}}}}}`)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	signature := signPayload([]byte("whsk_test"), timestamp, payload)
+
+	event, err := p.ParseWebhook(payload, "t="+timestamp+",te="+signature+",li=")
+	if err != nil {
+		t.Fatalf("ParseWebhook() returned error: %v", err)
+	}
+	if event.ID != "evt_1" || event.Type != EventPaid || event.SessionID != "cs_123" || event.Amount != 150000 || event.Currency != "PHP" {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	// A test mode signature must not be accepted for a live event
+	live := []byte(strings.Replace(string(payload), `"livemode":false`, `"livemode":true`, 1))
+	liveSignature := signPayload([]byte("whsk_test"), timestamp, live)
+	if _, err := p.ParseWebhook(live, "t="+timestamp+",te="+liveSignature+",li="); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected live event with only a test signature to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+package payments
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const payMongoAPI = "https://api.paymongo.com/v1"
+
+// PayMongo creates checkout sessions through the PayMongo API, which accepts
+// cards, GCash, Maya and GrabPay.
+type PayMongo struct {
+	secretKey     string
+	webhookSecret []byte
+	baseURL       string
+	client        *http.Client
+	now           func() time.Time
+}
+
+func NewPayMongo(secretKey, webhookSecret string) (*PayMongo, error) {
+	if secretKey == "" || webhookSecret == "" {
+		return nil, fmt.Errorf("PayMongo needs PAYMONGO_SECRET_KEY and PAYMENT_WEBHOOK_SECRET")
+	}
+	return &PayMongo{
+		secretKey:     secretKey,
+		webhookSecret: []byte(webhookSecret),
+		baseURL:       payMongoAPI,
+		client:        &http.Client{Timeout: 30 * time.Second},
+		now:           time.Now,
+	}, nil
+}
+
+func (p *PayMongo) Name() string {
+	return "paymongo"
+}
+
+type payMongoLineItem struct {
+	Name     string `json:"name"`
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+	Quantity int    `json:"quantity"`
+}
+
+type payMongoCheckoutAttributes struct {
+	LineItems          []payMongoLineItem `json:"line_items"`
+	PaymentMethodTypes []string           `json:"payment_method_types"`
+	Description        string             `json:"description,omitempty"`
+	ReferenceNumber    string             `json:"reference_number,omitempty"`
+	SuccessURL         string             `json:"success_url"`
+	CancelURL          string             `json:"cancel_url"`
+	Billing            *payMongoBilling   `json:"billing,omitempty"`
+}
+
+type payMongoBilling struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+func (p *PayMongo) CreateCheckout(ctx context.Context, req CheckoutRequest) (*Session, error) {
+	var body struct {
+		Data struct {
+			Attributes payMongoCheckoutAttributes `json:"attributes"`
+		} `json:"data"`
+	}
+	body.Data.Attributes = payMongoCheckoutAttributes{
+		LineItems: []payMongoLineItem{{
+			Name:     req.Description,
+			Amount:   req.Amount,
+			Currency: req.Currency,
+			Quantity: 1,
+		}},
+		PaymentMethodTypes: []string{"card", "gcash", "paymaya", "grab_pay"},
+		Description:        req.Description,
+		ReferenceNumber:    req.Reference,
+		SuccessURL:         req.SuccessURL,
+		CancelURL:          req.CancelURL,
+	}
+	if req.Email != "" || req.Name != "" {
+		body.Data.Attributes.Billing = &payMongoBilling{Name: req.Name, Email: req.Email}
+	}
+
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/checkout_sessions", bytes.NewReader(encoded))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.SetBasicAuth(p.secretKey, "")
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create checkout session: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("failed to create checkout session: %s: %s", resp.Status, bytes.TrimSpace(message))
+	}
+
+	var session struct {
+		Data struct {
+			ID         string `json:"id"`
+			Attributes struct {
+				CheckoutURL string `json:"checkout_url"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+		return nil, fmt.Errorf("failed to decode checkout session: %w", err)
+	}
+	if session.Data.ID == "" || session.Data.Attributes.CheckoutURL == "" {
+		return nil, fmt.Errorf("checkout session response is missing its ID or URL")
+	}
+
+	return &Session{ID: session.Data.ID, URL: session.Data.Attributes.CheckoutURL}, nil
+}
+
+func (p *PayMongo) SignatureHeader() string {
+	return "Paymongo-Signature"
+}
+
+// payMongoEvent is the subset of a PayMongo webhook event that is used.
+type payMongoEvent struct {
+	Data struct {
+		ID         string `json:"id"`
+		Attributes struct {
+			Type     string `json:"type"`
+			Livemode bool   `json:"livemode"`
+			Data     struct {
+				ID         string `json:"id"`
+				Attributes struct {
+					Payments []struct {
+						Attributes struct {
+							Amount   int64  `json:"amount"`
+							Currency string `json:"currency"`
+						} `json:"attributes"`
+					} `json:"payments"`
+				} `json:"attributes"`
+			} `json:"data"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+// ParseWebhook verifies a "t=...,te=...,li=..." signature, using the test or
+// live signature according to the event's livemode.
+func (p *PayMongo) ParseWebhook(payload []byte, signature string) (*Event, error) {
+	var raw payMongoEvent
+	if err := json.Unmarshal(payload, &raw); err != nil || raw.Data.ID == "" {
+		return nil, ErrInvalidPayload
+	}
+
+	fields := parseSignatureHeader(signature)
+	expected := fields["te"]
+	if raw.Data.Attributes.Livemode {
+		expected = fields["li"]
+	}
+	if err := verifySignature(p.webhookSecret, fields["t"], expected, payload, p.now()); err != nil {
+		return nil, err
+	}
+
+	event := &Event{ID: raw.Data.ID, SessionID: raw.Data.Attributes.Data.ID}
+	if raw.Data.Attributes.Type == "checkout_session.payment.paid" {
+		event.Type = EventPaid
+		for _, payment := range raw.Data.Attributes.Data.Attributes.Payments {
+			event.Amount += payment.Attributes.Amount
+			event.Currency = payment.Attributes.Currency
+		}
+	}
+	return event, nil
+}
@@ -0,0 +1,51 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryCounter struct {
+	windowStart time.Time
+	expiresAt   time.Time
+	count       int64
+}
+
+// MemoryStore keeps counters in process memory. Limits reset on restart and
+// are not shared between replicas.
+type MemoryStore struct {
+	mu       sync.Mutex
+	counters map[string]*memoryCounter
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		counters: make(map[string]*memoryCounter),
+	}
+}
+
+func (m *MemoryStore) Increment(_ context.Context, key string, windowStart, expiresAt time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.prune(windowStart)
+
+	counter, ok := m.counters[key]
+	if !ok || !counter.windowStart.Equal(windowStart) {
+		counter = &memoryCounter{windowStart: windowStart, expiresAt: expiresAt}
+		m.counters[key] = counter
+	}
+	counter.count++
+
+	return counter.count, nil
+}
+
+// prune drops counters whose window ended before the window being hit.
+func (m *MemoryStore) prune(before time.Time) {
+	for key, counter := range m.counters {
+		if !counter.expiresAt.After(before) {
+			delete(m.counters, key)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+	"unorcitconnect/internal/database"
+)
+
+// PostgresStore keeps counters in the rate_limits table so limits survive
+// restarts and are shared by every replica.
+type PostgresStore struct {
+	db database.RateLimitService
+}
+
+func NewPostgresStore(db database.RateLimitService) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (p *PostgresStore) Increment(ctx context.Context, key string, windowStart, expiresAt time.Time) (int64, error) {
+	return p.db.IncrementRateLimit(ctx, key, windowStart, expiresAt)
+}
@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Rule allows at most Limit hits per key within each fixed Window.
+type Rule struct {
+	Name   string
+	Limit  int64
+	Window time.Duration
+}
+
+// Result reports the outcome of a hit against a Rule.
+type Result struct {
+	Allowed    bool
+	Remaining  int64
+	RetryAfter time.Duration
+}
+
+// Store keeps hit counters. Increment adds one hit for key in the window
+// starting at windowStart and returns the new count. Counters may be dropped
+// once expiresAt has passed.
+type Store interface {
+	Increment(ctx context.Context, key string, windowStart, expiresAt time.Time) (int64, error)
+}
+
+// Limiter applies fixed-window rules on top of a Store.
+type Limiter struct {
+	store Store
+	now   func() time.Time
+}
+
+func New(store Store) *Limiter {
+	return &Limiter{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// Allow records a hit for key under rule.
+func (l *Limiter) Allow(ctx context.Context, rule Rule, key string) (Result, error) {
+	now := l.now()
+	windowStart := now.Truncate(rule.Window)
+	windowEnd := windowStart.Add(rule.Window)
+
+	count, err := l.store.Increment(ctx, rule.Name+":"+key, windowStart, windowEnd)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to check rate limit %s: %w", rule.Name, err)
+	}
+
+	if count > rule.Limit {
+		return Result{Allowed: false, RetryAfter: windowEnd.Sub(now)}, nil
+	}
+
+	return Result{Allowed: true, Remaining: rule.Limit - count}, nil
+}
+
+// Check pairs a rule with the key it applies to.
+type Check struct {
+	Rule Rule
+	Key  string
+}
+
+// AllowAll applies checks in order and stops at the first one that denies
+// the hit.
+func (l *Limiter) AllowAll(ctx context.Context, checks ...Check) (Result, error) {
+	for _, check := range checks {
+		result, err := l.Allow(ctx, check.Rule, check.Key)
+		if err != nil {
+			return Result{}, err
+		}
+		if !result.Allowed {
+			return result, nil
+		}
+	}
+	return Result{Allowed: true}, nil
+}
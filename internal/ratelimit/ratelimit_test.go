@@ -0,0 +1,55 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAllowBlocksAfterLimit(t *testing.T) {
+	l := New(NewMemoryStore())
+	rule := Rule{Name: "test", Limit: 2, Window: time.Minute}
+
+	for i := 0; i < 2; i++ {
+		result, err := l.Allow(context.Background(), rule, "a@example.com")
+		if err != nil {
+			t.Fatalf("Allow() returned error: %v", err)
+		}
+		if !result.Allowed {
+			t.Fatalf("hit %d: expected to be allowed", i+1)
+		}
+	}
+
+	result, err := l.Allow(context.Background(), rule, "a@example.com")
+	if err != nil {
+		t.Fatalf("Allow() returned error: %v", err)
+	}
+	if result.Allowed {
+		t.Fatalf("expected third hit to be denied")
+	}
+	if result.RetryAfter <= 0 || result.RetryAfter > time.Minute {
+		t.Fatalf("expected retry after within the window, got %v", result.RetryAfter)
+	}
+
+	other, _ := l.Allow(context.Background(), rule, "b@example.com")
+	if !other.Allowed {
+		t.Fatalf("expected a different key to be allowed")
+	}
+}
+
+func TestAllowResetsInNextWindow(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	l := New(NewMemoryStore())
+	l.now = func() time.Time { return now }
+	rule := Rule{Name: "test", Limit: 1, Window: time.Minute}
+
+	l.Allow(context.Background(), rule, "key")
+	if result, _ := l.Allow(context.Background(), rule, "key"); result.Allowed {
+		t.Fatalf("expected second hit in the same window to be denied")
+	}
+
+	now = now.Add(time.Minute)
+	if result, _ := l.Allow(context.Background(), rule, "key"); !result.Allowed {
+		t.Fatalf("expected hit in the next window to be allowed")
+	}
+}
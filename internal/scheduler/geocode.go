@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"unorcitconnect/internal/database"
+	"unorcitconnect/internal/geocode"
+)
+
+// locationStore is the part of database.Service the geocode backfill uses.
+type locationStore interface {
+	GetAllCountries(ctx context.Context) ([]database.Country, error)
+	GetAlumniMissingLocation(ctx context.Context) ([]database.Alumni, error)
+	GetGeocodeCache(ctx context.Context, countryCode, city string) (*database.GeocodeCache, error)
+	SaveGeocodeCache(ctx context.Context, entry *database.GeocodeCache) error
+	SetAlumniLocation(ctx context.Context, id int, country, city string, latitude, longitude float64) error
+}
+
+// geocodeBackfill fills in coordinates for alumni who gave only a city and
+// country. Answers, including misses, are cached, so later runs only ask
+// the geocoder about cities it has not seen.
+func geocodeBackfill(store locationStore, geocoder geocode.Provider) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		alumni, err := store.GetAlumniMissingLocation(ctx)
+		if err != nil || len(alumni) == 0 {
+			return err
+		}
+
+		// Alumni store the country's name; the geocoder wants its code
+		countries, err := store.GetAllCountries(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load countries: %w", err)
+		}
+		codes := make(map[string]string, len(countries))
+		for _, country := range countries {
+			codes[strings.ToLower(country.Name)] = country.Code
+			codes[strings.ToLower(country.Code)] = country.Code
+		}
+
+		located := 0
+		for _, a := range alumni {
+			code, ok := codes[strings.ToLower(strings.TrimSpace(a.Country))]
+			if !ok {
+				continue
+			}
+
+			entry, err := lookupCity(ctx, store, geocoder, code, a.City)
+			if err != nil {
+				return err
+			}
+			if !entry.Found {
+				continue
+			}
+
+			if err := store.SetAlumniLocation(ctx, a.ID, a.Country, a.City, entry.Latitude, entry.Longitude); err != nil {
+				return err
+			}
+			located++
+		}
+
+		if located > 0 {
+			log.Printf("geocoded %d of %d alumni without a location", located, len(alumni))
+		}
+		return nil
+	}
+}
+
+// lookupCity answers from the cache, asking the geocoder on a miss.
+func lookupCity(ctx context.Context, store locationStore, geocoder geocode.Provider, countryCode, city string) (*database.GeocodeCache, error) {
+	city = geocode.NormalizeCity(city)
+
+	entry, err := store.GetGeocodeCache(ctx, countryCode, city)
+	if err != nil || entry != nil {
+		return entry, err
+	}
+
+	entry = &database.GeocodeCache{CountryCode: countryCode, City: city, Provider: geocoder.Name()}
+	location, err := geocoder.Geocode(ctx, city, countryCode)
+	switch {
+	case err == nil:
+		entry.Found = true
+		entry.Latitude, entry.Longitude = location.Latitude, location.Longitude
+	case !errors.Is(err, geocode.ErrNotFound):
+		return nil, fmt.Errorf("failed to geocode %s, %s: %w", city, countryCode, err)
+	}
+
+	if err := store.SaveGeocodeCache(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
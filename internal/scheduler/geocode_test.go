@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"unorcitconnect/internal/database"
+	"unorcitconnect/internal/geocode"
+)
+
+type fakeLocationStore struct {
+	alumni    []database.Alumni
+	cache     map[string]database.GeocodeCache
+	locations map[int]geocode.Location
+}
+
+func (f *fakeLocationStore) GetAllCountries(context.Context) ([]database.Country, error) {
+	return []database.Country{{Name: "Philippines", Code: "PH"}, {Name: "Japan", Code: "JP"}}, nil
+}
+
+func (f *fakeLocationStore) GetAlumniMissingLocation(context.Context) ([]database.Alumni, error) {
+	return f.alumni, nil
+}
+
+func (f *fakeLocationStore) GetGeocodeCache(_ context.Context, countryCode, city string) (*database.GeocodeCache, error) {
+	entry, ok := f.cache[countryCode+"/"+city]
+	if !ok {
+		return nil, nil
+	}
+	return &entry, nil
+}
+
+func (f *fakeLocationStore) SaveGeocodeCache(_ context.Context, entry *database.GeocodeCache) error {
+	f.cache[entry.CountryCode+"/"+entry.City] = *entry
+	return nil
+}
+
+func (f *fakeLocationStore) SetAlumniLocation(_ context.Context, id int, _, _ string, latitude, longitude float64) error {
+	f.locations[id] = geocode.Location{Latitude: latitude, Longitude: longitude}
+	return nil
+}
+
+type countingGeocoder struct {
+	geocode.Provider
+	calls int
+}
+
+func (c *countingGeocoder) Geocode(ctx context.Context, city, countryCode string) (*geocode.Location, error) {
+	c.calls++
+	return c.Provider.Geocode(ctx, city, countryCode)
+}
+
+func TestGeocodeBackfill(t *testing.T) {
+	offline, err := geocode.LoadOffline(strings.NewReader("country_code,city,latitude,longitude\nPH,Bacolod,10.6765,122.9509\n"))
+	if err != nil {
+		t.Fatalf("LoadOffline() returned error: %v", err)
+	}
+	geocoder := &countingGeocoder{Provider: offline}
+
+	store := &fakeLocationStore{
+		alumni: []database.Alumni{
+			{ID: 1, City: "Bacolod City", Country: "Philippines"},
+			{ID: 2, City: "bacolod", Country: "PH"},
+			{ID: 3, City: "Atlantis", Country: "Philippines"},
+			{ID: 4, City: "Bacolod", Country: "Narnia"},
+		},
+		cache:     make(map[string]database.GeocodeCache),
+		locations: make(map[int]geocode.Location),
+	}
+
+	run := geocodeBackfill(store, geocoder)
+	if err := run(context.Background()); err != nil {
+		t.Fatalf("backfill returned error: %v", err)
+	}
+
+	if len(store.locations) != 2 || store.locations[1].Latitude != 10.6765 || store.locations[2].Latitude != 10.6765 {
+		t.Errorf("expected alumni 1 and 2 to be located, got %+v", store.locations)
+	}
+	if entry, ok := store.cache["PH/atlantis"]; !ok || entry.Found {
+		t.Errorf("expected the miss to be cached, got %+v", store.cache)
+	}
+	if geocoder.calls != 2 {
+		t.Errorf("expected 2 geocoder lookups, got %d", geocoder.calls)
+	}
+
+	// A second run is answered from the cache
+	if err := run(context.Background()); err != nil {
+		t.Fatalf("backfill returned error: %v", err)
+	}
+	if geocoder.calls != 2 {
+		t.Errorf("expected cached answers on the second run, got %d lookups", geocoder.calls)
+	}
+}
@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"log"
+	"os"
+	"time"
+	"unorcitconnect/internal/database"
+	"unorcitconnect/internal/geocode"
+)
+
+// MaintenanceJobs returns the built-in database maintenance jobs. Intervals
+// can be overridden with Go duration strings, e.g. OTP_CLEANUP_INTERVAL=5m.
+// The geocode backfill is left out when geocoder is nil.
+func MaintenanceJobs(db database.Service, geocoder geocode.Provider) []Job {
+	jobs := []Job{
+		{
+			Name:     "otp_cleanup",
+			Interval: envDuration("OTP_CLEANUP_INTERVAL", 15*time.Minute),
+			Run:      db.CleanupExpiredOTPs,
+		},
+		{
+			Name:     "rate_limit_cleanup",
+			Interval: envDuration("RATE_LIMIT_CLEANUP_INTERVAL", time.Hour),
+			Run:      db.CleanupExpiredRateLimits,
+		},
+	}
+
+	if geocoder != nil {
+		jobs = append(jobs, Job{
+			Name:     "geocode_backfill",
+			Interval: envDuration("GEOCODE_BACKFILL_INTERVAL", time.Hour),
+			Run:      geocodeBackfill(db, geocoder),
+		})
+	}
+	return jobs
+}
+
+func envDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("Warning: invalid %s %q, using %s", key, value, fallback)
+		return fallback
+	}
+	return d
+}
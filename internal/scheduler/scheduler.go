@@ -0,0 +1,109 @@
+package scheduler
+
+import (
+	"context"
+	"hash/fnv"
+	"log"
+	"sync"
+	"time"
+)
+
+// Job is a periodic maintenance task.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Locker runs fn only if the named lock could be taken, so that a job runs on
+// a single replica at a time. It reports whether fn was run.
+type Locker interface {
+	WithAdvisoryLock(ctx context.Context, key int64, fn func(ctx context.Context) error) (bool, error)
+}
+
+type Scheduler struct {
+	locker Locker
+	jobs   []Job
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New(locker Locker) *Scheduler {
+	return &Scheduler{locker: locker}
+}
+
+func (s *Scheduler) Register(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// Start runs every registered job once and then on its interval until Stop
+// is called.
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+}
+
+// Stop cancels all jobs and waits for running ones to return, or for ctx to
+// expire.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, job Job) {
+	ran, err := s.locker.WithAdvisoryLock(ctx, lockKey(job.Name), job.Run)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("scheduler: job %s failed: %v", job.Name, err)
+		}
+		return
+	}
+	if !ran {
+		log.Printf("scheduler: job %s skipped, another replica holds the lock", job.Name)
+	}
+}
+
+// lockKey maps a job name to a stable advisory lock key.
+func lockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("scheduler:" + name))
+	return int64(h.Sum64())
+}
@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeLocker struct {
+	available bool
+}
+
+func (f *fakeLocker) WithAdvisoryLock(ctx context.Context, _ int64, fn func(ctx context.Context) error) (bool, error) {
+	if !f.available {
+		return false, nil
+	}
+	return true, fn(ctx)
+}
+
+func TestSchedulerRunsJobUntilStopped(t *testing.T) {
+	var runs atomic.Int32
+	s := New(&fakeLocker{available: true})
+	s.Register(Job{
+		Name:     "count",
+		Interval: 10 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			runs.Add(1)
+			return nil
+		},
+	})
+
+	s.Start()
+	time.Sleep(35 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := s.Stop(ctx); err != nil {
+		t.Fatalf("Stop() returned error: %v", err)
+	}
+
+	if runs.Load() < 2 {
+		t.Fatalf("expected job to run at least twice, ran %d times", runs.Load())
+	}
+
+	stopped := runs.Load()
+	time.Sleep(25 * time.Millisecond)
+	if runs.Load() != stopped {
+		t.Fatalf("expected job not to run after Stop()")
+	}
+}
+
+func TestSchedulerSkipsJobWhenLockHeld(t *testing.T) {
+	var runs atomic.Int32
+	s := New(&fakeLocker{available: false})
+	s.Register(Job{
+		Name:     "locked",
+		Interval: time.Hour,
+		Run: func(ctx context.Context) error {
+			runs.Add(1)
+			return nil
+		},
+	})
+
+	s.Start()
+	time.Sleep(10 * time.Millisecond)
+	s.Stop(context.Background())
+
+	if runs.Load() != 0 {
+		t.Fatalf("expected job to be skipped, ran %d times", runs.Load())
+	}
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"errors"
+	"strconv"
+	"unorcitconnect/internal/database"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Admin User Management Handlers
+type ResetAdminPasswordRequest struct {
+	Password string `json:"password"`
+}
+
+type ChangeOwnPasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// validateAdminPassword returns a user-facing message when password is too weak.
+func validateAdminPassword(password string) string {
+	if len(password) < 8 {
+		return "Password must be at least 8 characters long"
+	}
+	return ""
+}
+
+func adminResponse(admin database.Admin) fiber.Map {
+	roles := make([]string, 0, len(admin.Roles))
+	for _, role := range admin.Roles {
+		roles = append(roles, role.Name)
+	}
+
+	return fiber.Map{
+		"id":                   admin.ID,
+		"username":             admin.Username,
+		"disabled":             admin.Disabled,
+		"must_change_password": admin.MustChangePassword,
+		"roles":                roles,
+		"created_at":           admin.CreatedAt,
+	}
+}
+
+func (s *FiberServer) listAdminsHandler(c *fiber.Ctx) error {
+	admins, err := s.db.ListAdmins(c.Context())
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch admins"})
+	}
+
+	result := make([]fiber.Map, 0, len(admins))
+	for _, admin := range admins {
+		result = append(result, adminResponse(admin))
+	}
+
+	return c.JSON(fiber.Map{"admins": result})
+}
+
+func (s *FiberServer) disableAdminHandler(c *fiber.Ctx) error {
+	return s.setAdminDisabled(c, true)
+}
+
+func (s *FiberServer) enableAdminHandler(c *fiber.Ctx) error {
+	return s.setAdminDisabled(c, false)
+}
+
+func (s *FiberServer) setAdminDisabled(c *fiber.Ctx, disabled bool) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid admin ID"})
+	}
+
+	if disabled && id == currentAdmin(c).ID {
+		return c.Status(400).JSON(fiber.Map{"error": "You cannot disable your own account"})
+	}
+
+	if err := s.db.SetAdminDisabled(c.Context(), id, disabled); err != nil {
+		if errors.Is(err, database.ErrLastAdminManager) {
+			return c.Status(409).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(404).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	message := "Admin enabled successfully"
+	if disabled {
+		message = "Admin disabled successfully"
+	}
+	return c.JSON(fiber.Map{"message": message})
+}
+
+func (s *FiberServer) resetAdminPasswordHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid admin ID"})
+	}
+
+	var req ResetAdminPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if msg := validateAdminPassword(req.Password); msg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": msg})
+	}
+
+	if err := s.db.ResetAdminPassword(c.Context(), id, req.Password); err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{"message": "Password reset successfully"})
+}
+
+func (s *FiberServer) changeOwnPasswordHandler(c *fiber.Ctx) error {
+	var req ChangeOwnPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if req.CurrentPassword == "" || req.NewPassword == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "Current and new password are required"})
+	}
+
+	if msg := validateAdminPassword(req.NewPassword); msg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": msg})
+	}
+
+	err := s.db.ChangeAdminPassword(c.Context(), currentAdmin(c).ID, req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		if errors.Is(err, database.ErrInvalidPassword) {
+			return c.Status(400).JSON(fiber.Map{"error": "Current password is incorrect"})
+		}
+		if errors.Is(err, database.ErrSamePasswordReused) {
+			return c.Status(400).JSON(fiber.Map{"error": "New password must differ from the current password"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to change password"})
+	}
+
+	return c.JSON(fiber.Map{"message": "Password changed successfully"})
+}
+
+func (s *FiberServer) deleteAdminHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid admin ID"})
+	}
+
+	if id == currentAdmin(c).ID {
+		return c.Status(400).JSON(fiber.Map{"error": "You cannot delete your own account"})
+	}
+
+	if err := s.db.DeleteAdmin(c.Context(), id); err != nil {
+		if errors.Is(err, database.ErrLastAdminManager) {
+			return c.Status(409).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(404).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{"message": "Admin deleted successfully"})
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"math"
+
+	"unorcitconnect/internal/database"
+)
+
+// publicAlumniSortFields are the sort fields accepted on the public alumni
+// list. Sorting on a hidden field would leak its order, so country and city,
+// which map precision can hide, are left out.
+var publicAlumniSortFields = map[string]bool{
+	"id":         true,
+	"first_name": true,
+	"last_name":  true,
+	"year":       true,
+	"course":     true,
+	"created_at": true,
+}
+
+// PublicAlumni is the directory entry anyone may see. It keeps the field
+// names of database.Alumni so clients read both the same way; fields the
+// alumnus has hidden are left out.
+type PublicAlumni struct {
+	ID        int
+	FirstName string
+	LastName  string
+	Email     string `json:",omitempty"`
+	Phone     string `json:",omitempty"`
+	Year      int
+	Course    string
+	Company   string  `json:",omitempty"`
+	Position  string  `json:",omitempty"`
+	Country   string  `json:",omitempty"`
+	City      string  `json:",omitempty"`
+	Latitude  float64 `json:",omitempty"`
+	Longitude float64 `json:",omitempty"`
+}
+
+// publicAlumni applies the alumnus's privacy settings.
+func publicAlumni(a *database.Alumni) PublicAlumni {
+	p := PublicAlumni{
+		ID:        a.ID,
+		FirstName: a.FirstName,
+		LastName:  a.LastName,
+		Year:      a.Year,
+		Course:    a.Course,
+	}
+	if a.ShowEmail {
+		p.Email = a.Email
+	}
+	if a.ShowPhone {
+		p.Phone = a.Phone
+	}
+	if a.ShowEmployer {
+		p.Company = a.Company
+		p.Position = a.Position
+	}
+
+	switch a.MapPrecision {
+	case database.MapPrecisionExact:
+		p.Country, p.City = a.Country, a.City
+		p.Latitude, p.Longitude = a.Latitude, a.Longitude
+	case database.MapPrecisionCountry:
+		p.Country = a.Country
+	case database.MapPrecisionHidden:
+	default:
+		// City is the default, so an unset precision is treated as one
+		p.Country, p.City = a.Country, a.City
+		p.Latitude = roundTo(a.Latitude, database.CityPrecisionDecimals)
+		p.Longitude = roundTo(a.Longitude, database.CityPrecisionDecimals)
+	}
+	return p
+}
+
+func publicAlumniList(alumni []database.Alumni) []PublicAlumni {
+	list := make([]PublicAlumni, 0, len(alumni))
+	for i := range alumni {
+		list = append(list, publicAlumni(&alumni[i]))
+	}
+	return list
+}
+
+func roundTo(value float64, decimals int) float64 {
+	scale := math.Pow(10, float64(decimals))
+	return math.Round(value*scale) / scale
+}
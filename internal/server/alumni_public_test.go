@@ -0,0 +1,49 @@
+package server
+
+import (
+	"testing"
+
+	"unorcitconnect/internal/database"
+)
+
+func TestPublicAlumni(t *testing.T) {
+	alumni := database.Alumni{
+		ID:        3,
+		FirstName: "ANA",
+		Email:     "ana@example.com",
+		Phone:     "+63 912 345 6789",
+		Company:   "Acme",
+		Country:   "Philippines",
+		City:      "Bacolod",
+		Latitude:  10.67654,
+		Longitude: 122.95412,
+	}
+
+	tests := []struct {
+		name   string
+		modify func(a *database.Alumni)
+		want   PublicAlumni
+	}{
+		{"defaults", func(a *database.Alumni) {
+			a.ShowEmployer = true
+			a.MapPrecision = database.MapPrecisionCity
+		}, PublicAlumni{ID: 3, FirstName: "ANA", Company: "Acme", Country: "Philippines", City: "Bacolod", Latitude: 10.7, Longitude: 123}},
+		{"everything shown", func(a *database.Alumni) {
+			a.ShowEmail, a.ShowPhone = true, true
+			a.MapPrecision = database.MapPrecisionExact
+		}, PublicAlumni{ID: 3, FirstName: "ANA", Email: "ana@example.com", Phone: "+63 912 345 6789", Country: "Philippines", City: "Bacolod", Latitude: 10.67654, Longitude: 122.95412}},
+		{"country", func(a *database.Alumni) {
+			a.MapPrecision = database.MapPrecisionCountry
+		}, PublicAlumni{ID: 3, FirstName: "ANA", Country: "Philippines"}},
+		{"hidden", func(a *database.Alumni) {
+			a.MapPrecision = database.MapPrecisionHidden
+		}, PublicAlumni{ID: 3, FirstName: "ANA"}},
+	}
+	for _, tt := range tests {
+		a := alumni
+		tt.modify(&a)
+		if got := publicAlumni(&a); got != tt.want {
+			t.Errorf("%s: expected %+v; got %+v", tt.name, tt.want, got)
+		}
+	}
+}
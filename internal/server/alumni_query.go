@@ -0,0 +1,90 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"unorcitconnect/internal/database"
+)
+
+// parseAlumniQuery reads the alumni list filters from the query string:
+// page, page_size, year, course, country, city, paid, payment_status,
+// is_verified, q (free text), sort (a field name) and order ("asc" or "desc").
+func parseAlumniQuery(c *fiber.Ctx) (database.AlumniQuery, error) {
+	q := database.AlumniQuery{
+		Course:        strings.TrimSpace(c.Query("course")),
+		Country:       strings.TrimSpace(c.Query("country")),
+		City:          strings.TrimSpace(c.Query("city")),
+		PaymentStatus: c.Query("payment_status"),
+		Search:        strings.TrimSpace(c.Query("q")),
+		SortBy:        c.Query("sort"),
+	}
+
+	if q.SortBy != "" && !database.IsAlumniSortField(q.SortBy) {
+		return q, fmt.Errorf("invalid sort field %q", q.SortBy)
+	}
+
+	var err error
+	if q.Page, err = queryInt(c, "page", 1); err != nil {
+		return q, err
+	}
+	if q.PageSize, err = queryInt(c, "page_size", 10); err != nil {
+		return q, err
+	}
+	if q.Year, err = queryInt(c, "year", 0); err != nil {
+		return q, err
+	}
+	if q.Paid, err = queryBool(c, "paid"); err != nil {
+		return q, err
+	}
+	if q.IsVerified, err = queryBool(c, "is_verified"); err != nil {
+		return q, err
+	}
+
+	switch strings.ToLower(c.Query("order", "asc")) {
+	case "asc":
+	case "desc":
+		q.SortDesc = true
+	default:
+		return q, fmt.Errorf("order must be asc or desc")
+	}
+
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.PageSize < 1 {
+		q.PageSize = 10
+	}
+	if q.PageSize > database.MaxAlumniPageSize {
+		q.PageSize = database.MaxAlumniPageSize
+	}
+
+	return q, nil
+}
+
+func queryInt(c *fiber.Ctx, key string, fallback int) (int, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return fallback, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a number", key)
+	}
+	return v, nil
+}
+
+func queryBool(c *fiber.Ctx, key string) (*bool, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be true or false", key)
+	}
+	return &v, nil
+}
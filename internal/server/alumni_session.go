@@ -0,0 +1,289 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"unorcitconnect/internal/auth"
+	"unorcitconnect/internal/database"
+)
+
+const (
+	alumniAccessToken = "alumni_access"
+	alumniAccessTTL   = 24 * time.Hour
+	alumniLocalsKey   = "alumni"
+)
+
+type LoginLinkRequest struct {
+	Email string `json:"email"`
+}
+
+type VerifyLoginLinkRequest struct {
+	Email string `json:"email"`
+	Token string `json:"token"`
+}
+
+// UpdateProfileRequest holds the fields alumni may change themselves. The
+// email is their identity, payment fields go through their own routes and
+// coordinates come from geocoding the city.
+type UpdateProfileRequest struct {
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	Phone     string `json:"phone"`
+	Year      int    `json:"year"`
+	Course    string `json:"course"`
+	Company   string `json:"company"`
+	Position  string `json:"position"`
+	Country   string `json:"country"`
+	City      string `json:"city"`
+}
+
+// UpdatePrivacyRequest sets what the public alumni list and map show.
+type UpdatePrivacyRequest struct {
+	ShowEmail    bool   `json:"showEmail"`
+	ShowPhone    bool   `json:"showPhone"`
+	ShowEmployer bool   `json:"showEmployer"`
+	MapPrecision string `json:"mapPrecision"`
+}
+
+// loginLinkURL is the page the magic link opens; the frontend exchanges the
+// token in its query string for a session. ALUMNI_LOGIN_URL overrides it,
+// and a path is resolved against the public URL rather than the request's
+// host, so a forged Host header cannot send the token elsewhere.
+func (s *FiberServer) loginLinkURL(email, token string) string {
+	base := os.Getenv("ALUMNI_LOGIN_URL")
+	if base == "" {
+		base = "/"
+	}
+
+	query := url.Values{"email": {email}, "login_token": {token}}
+	separator := "?"
+	if strings.Contains(base, "?") {
+		separator = "&"
+	}
+	return s.absoluteURL(base) + separator + query.Encode()
+}
+
+// Alumni Session Handlers
+func (s *FiberServer) requestLoginLinkHandler(c *fiber.Ctx) error {
+	var req LoginLinkRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	email := strings.TrimSpace(req.Email)
+	if email == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "Email is required"})
+	}
+
+	// The response is the same whether or not the email is registered, so
+	// the endpoint cannot be used to find out who has registered.
+	resp := fiber.Map{"message": "If this email is registered, a sign-in link has been sent"}
+
+	alumni, err := s.db.FindAlumniByEmail(c.Context(), email)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to send sign-in link"})
+	}
+	if alumni == nil {
+		return c.JSON(resp)
+	}
+
+	otp, err := s.db.CreateLoginLink(c.Context(), alumni.Email)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to send sign-in link"})
+	}
+
+	link := s.loginLinkURL(alumni.Email, otp.Code)
+	name := strings.TrimSpace(alumni.FirstName + " " + alumni.LastName)
+	if err := s.email.SendLoginLink(alumni.Email, name, link); err != nil {
+		log.Printf("failed to send sign-in link to alumni %d: %v", alumni.ID, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to send sign-in link"})
+	}
+
+	if s.config.DebugOTP {
+		resp["debug_login_link"] = link
+	}
+	return c.JSON(resp)
+}
+
+func (s *FiberServer) verifyLoginLinkHandler(c *fiber.Ctx) error {
+	var req VerifyLoginLinkRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if req.Email == "" || req.Token == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "Email and token are required"})
+	}
+
+	otp, err := s.db.VerifyOTP(c.Context(), req.Email, req.Token, database.LoginPurpose)
+	if err != nil {
+		if errors.Is(err, database.ErrOTPInvalid) || errors.Is(err, database.ErrOTPTooManyAttempts) {
+			return c.Status(400).JSON(fiber.Map{"error": "This sign-in link is invalid or has expired"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to verify sign-in link"})
+	}
+
+	alumni, err := s.db.FindAlumniByEmail(c.Context(), otp.Email)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to verify sign-in link"})
+	}
+	if alumni == nil {
+		return c.Status(400).JSON(fiber.Map{"error": "This sign-in link is invalid or has expired"})
+	}
+
+	token, expiresAt, err := s.tokens.Issue(alumniAccessToken, strconv.Itoa(alumni.ID), alumniAccessTTL)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to create session"})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Signed in",
+		"alumni":  alumni,
+		"session": fiber.Map{
+			"access_token": token,
+			"expires_at":   expiresAt,
+		},
+	})
+}
+
+// requireAlumni validates an alumni bearer token and loads the signed-in
+// alumnus into c.Locals for currentAlumni.
+func (s *FiberServer) requireAlumni(c *fiber.Ctx) error {
+	header := c.Get(fiber.HeaderAuthorization)
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || token == "" {
+		return c.Status(401).JSON(fiber.Map{"error": "Missing authorization token"})
+	}
+
+	claims, err := s.tokens.Parse(token, alumniAccessToken)
+	if err != nil {
+		if err == auth.ErrExpiredToken {
+			return c.Status(401).JSON(fiber.Map{"error": "Session expired"})
+		}
+		return c.Status(401).JSON(fiber.Map{"error": "Invalid authorization token"})
+	}
+
+	id, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return c.Status(401).JSON(fiber.Map{"error": "Invalid authorization token"})
+	}
+
+	// A deleted registration ends the session
+	alumni, err := s.db.GetAlumniByID(c.Context(), id)
+	if err != nil {
+		return c.Status(401).JSON(fiber.Map{"error": "Invalid authorization token"})
+	}
+
+	c.Locals(alumniLocalsKey, alumni)
+	return c.Next()
+}
+
+func currentAlumni(c *fiber.Ctx) *database.Alumni {
+	alumni, _ := c.Locals(alumniLocalsKey).(*database.Alumni)
+	return alumni
+}
+
+// Self-service Profile Handlers
+func (s *FiberServer) getProfileHandler(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"alumni": currentAlumni(c)})
+}
+
+func (s *FiberServer) updateProfileHandler(c *fiber.Ctx) error {
+	var req UpdateProfileRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	req.FirstName = strings.TrimSpace(req.FirstName)
+	req.LastName = strings.TrimSpace(req.LastName)
+	if req.FirstName == "" || req.LastName == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "First and last name are required"})
+	}
+
+	alumni := currentAlumni(c)
+	alumni.FirstName = req.FirstName
+	alumni.LastName = req.LastName
+	alumni.Phone = req.Phone
+	alumni.Year = req.Year
+	alumni.Course = req.Course
+	alumni.Company = req.Company
+	alumni.Position = req.Position
+	columns := slices.Clone(alumniProfileColumns)
+	if alumni.SetLocation(req.Country, req.City) {
+		columns = append(columns, alumniCoordinateColumns...)
+	}
+
+	if err := s.db.UpdateAlumni(c.Context(), alumni, columns...); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update profile"})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Profile updated successfully",
+		"alumni":  alumni,
+	})
+}
+
+func (s *FiberServer) updatePrivacyHandler(c *fiber.Ctx) error {
+	var req UpdatePrivacyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if !database.IsMapPrecision(req.MapPrecision) {
+		return c.Status(400).JSON(fiber.Map{"error": "Map precision must be exact, city, country or hidden"})
+	}
+
+	alumni := currentAlumni(c)
+	alumni.ShowEmail = req.ShowEmail
+	alumni.ShowPhone = req.ShowPhone
+	alumni.ShowEmployer = req.ShowEmployer
+	alumni.MapPrecision = req.MapPrecision
+
+	if err := s.db.UpdateAlumni(c.Context(), alumni, "show_email", "show_phone", "show_employer", "map_precision"); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update privacy settings"})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Privacy settings updated successfully",
+		"alumni":  alumni,
+	})
+}
+
+func (s *FiberServer) getOwnPaymentProofHandler(c *fiber.Ctx) error {
+	return s.sendPaymentProof(c, currentAlumni(c))
+}
+
+func (s *FiberServer) uploadOwnPaymentProofHandler(c *fiber.Ctx) error {
+	return s.storePaymentProof(c, currentAlumni(c))
+}
+
+func (s *FiberServer) getOwnPaymentReviewsHandler(c *fiber.Ctx) error {
+	reviews, err := s.db.GetPaymentReviews(c.Context(), currentAlumni(c).ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch payment history"})
+	}
+
+	// Reviewer identities are internal
+	history := make([]fiber.Map, 0, len(reviews))
+	for _, review := range reviews {
+		history = append(history, fiber.Map{
+			"status":     review.ToStatus,
+			"reason":     review.Reason,
+			"created_at": review.CreatedAt,
+		})
+	}
+	return c.JSON(fiber.Map{"history": history})
+}
+
+func (s *FiberServer) startOwnCheckoutHandler(c *fiber.Ctx) error {
+	return s.startCheckout(c, currentAlumni(c))
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+
+	"unorcitconnect/internal/database"
+	"unorcitconnect/internal/upload"
+)
+
+// paymentProofMigrationLock is the advisory lock key held while moving
+// legacy payment proofs, so only one replica migrates at a time.
+const paymentProofMigrationLock int64 = 0x70726f6f66 // "proof"
+
+// uploadError responds to a failed upload validation: problems with the
+// file itself are the client's fault, anything else is ours.
+func uploadError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, upload.ErrEmpty) || errors.Is(err, upload.ErrTooLarge) || errors.Is(err, upload.ErrUnsupportedType) {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	log.Printf("failed to read upload: %v", err)
+	return c.Status(500).JSON(fiber.Map{"error": "Failed to read file"})
+}
+
+// paymentProofBlob returns a store function that writes file to the blob
+// store as an alumnus's payment proof, for the database methods that record
+// it in a transaction, and finish, to call with their result. finish
+// deletes the new blob if recording it failed and the replaced blobs once
+// it succeeded.
+func (s *FiberServer) paymentProofBlob(ctx context.Context, file *upload.File) (store func(alumniID int) (*database.Attachment, error), finish func(replaced []database.Attachment, err error)) {
+	var stored *database.Attachment
+	store = func(alumniID int) (*database.Attachment, error) {
+		attachment, err := s.putBlob(ctx, alumniID, database.AttachmentPaymentProof, file)
+		stored = attachment
+		return attachment, err
+	}
+	finish = func(replaced []database.Attachment, err error) {
+		if err != nil {
+			if stored != nil {
+				s.deleteBlob(ctx, stored.StorageKey)
+			}
+			return
+		}
+		for _, old := range replaced {
+			s.deleteBlob(ctx, old.StorageKey)
+		}
+	}
+	return store, finish
+}
+
+// submitPaymentProof replaces the alumnus's payment proof with file and
+// queues it for review. It returns database.ErrInvalidPaymentTransition once
+// the payment is approved.
+func (s *FiberServer) submitPaymentProof(ctx context.Context, alumniID int, file *upload.File) (*database.Alumni, error) {
+	store, finish := s.paymentProofBlob(ctx, file)
+	alumni, replaced, err := s.db.SubmitPaymentProof(ctx, alumniID, store)
+	finish(replaced, err)
+	return alumni, err
+}
+
+// putBlob stores file under a fresh key and returns the unsaved attachment.
+func (s *FiberServer) putBlob(ctx context.Context, alumniID int, kind string, file *upload.File) (*database.Attachment, error) {
+	suffix := make([]byte, 16)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, fmt.Errorf("failed to generate storage key: %w", err)
+	}
+	key := fmt.Sprintf("alumni/%d/%s/%s", alumniID, kind, hex.EncodeToString(suffix))
+
+	if err := s.blobs.Put(ctx, key, bytes.NewReader(file.Data), int64(len(file.Data)), file.ContentType); err != nil {
+		return nil, fmt.Errorf("failed to store file: %w", err)
+	}
+
+	checksum := sha256.Sum256(file.Data)
+	return &database.Attachment{
+		AlumniID:    alumniID,
+		Kind:        kind,
+		StorageKey:  key,
+		Filename:    file.Filename,
+		ContentType: file.ContentType,
+		Size:        int64(len(file.Data)),
+		Checksum:    hex.EncodeToString(checksum[:]),
+	}, nil
+}
+
+// deleteBlob removes a blob that is no longer referenced. Failures only
+// leave an orphaned file behind, so they are logged rather than returned.
+func (s *FiberServer) deleteBlob(ctx context.Context, key string) {
+	if err := s.blobs.Delete(ctx, key); err != nil {
+		log.Printf("failed to delete blob %s: %v", key, err)
+	}
+}
+
+// migrateLegacyPaymentProofs moves payment proofs still stored in the alumni
+// table into the blob store.
+func (s *FiberServer) migrateLegacyPaymentProofs(ctx context.Context) {
+	ran, err := s.db.WithAdvisoryLock(ctx, paymentProofMigrationLock, func(ctx context.Context) error {
+		moved, err := s.db.MigrateLegacyPaymentProofs(ctx, func(p database.LegacyPaymentProof) (*database.Attachment, error) {
+			return s.putBlob(ctx, p.AlumniID, database.AttachmentPaymentProof, &upload.File{
+				Filename:    p.Filename,
+				ContentType: p.ContentType,
+				Data:        p.Data,
+			})
+		})
+		if moved > 0 {
+			log.Printf("moved %d payment proofs to blob storage", moved)
+		}
+		return err
+	})
+	if err != nil {
+		log.Printf("Warning: failed to migrate payment proofs: %v", err)
+	} else if !ran {
+		log.Printf("payment proof migration is running on another instance")
+	}
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"log"
+	"os"
+	"sync"
+	"unorcitconnect/internal/database"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupState holds the one-time token that allows creating the first
+// superuser through the API. It is empty once an admin exists.
+type setupState struct {
+	mu    sync.Mutex
+	token string
+}
+
+type AdminSetupRequest struct {
+	SetupToken string `json:"setup_token"`
+	Username   string `json:"username"`
+	Password   string `json:"password"`
+}
+
+// seedAdmin creates the superuser named by ADMIN_SEED_USERNAME and
+// ADMIN_SEED_PASSWORD, when both are set. Published passwords are refused
+// outside development mode.
+func (s *FiberServer) seedAdmin() {
+	username, password := os.Getenv("ADMIN_SEED_USERNAME"), os.Getenv("ADMIN_SEED_PASSWORD")
+	if username == "" || password == "" {
+		return
+	}
+	if err := s.db.SeedAdmin(context.Background(), username, password, s.config.DevMode); err != nil {
+		log.Printf("Warning: failed to seed admin: %v", err)
+	}
+}
+
+// prepareAdminSetup enables first-run setup when there are no admins yet. The
+// token comes from ADMIN_SETUP_TOKEN or is generated and printed to the log.
+func (s *FiberServer) prepareAdminSetup() {
+	count, err := s.db.CountAdmins(context.Background())
+	if err != nil {
+		log.Printf("Warning: failed to count admins: %v", err)
+		return
+	}
+	if count > 0 {
+		return
+	}
+
+	token := os.Getenv("ADMIN_SETUP_TOKEN")
+	if token == "" {
+		buf := make([]byte, 16)
+		if _, err := rand.Read(buf); err != nil {
+			log.Printf("Warning: failed to generate admin setup token: %v", err)
+			return
+		}
+		token = hex.EncodeToString(buf)
+		log.Printf("No admin accounts exist. Use this one-time setup token to create the first superuser: %s", token)
+	} else {
+		log.Println("No admin accounts exist. Use ADMIN_SETUP_TOKEN to create the first superuser.")
+	}
+
+	s.setup.token = token
+}
+
+func (s *FiberServer) adminSetupStatusHandler(c *fiber.Ctx) error {
+	s.setup.mu.Lock()
+	required := s.setup.token != ""
+	s.setup.mu.Unlock()
+
+	return c.JSON(fiber.Map{"setup_required": required})
+}
+
+func (s *FiberServer) adminSetupHandler(c *fiber.Ctx) error {
+	var req AdminSetupRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if req.SetupToken == "" || req.Username == "" || req.Password == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "Setup token, username and password are required"})
+	}
+
+	if msg := validateAdminPassword(req.Password); msg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": msg})
+	}
+
+	s.setup.mu.Lock()
+	defer s.setup.mu.Unlock()
+
+	if s.setup.token == "" {
+		return c.Status(409).JSON(fiber.Map{"error": "Setup has already been completed"})
+	}
+
+	if subtle.ConstantTimeCompare([]byte(req.SetupToken), []byte(s.setup.token)) != 1 {
+		return c.Status(401).JSON(fiber.Map{"error": "Invalid setup token"})
+	}
+
+	admin, err := s.db.CreateFirstSuperuser(c.Context(), req.Username, req.Password)
+	if err != nil {
+		if errors.Is(err, database.ErrSetupAlreadyDone) {
+			s.setup.token = ""
+			return c.Status(409).JSON(fiber.Map{"error": "Setup has already been completed"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to create superuser"})
+	}
+
+	s.setup.token = ""
+
+	return c.Status(201).JSON(fiber.Map{
+		"message": "Superuser created successfully",
+		"admin": fiber.Map{
+			"id":       admin.ID,
+			"username": admin.Username,
+		},
+	})
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+	"unorcitconnect/internal/auth"
+	"unorcitconnect/internal/database"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// adminStoreStub keeps admins in memory; calling any other database method
+// panics.
+type adminStoreStub struct {
+	database.Service
+	admins map[int]*database.Admin
+}
+
+func (s *adminStoreStub) CreateFirstSuperuser(ctx context.Context, username, password string) (*database.Admin, error) {
+	if len(s.admins) > 0 {
+		return nil, database.ErrSetupAlreadyDone
+	}
+	admin := &database.Admin{ID: 1, Username: username, IsSuperuser: true}
+	s.admins = map[int]*database.Admin{admin.ID: admin}
+	return admin, nil
+}
+
+func (s *adminStoreStub) GetAdminByID(ctx context.Context, id int) (*database.Admin, error) {
+	admin, ok := s.admins[id]
+	if !ok {
+		return nil, fmt.Errorf("admin not found")
+	}
+	return admin, nil
+}
+
+func (s *adminStoreStub) GetAdminPermissions(ctx context.Context, adminID int) ([]string, error) {
+	return []string{database.PermAdminManage}, nil
+}
+
+func TestAdminSetup(t *testing.T) {
+	app := fiber.New()
+	s := &FiberServer{App: app, db: &adminStoreStub{}}
+	s.setup.token = "setup-token"
+	app.Post("/admin/setup", s.adminSetupHandler)
+
+	setup := func(body string) int {
+		req, err := http.NewRequest("POST", "/admin/setup", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		return resp.StatusCode
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"missing token", `{"username":"root","password":"long-password"}`, http.StatusBadRequest},
+		{"short password", `{"setup_token":"setup-token","username":"root","password":"short"}`, http.StatusBadRequest},
+		{"wrong token", `{"setup_token":"guess","username":"root","password":"long-password"}`, http.StatusUnauthorized},
+		{"right token", `{"setup_token":"setup-token","username":"root","password":"long-password"}`, http.StatusCreated},
+		{"token used up", `{"setup_token":"setup-token","username":"other","password":"long-password"}`, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		if got := setup(tt.body); got != tt.want {
+			t.Errorf("%s: expected status %d; got %d", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestMustChangePasswordGate(t *testing.T) {
+	app := fiber.New()
+	admin := &database.Admin{ID: 1, Username: "root", MustChangePassword: true}
+	s := &FiberServer{
+		App:    app,
+		db:     &adminStoreStub{admins: map[int]*database.Admin{admin.ID: admin}},
+		tokens: auth.NewTokenManager([]byte("secret")),
+	}
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(http.StatusOK) }
+	app.Get("/dashboard", s.requireAdmin, ok)
+	app.Put("/password", s.requireAdminPendingPasswordChange, ok)
+
+	token, _, _ := s.tokens.Issue(adminAccessToken, "1", time.Minute)
+	call := func(method, path string) int {
+		req, err := http.NewRequest(method, path, nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		return resp.StatusCode
+	}
+
+	if got := call("GET", "/dashboard"); got != http.StatusForbidden {
+		t.Errorf("expected the dashboard to wait for a password change; got %d", got)
+	}
+	if got := call("PUT", "/password"); got != http.StatusOK {
+		t.Errorf("expected the password change to be allowed; got %d", got)
+	}
+
+	admin.MustChangePassword = false
+	if got := call("GET", "/dashboard"); got != http.StatusOK {
+		t.Errorf("expected the dashboard once the password changed; got %d", got)
+	}
+}
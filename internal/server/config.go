@@ -0,0 +1,77 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Config holds environment-driven server settings.
+type Config struct {
+	Env string
+	// DevMode enables development-only helpers such as the mail catcher
+	// endpoints. It is on only when APP_ENV is "development" or "local".
+	DevMode bool
+	// DebugOTP echoes OTP codes in API responses. It requires DevMode and an
+	// explicit DEBUG_OTP=true.
+	DebugOTP bool
+	// PublicURL is the origin links in emails and checkout return URLs are
+	// built from, such as "https://alumni.example.com". It comes from
+	// PUBLIC_URL and never from the request's Host header, which clients
+	// control.
+	PublicURL string
+}
+
+func LoadConfig() Config {
+	env := os.Getenv("APP_ENV")
+	cfg := Config{
+		Env:     env,
+		DevMode: env == "development" || env == "local",
+	}
+
+	if os.Getenv("DEBUG_OTP") == "true" {
+		if cfg.DevMode {
+			cfg.DebugOTP = true
+		} else {
+			log.Println("Warning: DEBUG_OTP is ignored outside development mode")
+		}
+	}
+
+	publicURL, err := parsePublicURL(os.Getenv("PUBLIC_URL"), cfg.DevMode)
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+	cfg.PublicURL = publicURL
+
+	return cfg
+}
+
+// parsePublicURL validates PUBLIC_URL and strips any trailing slash. It is
+// required outside development mode, where it defaults to the local server.
+func parsePublicURL(raw string, devMode bool) (string, error) {
+	if raw == "" {
+		if !devMode {
+			return "", errors.New("PUBLIC_URL must be set")
+		}
+		port := os.Getenv("PORT")
+		if port == "" {
+			port = "8080"
+		}
+		return "http://localhost:" + port, nil
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("PUBLIC_URL is not a valid URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("PUBLIC_URL must be an absolute http(s) URL, got %q", raw)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("PUBLIC_URL must not have a query or fragment, got %q", raw)
+	}
+	return strings.TrimRight(raw, "/"), nil
+}
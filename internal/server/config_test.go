@@ -0,0 +1,32 @@
+package server
+
+import "testing"
+
+func TestParsePublicURL(t *testing.T) {
+	t.Setenv("PORT", "")
+
+	tests := []struct {
+		raw     string
+		devMode bool
+		want    string
+		wantErr bool
+	}{
+		{"https://alumni.example.com/", false, "https://alumni.example.com", false},
+		{"https://example.com/alumni", false, "https://example.com/alumni", false},
+		{"", true, "http://localhost:8080", false},
+		{"", false, "", true},
+		{"alumni.example.com", false, "", true},
+		{"ftp://alumni.example.com", false, "", true},
+		{"https://alumni.example.com/?next=/", false, "", true},
+	}
+	for _, tt := range tests {
+		got, err := parsePublicURL(tt.raw, tt.devMode)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parsePublicURL(%q, %v) error = %v, wantErr %v", tt.raw, tt.devMode, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parsePublicURL(%q, %v) = %q; want %q", tt.raw, tt.devMode, got, tt.want)
+		}
+	}
+}
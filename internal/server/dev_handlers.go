@@ -0,0 +1,34 @@
+package server
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"unorcitconnect/internal/payments"
+)
+
+// Development Handlers (registered only in dev mode)
+func (s *FiberServer) getCaughtMailHandler(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"messages": s.email.Catcher().Messages()})
+}
+
+func (s *FiberServer) clearCaughtMailHandler(c *fiber.Ctx) error {
+	s.email.Catcher().Clear()
+	return c.JSON(fiber.Map{"message": "Mailbox cleared"})
+}
+
+// completeFakePaymentHandler sends the fake provider's "paid" webhook for a
+// checkout session, standing in for the payer finishing the checkout.
+func (s *FiberServer) completeFakePaymentHandler(c *fiber.Ctx) error {
+	fake := s.payments.(*payments.FakeProvider)
+
+	payload, signature, err := fake.Event(payments.EventPaid, c.Params("session"), s.fee.Amount, s.fee.Currency)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to build payment event"})
+	}
+	event, err := fake.ParseWebhook(payload, signature)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to build payment event"})
+	}
+
+	return s.applyPaymentEvent(c, event)
+}
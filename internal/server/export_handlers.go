@@ -0,0 +1,164 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"unorcitconnect/internal/database"
+	"unorcitconnect/internal/export"
+)
+
+// Export Handlers
+//
+// Each export accepts the same filters as its list endpoint plus format
+// (csv or xlsx) and columns (a comma separated subset of the columns below).
+// Uploaded files are never exported, only their names.
+
+func formatBool(v bool) string { return strconv.FormatBool(v) }
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func optionalInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+var alumniExportColumns = []export.Column[database.Alumni]{
+	{Name: "id", Value: func(a database.Alumni) string { return strconv.Itoa(a.ID) }},
+	{Name: "first_name", Value: func(a database.Alumni) string { return a.FirstName }},
+	{Name: "last_name", Value: func(a database.Alumni) string { return a.LastName }},
+	{Name: "email", Value: func(a database.Alumni) string { return a.Email }},
+	{Name: "phone", Value: func(a database.Alumni) string { return a.Phone }},
+	{Name: "year", Value: func(a database.Alumni) string { return strconv.Itoa(a.Year) }},
+	{Name: "course", Value: func(a database.Alumni) string { return a.Course }},
+	{Name: "company", Value: func(a database.Alumni) string { return a.Company }},
+	{Name: "position", Value: func(a database.Alumni) string { return a.Position }},
+	{Name: "country", Value: func(a database.Alumni) string { return a.Country }},
+	{Name: "city", Value: func(a database.Alumni) string { return a.City }},
+	{Name: "is_verified", Value: func(a database.Alumni) string { return formatBool(a.IsVerified) }},
+	{Name: "paid", Value: func(a database.Alumni) string { return formatBool(a.Paid) }},
+	{Name: "payment_status", Value: func(a database.Alumni) string { return a.PaymentStatus }},
+	{Name: "payment_proof", Value: func(a database.Alumni) string { return a.PaymentProof }},
+	{Name: "created_at", Value: func(a database.Alumni) string { return formatTime(a.CreatedAt) }},
+}
+
+var nominationExportColumns = []export.Column[database.Nomination]{
+	{Name: "id", Value: func(n database.Nomination) string { return strconv.Itoa(n.ID) }},
+	{Name: "category", Value: func(n database.Nomination) string { return n.Category }},
+	{Name: "alumni_id", Value: func(n database.Nomination) string { return optionalInt(n.AlumniID) }},
+	{Name: "first_name", Value: func(n database.Nomination) string { return n.FirstName }},
+	{Name: "last_name", Value: func(n database.Nomination) string { return n.LastName }},
+	{Name: "year", Value: func(n database.Nomination) string { return strconv.Itoa(n.Year) }},
+	{Name: "nominated_email", Value: func(n database.Nomination) string { return n.NominatedEmail }},
+	{Name: "nominator_email", Value: func(n database.Nomination) string { return n.NominatorEmail }},
+	{Name: "created_at", Value: func(n database.Nomination) string { return formatTime(n.CreatedAt) }},
+}
+
+var sponsorshipExportColumns = []export.Column[database.Sponsorship]{
+	{Name: "id", Value: func(s database.Sponsorship) string { return strconv.FormatUint(uint64(s.ID), 10) }},
+	{Name: "level", Value: func(s database.Sponsorship) string { return s.Level }},
+	{Name: "first_name", Value: func(s database.Sponsorship) string { return s.FirstName }},
+	{Name: "last_name", Value: func(s database.Sponsorship) string { return s.LastName }},
+	{Name: "email", Value: func(s database.Sponsorship) string { return s.Email }},
+	{Name: "company", Value: func(s database.Sponsorship) string { return s.Company }},
+	{Name: "address", Value: func(s database.Sponsorship) string { return s.Address }},
+	{Name: "contact_number", Value: func(s database.Sponsorship) string { return s.ContactNumber }},
+	{Name: "requirement", Value: func(s database.Sponsorship) string { return s.Requirement }},
+	{Name: "confirmed", Value: func(s database.Sponsorship) string { return formatBool(s.Confirmed) }},
+	{Name: "feedback", Value: func(s database.Sponsorship) string { return s.Feedback }},
+	{Name: "created_at", Value: func(s database.Sponsorship) string { return formatTime(s.CreatedAt) }},
+}
+
+func (s *FiberServer) exportAlumniHandler(c *fiber.Ctx) error {
+	query, err := parseAlumniQuery(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return streamExport(c, "alumni", alumniExportColumns, func(ctx context.Context, fn func(database.Alumni) error) error {
+		return s.db.EachAlumni(ctx, query, fn)
+	})
+}
+
+func (s *FiberServer) exportNominationsHandler(c *fiber.Ctx) error {
+	category := c.Query("category")
+	return streamExport(c, "nominations", nominationExportColumns, func(ctx context.Context, fn func(database.Nomination) error) error {
+		return s.db.EachNomination(ctx, category, fn)
+	})
+}
+
+func (s *FiberServer) exportSponsorshipsHandler(c *fiber.Ctx) error {
+	return streamExport(c, "sponsorships", sponsorshipExportColumns, func(ctx context.Context, fn func(database.Sponsorship) error) error {
+		return s.db.EachSponsorship(ctx, fn)
+	})
+}
+
+// exportFailedRow ends a CSV export that stopped partway.
+const exportFailedRow = "ERROR: this export is incomplete"
+
+// streamExport validates the format and columns query parameters, then
+// streams every record produced by each to the client. Once streaming has
+// started the status can no longer change, so a failure partway is logged
+// and made visible in the file instead: a CSV ends with exportFailedRow and
+// an XLSX is left unfinished, which spreadsheets refuse to open.
+func streamExport[T any](c *fiber.Ctx, name string, columns []export.Column[T], each func(context.Context, func(T) error) error) error {
+	format, err := export.ParseFormat(c.Query("format"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	selected, err := export.SelectColumns(columns, export.ParseColumns(c.Query("columns")))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102"), format)
+	c.Set(fiber.HeaderContentType, format.ContentType())
+	c.Attachment(filename)
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		out, err := export.NewWriter(format, w, name)
+		if err != nil {
+			log.Printf("export %s: %v", name, err)
+			return
+		}
+		if err := out.WriteRow(export.Header(selected)); err != nil {
+			log.Printf("export %s: %v", name, err)
+			return
+		}
+
+		// The request context is released once the handler returns, so the
+		// stream runs on its own context.
+		err = each(context.Background(), func(record T) error {
+			return out.WriteRow(export.Row(selected, record))
+		})
+		if err != nil {
+			log.Printf("export %s: %v", name, err)
+			if format == export.CSV {
+				out.WriteRow([]string{exportFailedRow})
+				out.Close()
+			}
+			w.Flush()
+			return
+		}
+		if err := out.Close(); err != nil {
+			log.Printf("export %s: %v", name, err)
+		}
+		w.Flush()
+	})
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"unorcitconnect/internal/database"
+)
+
+func TestStreamExport(t *testing.T) {
+	app := fiber.New()
+	app.Get("/export", func(c *fiber.Ctx) error {
+		return streamExport(c, "alumni", alumniExportColumns, func(ctx context.Context, fn func(database.Alumni) error) error {
+			return fn(database.Alumni{ID: 7, FirstName: "ANA", Email: "ana@example.com"})
+		})
+	})
+	app.Get("/failing", func(c *fiber.Ctx) error {
+		return streamExport(c, "alumni", alumniExportColumns, func(ctx context.Context, fn func(database.Alumni) error) error {
+			if err := fn(database.Alumni{ID: 7, FirstName: "ANA", Email: "ana@example.com"}); err != nil {
+				return err
+			}
+			return errors.New("connection reset")
+		})
+	})
+
+	tests := []struct {
+		url      string
+		wantCode int
+		wantBody string
+	}{
+		{"/export?columns=id,first_name,email", http.StatusOK, "id,first_name,email\n7,ANA,ana@example.com\n"},
+		{"/failing?columns=id,first_name,email", http.StatusOK, "id,first_name,email\n7,ANA,ana@example.com\n" + exportFailedRow + "\n"},
+		{"/export?columns=password", http.StatusBadRequest, ""},
+		{"/export?format=pdf", http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest("GET", tt.url, nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		if resp.StatusCode != tt.wantCode {
+			t.Errorf("%s: expected status %d; got %d", tt.url, tt.wantCode, resp.StatusCode)
+			continue
+		}
+		if tt.wantBody == "" {
+			continue
+		}
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("error reading response body. Err: %v", err)
+		}
+		if string(body) != tt.wantBody {
+			t.Errorf("%s: expected body %q; got %q", tt.url, tt.wantBody, body)
+		}
+	}
+
+	// A workbook cut short must not open as if it were complete
+	req, err := http.NewRequest("GET", "/failing?format=xlsx", nil)
+	if err != nil {
+		t.Fatalf("error creating request. Err: %v", err)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error making request to server. Err: %v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("error reading response body. Err: %v", err)
+	}
+	if _, err := zip.NewReader(bytes.NewReader(body), int64(len(body))); err == nil {
+		t.Errorf("expected an interrupted xlsx export to be unreadable")
+	}
+}
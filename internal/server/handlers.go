@@ -1,11 +1,16 @@
 package server
 
 import (
-	"fmt"
+	"errors"
+	"log"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 	"unorcitconnect/internal/database"
+	"unorcitconnect/internal/storage"
+	"unorcitconnect/internal/upload"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -32,23 +37,27 @@ func (s *FiberServer) sendOTPHandler(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "Email and purpose are required"})
 	}
 
+	// Login links are only issued through /alumni/login
+	if req.Purpose == database.LoginPurpose {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid purpose"})
+	}
+
 	otp, err := s.db.CreateOTP(c.Context(), req.Email, req.Purpose)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	// Log the OTP for debugging (remove in production)
-	fmt.Printf("Generated OTP for %s: %s\n", req.Email, otp.Code)
-
 	// Send OTP via email
 	if err := s.email.SendOTP(req.Email, otp.Code, req.Purpose); err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": "Failed to send email: " + err.Error()})
 	}
 
-	return c.JSON(fiber.Map{
-		"message":   "OTP sent successfully",
-		"debug_otp": otp.Code, // TODO: Remove in production
-	})
+	resp := fiber.Map{"message": "OTP sent successfully"}
+	if s.config.DebugOTP {
+		resp["debug_otp"] = otp.Code
+	}
+
+	return c.JSON(resp)
 }
 
 func (s *FiberServer) verifyOTPHandler(c *fiber.Ctx) error {
@@ -63,7 +72,15 @@ func (s *FiberServer) verifyOTPHandler(c *fiber.Ctx) error {
 
 	otp, err := s.db.VerifyOTP(c.Context(), req.Email, req.Code, req.Purpose)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		if errors.Is(err, database.ErrOTPInvalid) || errors.Is(err, database.ErrOTPTooManyAttempts) {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to verify OTP"})
+	}
+
+	token, expiresAt, err := s.issueVerificationToken(otp.Email, otp.Purpose)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to issue verification token"})
 	}
 
 	// Check if alumni exists for registration purpose
@@ -73,44 +90,53 @@ func (s *FiberServer) verifyOTPHandler(c *fiber.Ctx) error {
 			return c.Status(500).JSON(fiber.Map{"error": "Failed to check alumni"})
 		}
 
-		// Log alumni data for debugging
-		if alumni != nil {
-			fmt.Printf("Found existing alumni for %s: ID=%d, Name=%s %s\n", req.Email, alumni.ID, alumni.FirstName, alumni.LastName)
-		} else {
-			fmt.Printf("No existing alumni found for %s\n", req.Email)
-		}
-
 		return c.JSON(fiber.Map{
-			"message":         "OTP verified successfully",
-			"verified":        true,
-			"alumni_exists":   alumni != nil,
-			"alumni_data":     alumni,
-			"verification_id": otp.ID,
+			"message":            "OTP verified successfully",
+			"verified":           true,
+			"alumni_exists":      alumni != nil,
+			"alumni_data":        alumni,
+			"verification_id":    otp.ID,
+			"verification_token": token,
+			"expires_at":         expiresAt,
 		})
 	}
 
 	return c.JSON(fiber.Map{
-		"message":         "OTP verified successfully",
-		"verified":        true,
-		"verification_id": otp.ID,
+		"message":            "OTP verified successfully",
+		"verified":           true,
+		"verification_id":    otp.ID,
+		"verification_token": token,
+		"expires_at":         expiresAt,
 	})
 }
 
 // Alumni Handlers
 func (s *FiberServer) getAllAlumniHandler(c *fiber.Ctx) error {
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	query, err := parseAlumniQuery(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if query.SortBy != "" && !publicAlumniSortFields[query.SortBy] {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid sort field"})
+	}
+	// Payment and verification state is for admins only
+	query.Paid, query.PaymentStatus, query.IsVerified = nil, "", nil
+	query.Public = true
 
-	alumni, total, err := s.db.GetPaginatedAlumni(c.Context(), page, pageSize)
+	alumni, total, err := s.db.SearchAlumni(c.Context(), query)
 	if err != nil {
+		if errors.Is(err, database.ErrInvalidSortField) {
+			return c.Status(400).JSON(fiber.Map{"error": "Invalid sort field"})
+		}
 		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	return c.JSON(fiber.Map{
-		"alumni": alumni,
+		"alumni": publicAlumniList(alumni),
 		"total":  total,
-		"page":   page,
-		"size":   pageSize,
+		"page":   query.Page,
+		"size":   query.PageSize,
 	})
 }
 
@@ -120,22 +146,73 @@ func (s *FiberServer) getAlumniLocationsHandler(c *fiber.Ctx) error {
 		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	return c.JSON(fiber.Map{"alumni": alumni})
+	// Alumni who only share their country have no coordinates to map
+	located := make([]PublicAlumni, 0, len(alumni))
+	for _, a := range publicAlumniList(alumni) {
+		if a.Latitude != 0 || a.Longitude != 0 {
+			located = append(located, a)
+		}
+	}
+
+	return c.JSON(fiber.Map{"alumni": located})
 }
 
-func (s *FiberServer) createAlumniHandler(c *fiber.Ctx) error {
-	fmt.Printf("🚀 CREATE ALUMNI HANDLER CALLED!\n")
-	fmt.Printf("Request Method: %s\n", c.Method())
-	fmt.Printf("Request URL: %s\n", c.OriginalURL())
+// getAdminAlumniHandler lists full alumni records, ignoring their privacy
+// settings, for admins.
+func (s *FiberServer) getAdminAlumniHandler(c *fiber.Ctx) error {
+	query, err := parseAlumniQuery(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
 
+	alumni, total, err := s.db.SearchAlumni(c.Context(), query)
+	if err != nil {
+		if errors.Is(err, database.ErrInvalidSortField) {
+			return c.Status(400).JSON(fiber.Map{"error": "Invalid sort field"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch alumni"})
+	}
+
+	return c.JSON(fiber.Map{
+		"alumni": alumni,
+		"total":  total,
+		"page":   query.Page,
+		"size":   query.PageSize,
+	})
+}
+
+// RegisterAlumniRequest holds the fields a new registration may set, the
+// same ones the multipart form reads. Coordinates are not among them; the
+// geocode backfill locates the city.
+type RegisterAlumniRequest struct {
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	Email     string `json:"email"`
+	Phone     string `json:"phone"`
+	Year      int    `json:"year"`
+	Course    string `json:"course"`
+	Company   string `json:"company"`
+	Position  string `json:"position"`
+	Country   string `json:"country"`
+	City      string `json:"city"`
+}
+
+// Columns written when an alumnus edits their registration. Payment,
+// verification and privacy columns have handlers of their own, and the
+// coordinates are only written when SetLocation clears them.
+var (
+	alumniProfileColumns    = []string{"first_name", "last_name", "phone", "year", "course", "company", "position", "country", "city"}
+	alumniCoordinateColumns = []string{"latitude", "longitude"}
+)
+
+func (s *FiberServer) createAlumniHandler(c *fiber.Ctx) error {
 	var alumni database.Alumni
+	var proof *upload.File
 
 	// Check if this is a multipart form (file upload)
 	contentType := c.Get("Content-Type")
-	fmt.Printf("📋 Content-Type: %s\n", contentType)
 
 	if contentType != "" && strings.Contains(contentType, "multipart/form-data") {
-		fmt.Printf("Processing multipart form data for new alumni\n")
 		// Handle multipart form data (with potential file upload)
 		alumni.FirstName = c.FormValue("firstName")
 		alumni.LastName = c.FormValue("lastName")
@@ -154,80 +231,56 @@ func (s *FiberServer) createAlumniHandler(c *fiber.Ctx) error {
 		}
 		alumni.Course = c.FormValue("course")
 
-		// Parse coordinates
-		if latStr := c.FormValue("latitude"); latStr != "" {
-			if lat, err := strconv.ParseFloat(latStr, 64); err == nil {
-				alumni.Latitude = lat
-			}
-		}
-		if lngStr := c.FormValue("longitude"); lngStr != "" {
-			if lng, err := strconv.ParseFloat(lngStr, 64); err == nil {
-				alumni.Longitude = lng
-			}
-		}
-
 		// Handle file upload
 		file, err := c.FormFile("payment_proof")
-		fmt.Printf("File upload attempt - Error: %v, File: %v\n", err, file != nil)
 		if err == nil && file != nil {
-			fmt.Printf("File found: %s, Size: %d, Type: %s\n", file.Filename, file.Size, file.Header.Get("Content-Type"))
-
-			// Check file size (limit to 5MB)
-			const maxSize = 5 * 1024 * 1024 // 5MB
-			if file.Size > maxSize {
-				return c.Status(400).JSON(fiber.Map{"error": "File size exceeds 5MB limit"})
-			}
-
-			// Check file type (only PDF allowed)
-			if file.Header.Get("Content-Type") != "application/pdf" {
-				return c.Status(400).JSON(fiber.Map{"error": "Only PDF files are allowed"})
-			}
-
-			// Open the file
-			src, err := file.Open()
+			// Validate the file by its content and read it in full
+			proof, err = s.uploads.Open(file)
 			if err != nil {
-				fmt.Printf("Failed to open file: %v\n", err)
-				return c.Status(500).JSON(fiber.Map{"error": "Failed to open file"})
+				return uploadError(c, err)
 			}
-			defer src.Close()
-
-			// Read file data
-			fileData := make([]byte, file.Size)
-			if _, err := src.Read(fileData); err != nil {
-				fmt.Printf("Failed to read file: %v\n", err)
-				return c.Status(500).JSON(fiber.Map{"error": "Failed to read file"})
-			}
-
-			fmt.Printf("File data read successfully, size: %d bytes\n", len(fileData))
-
-			// Set payment proof data
-			alumni.PaymentProof = file.Filename
-			alumni.PaymentProofData = fileData
-			alumni.PaymentProofType = "application/pdf"
-			alumni.PaymentProofSize = file.Size
-			alumni.Paid = true
-
-			fmt.Printf("Payment proof data set: filename=%s, dataSize=%d\n", alumni.PaymentProof, len(alumni.PaymentProofData))
-		} else if err != nil {
-			fmt.Printf("No file uploaded or error: %v\n", err)
 		}
 	} else {
-		// Handle JSON body (regular creation without file)
-		if err := c.BodyParser(&alumni); err != nil {
+		// Handle JSON body (regular creation without file). Only the
+		// registration fields are read, so clients cannot pick the ID,
+		// verification, payment or privacy state.
+		var req RegisterAlumniRequest
+		if err := c.BodyParser(&req); err != nil {
 			return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
 		}
-	}
 
-	fmt.Printf("💾 About to save new alumni to database...\n")
-	fmt.Printf("Alumni data before save: Paid=%t, PaymentProof=%s, PaymentProofDataSize=%d\n",
-		alumni.Paid, alumni.PaymentProof, len(alumni.PaymentProofData))
+		alumni = database.Alumni{
+			FirstName: req.FirstName,
+			LastName:  req.LastName,
+			Email:     req.Email,
+			Phone:     req.Phone,
+			Year:      req.Year,
+			Course:    req.Course,
+			Company:   req.Company,
+			Position:  req.Position,
+			Country:   req.Country,
+			City:      req.City,
+		}
+	}
 
-	if err := s.db.SaveAlumni(c.Context(), &alumni); err != nil {
-		fmt.Printf("❌ Failed to save new alumni: %v\n", err)
-		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+	if !verifiedEmailMatches(c, alumni.Email) {
+		return c.Status(403).JSON(fiber.Map{"error": "Email does not match the verified email"})
 	}
 
-	fmt.Printf("✅ New alumni saved successfully with ID: %d\n", alumni.ID)
+	// The file is stored once the alumni has an ID, in the same transaction
+	// as the row, so a failed upload leaves no registration behind
+	var err error
+	if proof != nil {
+		store, finish := s.paymentProofBlob(c.Context(), proof)
+		err = s.db.CreateAlumniWithPaymentProof(c.Context(), &alumni, store)
+		finish(nil, err)
+	} else {
+		err = s.db.SaveAlumni(c.Context(), &alumni)
+	}
+	if err != nil {
+		log.Printf("failed to create alumni: %v", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to save registration"})
+	}
 
 	return c.Status(201).JSON(fiber.Map{
 		"message": "Alumni created successfully",
@@ -236,33 +289,28 @@ func (s *FiberServer) createAlumniHandler(c *fiber.Ctx) error {
 }
 
 func (s *FiberServer) updateAlumniHandler(c *fiber.Ctx) error {
-	fmt.Printf("🚀 UPDATE ALUMNI HANDLER CALLED!\n")
-	fmt.Printf("Request Method: %s\n", c.Method())
-	fmt.Printf("Request URL: %s\n", c.OriginalURL())
-
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		fmt.Printf("❌ Invalid alumni ID: %s\n", c.Params("id"))
 		return c.Status(400).JSON(fiber.Map{"error": "Invalid alumni ID"})
 	}
 
-	fmt.Printf("✅ Alumni ID parsed: %d\n", id)
-
 	// Get existing alumni data
 	existingAlumni, err := s.db.GetAlumniByID(c.Context(), id)
 	if err != nil {
-		fmt.Printf("❌ Alumni not found for ID %d: %v\n", id, err)
 		return c.Status(404).JSON(fiber.Map{"error": "Alumni not found"})
 	}
 
-	fmt.Printf("✅ Found existing alumni: %s %s\n", existingAlumni.FirstName, existingAlumni.LastName)
+	if !verifiedEmailMatches(c, existingAlumni.Email) {
+		return c.Status(403).JSON(fiber.Map{"error": "You can only update your own registration"})
+	}
+
+	var proof *upload.File
+	var moved bool
 
 	// Check if this is a multipart form (file upload)
 	contentType := c.Get("Content-Type")
-	fmt.Printf("📋 Content-Type: %s\n", contentType)
 
 	if contentType != "" && strings.Contains(contentType, "multipart/form-data") {
-		fmt.Printf("Processing multipart form data\n")
 		// Handle multipart form data (with potential file upload)
 		existingAlumni.FirstName = c.FormValue("firstName")
 		existingAlumni.LastName = c.FormValue("lastName")
@@ -270,8 +318,7 @@ func (s *FiberServer) updateAlumniHandler(c *fiber.Ctx) error {
 		existingAlumni.Phone = c.FormValue("phone")
 		existingAlumni.Company = c.FormValue("company")
 		existingAlumni.Position = c.FormValue("position")
-		existingAlumni.Country = c.FormValue("country")
-		existingAlumni.City = c.FormValue("city")
+		moved = existingAlumni.SetLocation(c.FormValue("country"), c.FormValue("city"))
 
 		// Parse year and course
 		if yearStr := c.FormValue("year"); yearStr != "" {
@@ -281,66 +328,22 @@ func (s *FiberServer) updateAlumniHandler(c *fiber.Ctx) error {
 		}
 		existingAlumni.Course = c.FormValue("course")
 
-		// Parse coordinates
-		if latStr := c.FormValue("latitude"); latStr != "" {
-			if lat, err := strconv.ParseFloat(latStr, 64); err == nil {
-				existingAlumni.Latitude = lat
-			}
-		}
-		if lngStr := c.FormValue("longitude"); lngStr != "" {
-			if lng, err := strconv.ParseFloat(lngStr, 64); err == nil {
-				existingAlumni.Longitude = lng
-			}
-		}
-
 		// Handle file upload
 		file, err := c.FormFile("payment_proof")
-		fmt.Printf("File upload attempt - Error: %v, File: %v\n", err, file != nil)
 		if err == nil && file != nil {
-			fmt.Printf("File found: %s, Size: %d, Type: %s\n", file.Filename, file.Size, file.Header.Get("Content-Type"))
-
-			// Check file size (limit to 5MB)
-			const maxSize = 5 * 1024 * 1024 // 5MB
-			if file.Size > maxSize {
-				return c.Status(400).JSON(fiber.Map{"error": "File size exceeds 5MB limit"})
+			if !database.CanTransitionPayment(existingAlumni.PaymentStatus, database.PaymentSubmitted) {
+				return c.Status(409).JSON(fiber.Map{"error": "Your payment has already been approved"})
 			}
 
-			// Check file type (only PDF allowed)
-			if file.Header.Get("Content-Type") != "application/pdf" {
-				return c.Status(400).JSON(fiber.Map{"error": "Only PDF files are allowed"})
-			}
-
-			// Open the file
-			src, err := file.Open()
+			// Validate the file by its content and read it in full
+			proof, err = s.uploads.Open(file)
 			if err != nil {
-				fmt.Printf("Failed to open file: %v\n", err)
-				return c.Status(500).JSON(fiber.Map{"error": "Failed to open file"})
-			}
-			defer src.Close()
-
-			// Read file data
-			fileData := make([]byte, file.Size)
-			if _, err := src.Read(fileData); err != nil {
-				fmt.Printf("Failed to read file: %v\n", err)
-				return c.Status(500).JSON(fiber.Map{"error": "Failed to read file"})
+				return uploadError(c, err)
 			}
-
-			fmt.Printf("File data read successfully, size: %d bytes\n", len(fileData))
-
-			// Update alumni with payment proof data
-			existingAlumni.PaymentProof = file.Filename
-			existingAlumni.PaymentProofData = fileData
-			existingAlumni.PaymentProofType = "application/pdf"
-			existingAlumni.PaymentProofSize = file.Size
-			existingAlumni.Paid = true
-
-			fmt.Printf("Payment proof data set: filename=%s, dataSize=%d\n", existingAlumni.PaymentProof, len(existingAlumni.PaymentProofData))
-		} else if err != nil {
-			fmt.Printf("No file uploaded or error: %v\n", err)
 		}
 	} else {
 		// Handle JSON body (regular update without file)
-		var alumni database.Alumni
+		var alumni RegisterAlumniRequest
 		if err := c.BodyParser(&alumni); err != nil {
 			return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
 		}
@@ -354,27 +357,37 @@ func (s *FiberServer) updateAlumniHandler(c *fiber.Ctx) error {
 		existingAlumni.Course = alumni.Course
 		existingAlumni.Company = alumni.Company
 		existingAlumni.Position = alumni.Position
-		existingAlumni.Country = alumni.Country
-		existingAlumni.City = alumni.City
-		existingAlumni.Latitude = alumni.Latitude
-		existingAlumni.Longitude = alumni.Longitude
-		existingAlumni.IsVerified = alumni.IsVerified
-		// Don't overwrite payment proof data unless explicitly provided
-		if alumni.Paid {
-			existingAlumni.Paid = alumni.Paid
-		}
+		moved = existingAlumni.SetLocation(alumni.Country, alumni.City)
+		// Verification and payment details only change through admins
 	}
 
-	fmt.Printf("💾 About to save alumni to database...\n")
-	fmt.Printf("Alumni data before save: ID=%d, Paid=%t, PaymentProof=%s, PaymentProofDataSize=%d\n",
-		existingAlumni.ID, existingAlumni.Paid, existingAlumni.PaymentProof, len(existingAlumni.PaymentProofData))
+	if !verifiedEmailMatches(c, existingAlumni.Email) {
+		return c.Status(403).JSON(fiber.Map{"error": "Email does not match the verified email"})
+	}
 
-	if err := s.db.SaveAlumni(c.Context(), existingAlumni); err != nil {
-		fmt.Printf("❌ Failed to save alumni: %v\n", err)
-		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+	columns := append(slices.Clone(alumniProfileColumns), "email")
+	if moved {
+		columns = append(columns, alumniCoordinateColumns...)
 	}
 
-	fmt.Printf("✅ Alumni saved successfully!\n")
+	if err := s.db.UpdateAlumni(c.Context(), existingAlumni, columns...); err != nil {
+		log.Printf("failed to update alumni %d: %v", existingAlumni.ID, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update registration"})
+	}
+
+	// The profile is saved first; the proof and its status change are then
+	// recorded together, so a failed upload leaves no blob or status behind
+	if proof != nil {
+		updated, err := s.submitPaymentProof(c.Context(), existingAlumni.ID, proof)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidPaymentTransition) {
+				return c.Status(409).JSON(fiber.Map{"error": "Your payment has already been approved"})
+			}
+			log.Printf("failed to store payment proof for alumni %d: %v", existingAlumni.ID, err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to store payment proof"})
+		}
+		existingAlumni = updated
+	}
 
 	return c.JSON(fiber.Map{
 		"message": "Alumni updated successfully",
@@ -413,14 +426,52 @@ type AdminLoginRequest struct {
 }
 
 type CreateAdminRequest struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username string   `json:"username"`
+	Password string   `json:"password"`
+	Roles    []string `json:"roles"` // optional, defaults to viewer
+}
+
+type SetAdminRolesRequest struct {
+	Roles []string `json:"roles"`
+}
+
+type RefreshSessionRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+const (
+	adminAccessToken  = "admin_access"
+	adminRefreshToken = "admin_refresh"
+
+	adminAccessTTL  = 1 * time.Hour
+	adminRefreshTTL = 7 * 24 * time.Hour
+)
+
+// issueAdminSession signs a fresh access/refresh token pair for admin.
+func (s *FiberServer) issueAdminSession(admin *database.Admin) (fiber.Map, error) {
+	subject := strconv.Itoa(admin.ID)
+
+	accessToken, accessExpiresAt, err := s.tokens.Issue(adminAccessToken, subject, adminAccessTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, refreshExpiresAt, err := s.tokens.Issue(adminRefreshToken, subject, adminRefreshTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return fiber.Map{
+		"access_token":       accessToken,
+		"expires_at":         accessExpiresAt,
+		"refresh_token":      refreshToken,
+		"refresh_expires_at": refreshExpiresAt,
+	}, nil
 }
 
 func (s *FiberServer) adminLoginHandler(c *fiber.Ctx) error {
 	var req AdminLoginRequest
 	if err := c.BodyParser(&req); err != nil {
-		fmt.Printf("login BodyParser error: %v | body=%q", err, string(c.Body()))
 		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
 	}
 
@@ -430,16 +481,68 @@ func (s *FiberServer) adminLoginHandler(c *fiber.Ctx) error {
 
 	admin, err := s.db.AuthenticateAdmin(c.Context(), req.Username, req.Password)
 	if err != nil {
+		if errors.Is(err, database.ErrAdminDisabled) {
+			return c.Status(403).JSON(fiber.Map{"error": "Admin account is disabled"})
+		}
 		return c.Status(401).JSON(fiber.Map{"error": "Invalid credentials"})
 	}
 
+	permissions, err := s.db.GetAdminPermissions(c.Context(), admin.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to load permissions"})
+	}
+
+	session, err := s.issueAdminSession(admin)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to create session"})
+	}
+
 	return c.JSON(fiber.Map{
 		"message": "Login successful",
 		"admin": fiber.Map{
-			"id":           admin.ID,
-			"username":     admin.Username,
-			"is_superuser": admin.IsSuperuser,
+			"id":                   admin.ID,
+			"username":             admin.Username,
+			"is_superuser":         slices.Contains(permissions, database.PermAdminManage),
+			"permissions":          permissions,
+			"must_change_password": admin.MustChangePassword,
 		},
+		"session": session,
+	})
+}
+
+func (s *FiberServer) refreshAdminSessionHandler(c *fiber.Ctx) error {
+	var req RefreshSessionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if req.RefreshToken == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "Refresh token is required"})
+	}
+
+	claims, err := s.tokens.Parse(req.RefreshToken, adminRefreshToken)
+	if err != nil {
+		return c.Status(401).JSON(fiber.Map{"error": "Invalid or expired refresh token"})
+	}
+
+	id, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return c.Status(401).JSON(fiber.Map{"error": "Invalid or expired refresh token"})
+	}
+
+	admin, err := s.db.GetAdminByID(c.Context(), id)
+	if err != nil || admin.Disabled {
+		return c.Status(401).JSON(fiber.Map{"error": "Invalid or expired refresh token"})
+	}
+
+	session, err := s.issueAdminSession(admin)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to create session"})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Session refreshed",
+		"session": session,
 	})
 }
 
@@ -453,13 +556,17 @@ func (s *FiberServer) createAdminHandler(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "Username and password are required"})
 	}
 
-	// Validate password strength (minimum 8 characters, at least one uppercase, one lowercase, one number, one special character)
-	if len(req.Password) < 8 {
-		return c.Status(400).JSON(fiber.Map{"error": "Password must be at least 8 characters long"})
+	if msg := validateAdminPassword(req.Password); msg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": msg})
 	}
 
-	admin, err := s.db.CreateAdmin(c.Context(), req.Username, req.Password)
+	// The admin and their roles are saved together, so an unknown role
+	// leaves no account behind
+	admin, err := s.db.CreateAdmin(c.Context(), req.Username, req.Password, req.Roles...)
 	if err != nil {
+		if errors.Is(err, database.ErrUnknownRole) || errors.Is(err, database.ErrRoleRepeated) {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
 		// Check if it's a duplicate username error
 		if strings.Contains(err.Error(), "duplicate") || strings.Contains(err.Error(), "unique") {
 			return c.Status(409).JSON(fiber.Map{"error": "Username already exists"})
@@ -476,17 +583,78 @@ func (s *FiberServer) createAdminHandler(c *fiber.Ctx) error {
 	})
 }
 
-func (s *FiberServer) adminDashboardHandler(c *fiber.Ctx) error {
-	// Get all alumni
-	alumni, _, err := s.db.GetPaginatedAlumni(c.Context(), 1, 1000)
+func (s *FiberServer) getRolesHandler(c *fiber.Ctx) error {
+	roles, err := s.db.GetAllRoles(c.Context())
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch alumni"})
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch roles"})
 	}
 
-	// Get all nominations
-	nominations, err := s.db.FindNominationsByCategory(c.Context(), "")
+	result := make([]fiber.Map, 0, len(roles))
+	for _, role := range roles {
+		permissions := make([]string, 0, len(role.Permissions))
+		for _, p := range role.Permissions {
+			permissions = append(permissions, p.Name)
+		}
+		result = append(result, fiber.Map{
+			"name":        role.Name,
+			"description": role.Description,
+			"permissions": permissions,
+		})
+	}
+
+	return c.JSON(fiber.Map{"roles": result})
+}
+
+func (s *FiberServer) setAdminRolesHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch nominations"})
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid admin ID"})
+	}
+
+	var req SetAdminRolesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if _, err := s.db.GetAdminByID(c.Context(), id); err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Admin not found"})
+	}
+
+	if err := s.db.SetAdminRoles(c.Context(), id, req.Roles); err != nil {
+		if errors.Is(err, database.ErrLastAdminManager) {
+			return c.Status(409).JSON(fiber.Map{"error": err.Error()})
+		}
+		if errors.Is(err, database.ErrNoRoles) || errors.Is(err, database.ErrRoleRepeated) || errors.Is(err, database.ErrUnknownRole) {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update roles"})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Roles updated successfully",
+		"roles":   req.Roles,
+	})
+}
+
+// adminDashboardHandler returns the alumni and nominations the admin may
+// read; a list they have no permission for comes back empty.
+func (s *FiberServer) adminDashboardHandler(c *fiber.Ctx) error {
+	alumni := []database.Alumni{}
+	if hasPermission(c, database.PermAlumniRead) {
+		var err error
+		alumni, _, err = s.db.GetPaginatedAlumni(c.Context(), 1, 1000)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch alumni"})
+		}
+	}
+
+	nominations := []database.Nomination{}
+	if hasPermission(c, database.PermNominationRead) {
+		var err error
+		nominations, err = s.db.FindNominationsByCategory(c.Context(), "")
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch nominations"})
+		}
 	}
 
 	return c.JSON(fiber.Map{
@@ -499,17 +667,48 @@ func (s *FiberServer) adminDashboardHandler(c *fiber.Ctx) error {
 	})
 }
 
+// NominationRequest holds the fields a nominator may set. The ID, campaign
+// and timestamps are always chosen by the server.
+type NominationRequest struct {
+	AlumniID       *int   `json:"alumniId"`
+	FirstName      string `json:"firstName"`
+	LastName       string `json:"lastName"`
+	NominatedEmail string `json:"nominatedEmail"`
+	NominatorEmail string `json:"nominatorEmail"`
+	Year           int    `json:"year"`
+	Category       string `json:"category"`
+}
+
+func (r NominationRequest) nomination() database.Nomination {
+	return database.Nomination{
+		AlumniID:       r.AlumniID,
+		FirstName:      r.FirstName,
+		LastName:       r.LastName,
+		NominatedEmail: r.NominatedEmail,
+		NominatorEmail: r.NominatorEmail,
+		Year:           r.Year,
+		Category:       r.Category,
+	}
+}
+
 // Nomination Handlers
 func (s *FiberServer) createNominationHandler(c *fiber.Ctx) error {
-	var nomination database.Nomination
-	if err := c.BodyParser(&nomination); err != nil {
+	var req NominationRequest
+	if err := c.BodyParser(&req); err != nil {
 		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
 	}
+	nomination := req.nomination()
+
+	if !verifiedEmailMatches(c, nomination.NominatorEmail) {
+		return c.Status(403).JSON(fiber.Map{"error": "Nominator email does not match the verified email"})
+	}
 
 	if err := s.db.SaveNomination(c.Context(), &nomination); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+		return nominationError(c, err, "Failed to save nomination")
 	}
 
+	s.notifyNomination(c, &nomination)
+
 	return c.Status(201).JSON(fiber.Map{
 		"message":    "Nomination created successfully",
 		"nomination": nomination,
@@ -561,6 +760,16 @@ func (s *FiberServer) createSponsorshipHandler(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
 	}
 
+	if !verifiedEmailMatches(c, sponsorship.Email) {
+		return c.Status(403).JSON(fiber.Map{"error": "Email does not match the verified email"})
+	}
+
+	// New applications start unconfirmed; confirmation is only changed by admins
+	sponsorship.ID = 0
+	sponsorship.Confirmed = false
+	sponsorship.Feedback = ""
+	sponsorship.CreatedAt, sponsorship.UpdatedAt = time.Time{}, time.Time{}
+
 	if err := s.db.CreateSponsorship(c.Context(), &sponsorship); err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
 	}
@@ -642,8 +851,20 @@ func (s *FiberServer) updateSponsorshipHandler(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
 	}
 
-	// Set the ID from the URL parameter
+	existing, err := s.db.GetSponsorshipByID(c.Context(), uint(id))
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Sponsorship not found"})
+	}
+
+	if !verifiedEmailMatches(c, existing.Email) || !verifiedEmailMatches(c, sponsorship.Email) {
+		return c.Status(403).JSON(fiber.Map{"error": "You can only update your own sponsorship"})
+	}
+
+	// Set the ID from the URL parameter; confirmation is only changed by admins
 	sponsorship.ID = uint(id)
+	sponsorship.Confirmed = existing.Confirmed
+	sponsorship.Feedback = existing.Feedback
+	sponsorship.CreatedAt = existing.CreatedAt
 
 	if err := s.db.UpdateSponsorship(c.Context(), &sponsorship); err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
@@ -667,86 +888,85 @@ func (s *FiberServer) getPaymentProofHandler(c *fiber.Ctx) error {
 		return c.Status(404).JSON(fiber.Map{"error": "Alumni not found"})
 	}
 
-	if !alumni.Paid || len(alumni.PaymentProofData) == 0 {
+	return s.sendPaymentProof(c, alumni)
+}
+
+// sendPaymentProof streams the alumnus's payment proof as a download.
+func (s *FiberServer) sendPaymentProof(c *fiber.Ctx, alumni *database.Alumni) error {
+	attachment, err := s.db.GetAttachment(c.Context(), alumni.ID, database.AttachmentPaymentProof)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to load payment proof"})
+	}
+	if attachment == nil {
 		return c.Status(404).JSON(fiber.Map{"error": "No payment proof available"})
 	}
 
+	blob, err := s.blobs.Get(c.Context(), attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return c.Status(404).JSON(fiber.Map{"error": "No payment proof available"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to load payment proof"})
+	}
+
 	// Set appropriate headers for file download
-	c.Set("Content-Type", alumni.PaymentProofType)
-	c.Set("Content-Disposition", "attachment; filename=\""+alumni.PaymentProof+"\"")
-	c.Set("Content-Length", strconv.FormatInt(alumni.PaymentProofSize, 10))
+	c.Set("Content-Type", attachment.ContentType)
+	c.Attachment(attachment.Filename)
 
-	// Return the file data
-	return c.Send(alumni.PaymentProofData)
+	// Stream the file; fiber closes the reader once it has been sent
+	return c.SendStream(blob, int(attachment.Size))
 }
 
 func (s *FiberServer) uploadPaymentProofHandler(c *fiber.Ctx) error {
-	fmt.Printf("🚀 UPLOAD PAYMENT PROOF HANDLER CALLED!\n")
-	fmt.Printf("Request Method: %s\n", c.Method())
-	fmt.Printf("Request URL: %s\n", c.OriginalURL())
-
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		fmt.Printf("❌ Invalid alumni ID: %s\n", c.Params("id"))
 		return c.Status(400).JSON(fiber.Map{"error": "Invalid alumni ID"})
 	}
 
-	fmt.Printf("✅ Alumni ID parsed: %d\n", id)
-
-	// Get the uploaded file
-	file, err := c.FormFile("payment_proof")
+	// Get alumni by ID
+	alumni, err := s.db.GetAlumniByID(c.Context(), id)
 	if err != nil {
-		fmt.Printf("❌ No file uploaded: %v\n", err)
-		return c.Status(400).JSON(fiber.Map{"error": "No file uploaded"})
+		return c.Status(404).JSON(fiber.Map{"error": "Alumni not found"})
 	}
 
-	fmt.Printf("✅ File received: %s, Size: %d, Type: %s\n", file.Filename, file.Size, file.Header.Get("Content-Type"))
-
-	// Check file size (limit to 5MB)
-	const maxSize = 5 * 1024 * 1024 // 5MB
-	if file.Size > maxSize {
-		return c.Status(400).JSON(fiber.Map{"error": "File size exceeds 5MB limit"})
+	if !verifiedEmailMatches(c, alumni.Email) {
+		return c.Status(403).JSON(fiber.Map{"error": "You can only upload your own payment proof"})
 	}
 
-	// Check file type (only PDF allowed)
-	if file.Header.Get("Content-Type") != "application/pdf" {
-		return c.Status(400).JSON(fiber.Map{"error": "Only PDF files are allowed"})
-	}
+	return s.storePaymentProof(c, alumni)
+}
 
-	// Open the file
-	src, err := file.Open()
+// storePaymentProof replaces the alumnus's payment proof with the uploaded
+// payment_proof file and queues it for review.
+func (s *FiberServer) storePaymentProof(c *fiber.Ctx, alumni *database.Alumni) error {
+	// Get the uploaded file
+	file, err := c.FormFile("payment_proof")
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to open file"})
+		return c.Status(400).JSON(fiber.Map{"error": "No file uploaded"})
 	}
-	defer src.Close()
 
-	// Read file data
-	fileData := make([]byte, file.Size)
-	if _, err := src.Read(fileData); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to read file"})
+	if !database.CanTransitionPayment(alumni.PaymentStatus, database.PaymentSubmitted) {
+		return c.Status(409).JSON(fiber.Map{"error": "Your payment has already been approved"})
 	}
 
-	// Get alumni by ID
-	alumni, err := s.db.GetAlumniByID(c.Context(), id)
+	// Validate the file by its content and read it in full
+	proof, err := s.uploads.Open(file)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": "Alumni not found"})
+		return uploadError(c, err)
 	}
 
-	// Update alumni with payment proof data
-	alumni.PaymentProof = file.Filename
-	alumni.PaymentProofData = fileData
-	alumni.PaymentProofType = "application/pdf"
-	alumni.PaymentProofSize = file.Size
-	alumni.Paid = true
-
-	if err := s.db.SaveAlumni(c.Context(), alumni); err != nil {
+	if _, err := s.submitPaymentProof(c.Context(), alumni.ID, proof); err != nil {
+		if errors.Is(err, database.ErrInvalidPaymentTransition) {
+			return c.Status(409).JSON(fiber.Map{"error": "Your payment has already been approved"})
+		}
+		log.Printf("failed to store payment proof for alumni %d: %v", alumni.ID, err)
 		return c.Status(500).JSON(fiber.Map{"error": "Failed to save payment proof"})
 	}
 
 	return c.JSON(fiber.Map{
 		"message":  "Payment proof uploaded successfully",
-		"filename": file.Filename,
-		"size":     file.Size,
+		"filename": proof.Filename,
+		"size":     len(proof.Data),
 	})
 }
 
@@ -757,8 +977,19 @@ func (s *FiberServer) deleteAlumniHandler(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "Invalid alumni ID"})
 	}
 
+	// Attachment rows are removed by the foreign key; their files are not
+	attachments, err := s.db.ListAttachments(c.Context(), id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete alumni"})
+	}
+
 	if err := s.db.DeleteAlumni(c.Context(), id); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+		log.Printf("failed to delete alumni %d: %v", id, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete alumni"})
+	}
+
+	for _, attachment := range attachments {
+		s.deleteBlob(c.Context(), attachment.StorageKey)
 	}
 
 	return c.JSON(fiber.Map{"message": "Alumni deleted successfully"})
@@ -770,7 +1001,10 @@ func (s *FiberServer) deleteNominationHandler(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "Invalid nomination ID"})
 	}
 
-	if err := s.db.DeleteNomination(c.Context(), id); err != nil {
+	if err := s.db.DeleteNomination(c.Context(), id, currentAdmin(c).Username); err != nil {
+		if errors.Is(err, database.ErrNominationNotFound) {
+			return c.Status(404).JSON(fiber.Map{"error": "Nomination not found"})
+		}
 		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
 	}
 
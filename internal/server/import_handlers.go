@@ -0,0 +1,44 @@
+package server
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"unorcitconnect/internal/importer"
+)
+
+// maxImportFileSize caps the size of an uploaded alumni spreadsheet.
+const maxImportFileSize = 5 * 1024 * 1024
+
+// importAlumniHandler accepts a CSV upload in the "file" form field. With
+// ?dry_run=true the rows are only validated; otherwise they are imported in
+// one transaction, and only if every row is valid.
+func (s *FiberServer) importAlumniHandler(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "A CSV file is required"})
+	}
+	if fileHeader.Size > maxImportFileSize {
+		return c.Status(400).JSON(fiber.Map{"error": "File size must be less than 5MB"})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Failed to read uploaded file"})
+	}
+	defer file.Close()
+
+	report, err := importer.ImportAlumniCSV(c.Context(), s.db, file, c.QueryBool("dry_run"))
+	if err != nil {
+		if errors.Is(err, importer.ErrMalformedFile) {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to import alumni"})
+	}
+
+	if !report.OK() {
+		return c.Status(422).JSON(fiber.Map{"error": "Some rows failed validation; nothing was imported", "report": report})
+	}
+	return c.JSON(fiber.Map{"report": report})
+}
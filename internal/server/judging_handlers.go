@@ -0,0 +1,367 @@
+package server
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"unorcitconnect/internal/database"
+)
+
+// Judging Handlers
+type ShortlistRequest struct {
+	Category  string `json:"category"`
+	AlumniID  *int   `json:"alumni_id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Year      int    `json:"year"`
+}
+
+type JudgingCriterionRequest struct {
+	ID          int     `json:"id"` // set to keep an existing criterion and its scores
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Weight      float64 `json:"weight"`
+}
+
+type JudgingCriteriaRequest struct {
+	Criteria []JudgingCriterionRequest `json:"criteria"`
+}
+
+type CampaignJudgesRequest struct {
+	AdminIDs []int `json:"admin_ids"`
+}
+
+type JudgeScoreRequest struct {
+	CriterionID int `json:"criterion_id"`
+	Score       int `json:"score"`
+}
+
+type JudgeScoresRequest struct {
+	Scores []JudgeScoreRequest `json:"scores"`
+}
+
+func (s *FiberServer) getCampaignTallyHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid campaign ID"})
+	}
+
+	tally, err := s.db.GetCampaignTally(c.Context(), id, c.Query("category"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch nomination tally"})
+	}
+	return c.JSON(fiber.Map{"nominees": tally})
+}
+
+func (s *FiberServer) getShortlistHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid campaign ID"})
+	}
+
+	shortlist, err := s.db.GetShortlist(c.Context(), id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch shortlist"})
+	}
+	return c.JSON(fiber.Map{"shortlist": shortlist})
+}
+
+func (s *FiberServer) addToShortlistHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid campaign ID"})
+	}
+
+	var req ShortlistRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	entry := database.ShortlistEntry{
+		CampaignID: id,
+		Category:   req.Category,
+		AlumniID:   req.AlumniID,
+		FirstName:  req.FirstName,
+		LastName:   req.LastName,
+		Year:       req.Year,
+	}
+	if err := s.db.AddToShortlist(c.Context(), &entry); err != nil {
+		return judgingError(c, err, "Failed to shortlist nominee")
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"message": "Nominee shortlisted successfully",
+		"entry":   entry,
+	})
+}
+
+func (s *FiberServer) removeFromShortlistHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid campaign ID"})
+	}
+	entryID, err := strconv.Atoi(c.Params("entryId"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid shortlist entry ID"})
+	}
+
+	if err := s.db.RemoveFromShortlist(c.Context(), id, entryID); err != nil {
+		return judgingError(c, err, "Failed to remove nominee from shortlist")
+	}
+	return c.JSON(fiber.Map{"message": "Nominee removed from shortlist"})
+}
+
+func (s *FiberServer) getJudgingCriteriaHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid campaign ID"})
+	}
+
+	criteria, err := s.db.GetJudgingCriteria(c.Context(), id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch judging criteria"})
+	}
+	return c.JSON(fiber.Map{"criteria": criteria, "max_score": database.MaxJudgeScore})
+}
+
+func (s *FiberServer) setJudgingCriteriaHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid campaign ID"})
+	}
+
+	var req JudgingCriteriaRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	criteria := make([]database.JudgingCriterion, 0, len(req.Criteria))
+	for _, criterion := range req.Criteria {
+		criteria = append(criteria, database.JudgingCriterion{
+			ID:          criterion.ID,
+			Name:        criterion.Name,
+			Description: criterion.Description,
+			Weight:      criterion.Weight,
+		})
+	}
+	if err := s.db.SetJudgingCriteria(c.Context(), id, criteria); err != nil {
+		return judgingError(c, err, "Failed to save judging criteria")
+	}
+
+	return c.JSON(fiber.Map{
+		"message":  "Judging criteria saved successfully",
+		"criteria": criteria,
+	})
+}
+
+func (s *FiberServer) getJudgeCandidatesHandler(c *fiber.Ctx) error {
+	judges, err := s.db.GetJudgeCandidates(c.Context())
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch judges"})
+	}
+	return c.JSON(fiber.Map{"judges": judges})
+}
+
+func (s *FiberServer) getCampaignJudgesHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid campaign ID"})
+	}
+
+	judges, err := s.db.GetCampaignJudges(c.Context(), id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch judges"})
+	}
+	return c.JSON(fiber.Map{"judges": judges})
+}
+
+func (s *FiberServer) setCampaignJudgesHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid campaign ID"})
+	}
+
+	var req CampaignJudgesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if err := s.db.SetCampaignJudges(c.Context(), id, req.AdminIDs); err != nil {
+		return judgingError(c, err, "Failed to assign judges")
+	}
+	return c.JSON(fiber.Map{"message": "Judges assigned successfully"})
+}
+
+func (s *FiberServer) getRankingsHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid campaign ID"})
+	}
+
+	campaign, err := s.db.GetNominationCampaign(c.Context(), id)
+	if err != nil {
+		return campaignError(c, err, "Failed to fetch nomination campaign")
+	}
+	rankings, err := s.db.GetRankings(c.Context(), id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to rank shortlist"})
+	}
+	judges, err := s.db.GetCampaignJudges(c.Context(), id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch judges"})
+	}
+
+	return c.JSON(fiber.Map{
+		"rankings":     rankings,
+		"judges":       len(judges),
+		"locked_at":    campaign.ResultsLockedAt,
+		"published_at": campaign.ResultsPublishedAt,
+	})
+}
+
+func (s *FiberServer) lockResultsHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid campaign ID"})
+	}
+
+	if err := s.db.LockResults(c.Context(), id); err != nil {
+		return judgingError(c, err, "Failed to lock results")
+	}
+	return c.JSON(fiber.Map{"message": "Results locked successfully"})
+}
+
+func (s *FiberServer) publishResultsHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid campaign ID"})
+	}
+
+	if err := s.db.PublishResults(c.Context(), id); err != nil {
+		return judgingError(c, err, "Failed to publish results")
+	}
+	return c.JSON(fiber.Map{"message": "Results published successfully"})
+}
+
+// getJudgeCampaignsHandler lists the campaigns the signed-in admin judges.
+func (s *FiberServer) getJudgeCampaignsHandler(c *fiber.Ctx) error {
+	campaigns, err := s.db.GetJudgeCampaigns(c.Context(), currentAdmin(c).ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch nomination campaigns"})
+	}
+	return c.JSON(fiber.Map{"campaigns": campaigns})
+}
+
+// getJudgeBallotHandler returns what a judge scores in a campaign: the
+// criteria, the shortlist and the judge's own scores so far.
+func (s *FiberServer) getJudgeBallotHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid campaign ID"})
+	}
+	admin := currentAdmin(c)
+
+	judge, err := s.db.IsCampaignJudge(c.Context(), id, admin.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch ballot"})
+	}
+	if !judge {
+		return c.Status(403).JSON(fiber.Map{"error": "You are not a judge for this campaign"})
+	}
+
+	campaign, err := s.db.GetNominationCampaign(c.Context(), id)
+	if err != nil {
+		return campaignError(c, err, "Failed to fetch ballot")
+	}
+	criteria, err := s.db.GetJudgingCriteria(c.Context(), id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch ballot"})
+	}
+	shortlist, err := s.db.GetShortlist(c.Context(), id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch ballot"})
+	}
+	scores, err := s.db.GetJudgeScores(c.Context(), id, admin.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch ballot"})
+	}
+
+	// Final ranks are for the committee
+	for i := range shortlist {
+		shortlist[i].Score, shortlist[i].Rank, shortlist[i].Winner = nil, nil, false
+	}
+
+	return c.JSON(fiber.Map{
+		"campaign":  campaign,
+		"criteria":  criteria,
+		"shortlist": shortlist,
+		"scores":    scores,
+		"max_score": database.MaxJudgeScore,
+	})
+}
+
+func (s *FiberServer) saveJudgeScoresHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid campaign ID"})
+	}
+	entryID, err := strconv.Atoi(c.Params("entryId"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid shortlist entry ID"})
+	}
+
+	var req JudgeScoresRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	scores := make([]database.JudgeScore, 0, len(req.Scores))
+	for _, score := range req.Scores {
+		scores = append(scores, database.JudgeScore{CriterionID: score.CriterionID, Score: score.Score})
+	}
+	if err := s.db.SaveJudgeScores(c.Context(), id, currentAdmin(c).ID, entryID, scores); err != nil {
+		return judgingError(c, err, "Failed to save scores")
+	}
+	return c.JSON(fiber.Map{"message": "Scores saved successfully"})
+}
+
+// getAwardWinnersHandler lists the winners of every published campaign.
+func (s *FiberServer) getAwardWinnersHandler(c *fiber.Ctx) error {
+	winners, err := s.db.GetPublishedWinners(c.Context())
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch award winners"})
+	}
+	if winners == nil {
+		winners = []database.AwardWinner{}
+	}
+	return c.JSON(fiber.Map{"winners": winners})
+}
+
+// judgingError responds to a failed judging change, using fallback for
+// unexpected errors.
+func judgingError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, database.ErrCampaignNotFound):
+		return c.Status(404).JSON(fiber.Map{"error": "Nomination campaign not found"})
+	case errors.Is(err, database.ErrShortlistEntryNotFound):
+		return c.Status(404).JSON(fiber.Map{"error": "Shortlisted nominee not found"})
+	case errors.Is(err, database.ErrNotJudge):
+		return c.Status(403).JSON(fiber.Map{"error": "You are not a judge for this campaign"})
+	case errors.Is(err, database.ErrResultsLocked),
+		errors.Is(err, database.ErrResultsNotLocked),
+		errors.Is(err, database.ErrCampaignStillOpen),
+		errors.Is(err, database.ErrAlreadyShortlisted):
+		return c.Status(409).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, database.ErrUnknownCategory),
+		errors.Is(err, database.ErrNothingToJudge),
+		errors.Is(err, database.ErrInvalidJudge),
+		errors.Is(err, database.ErrNotNominated),
+		errors.Is(err, database.ErrInvalidCriteria),
+		errors.Is(err, database.ErrInvalidScore):
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	default:
+		return c.Status(500).JSON(fiber.Map{"error": fallback})
+	}
+}
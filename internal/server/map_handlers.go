@@ -0,0 +1,146 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"unorcitconnect/internal/database"
+)
+
+const (
+	defaultMapZoom = 2
+	maxMapZoom     = 20
+
+	// clusterCellPixels is the width of a cluster on screen. Map tiles are
+	// 256 pixels wide, so a cell is a quarter of a tile at any zoom.
+	clusterCellPixels = 64
+)
+
+// GeoJSON types for the map endpoint, see RFC 7946.
+type geoJSONPoint struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"` // longitude, latitude
+}
+
+type geoJSONFeature struct {
+	Type       string       `json:"type"`
+	Geometry   geoJSONPoint `json:"geometry"`
+	Properties fiber.Map    `json:"properties"`
+}
+
+// alumniMapResponse is a GeoJSON FeatureCollection of clusters. The counts
+// are foreign members, which GeoJSON readers ignore.
+type alumniMapResponse struct {
+	Type      string                   `json:"type"`
+	BBox      [4]float64               `json:"bbox"`
+	Features  []geoJSONFeature         `json:"features"`
+	Zoom      int                      `json:"zoom"`
+	Countries []database.LocationCount `json:"countries"`
+	Cities    []database.LocationCount `json:"cities"`
+}
+
+// parseMapQuery reads the map filters from the query string: bbox as
+// "minLng,minLat,maxLng,maxLat" (the whole world by default), zoom (0-20),
+// year and course.
+func parseMapQuery(c *fiber.Ctx) (database.MapQuery, int, error) {
+	q := database.MapQuery{
+		MinLat: -90, MinLng: -180,
+		MaxLat: 90, MaxLng: 180,
+		Course: strings.TrimSpace(c.Query("course")),
+	}
+
+	if bbox := c.Query("bbox"); bbox != "" {
+		parts := strings.Split(bbox, ",")
+		if len(parts) != 4 {
+			return q, 0, fmt.Errorf("bbox must be minLng,minLat,maxLng,maxLat")
+		}
+		values := make([]float64, 4)
+		for i, part := range parts {
+			v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+			if err != nil {
+				return q, 0, fmt.Errorf("bbox must be minLng,minLat,maxLng,maxLat")
+			}
+			values[i] = v
+		}
+		q.MinLng, q.MinLat, q.MaxLng, q.MaxLat = values[0], values[1], values[2], values[3]
+
+		if q.MinLat < -90 || q.MaxLat > 90 || q.MinLat > q.MaxLat {
+			return q, 0, fmt.Errorf("bbox latitudes must be between -90 and 90, south first")
+		}
+		if q.MinLng < -180 || q.MaxLng > 180 {
+			return q, 0, fmt.Errorf("bbox longitudes must be between -180 and 180")
+		}
+	}
+
+	zoom, err := queryInt(c, "zoom", defaultMapZoom)
+	if err != nil {
+		return q, 0, err
+	}
+	if zoom < 0 || zoom > maxMapZoom {
+		return q, 0, fmt.Errorf("zoom must be between 0 and %d", maxMapZoom)
+	}
+	q.CellSize = 360 / math.Pow(2, float64(zoom)) * clusterCellPixels / 256
+
+	if q.Year, err = queryInt(c, "year", 0); err != nil {
+		return q, 0, err
+	}
+	return q, zoom, nil
+}
+
+// getAlumniMapHandler returns the alumni in a bounding box clustered for a
+// zoom level, with per-country and per-city counts, as GeoJSON. Locations
+// are only as precise as each alumnus allows.
+func (s *FiberServer) getAlumniMapHandler(c *fiber.Ctx) error {
+	query, zoom, err := parseMapQuery(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	clusters, err := s.db.GetMapClusters(c.Context(), query)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to load map"})
+	}
+	countries, err := s.db.GetCountryCounts(c.Context(), query)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to load map"})
+	}
+	cities, err := s.db.GetCityCounts(c.Context(), query)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to load map"})
+	}
+
+	features := make([]geoJSONFeature, 0, len(clusters))
+	for _, cluster := range clusters {
+		features = append(features, geoJSONFeature{
+			Type: "Feature",
+			Geometry: geoJSONPoint{
+				Type:        "Point",
+				Coordinates: [2]float64{cluster.Longitude, cluster.Latitude},
+			},
+			Properties: fiber.Map{
+				"count":   cluster.Count,
+				"cluster": cluster.Count > 1,
+			},
+		})
+	}
+
+	if countries == nil {
+		countries = []database.LocationCount{}
+	}
+	if cities == nil {
+		cities = []database.LocationCount{}
+	}
+
+	return c.JSON(alumniMapResponse{
+		Type:      "FeatureCollection",
+		BBox:      [4]float64{query.MinLng, query.MinLat, query.MaxLng, query.MaxLat},
+		Features:  features,
+		Zoom:      zoom,
+		Countries: countries,
+		Cities:    cities,
+	}, "application/geo+json")
+}
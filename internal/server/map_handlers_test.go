@@ -0,0 +1,47 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestParseMapQuery(t *testing.T) {
+	app := fiber.New()
+	app.Get("/map", func(c *fiber.Ctx) error {
+		q, zoom, err := parseMapQuery(c)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.JSON(fiber.Map{"query": q, "zoom": zoom})
+	})
+
+	tests := []struct {
+		query    string
+		wantCode int
+	}{
+		{"", http.StatusOK},
+		{"?bbox=120,5,127,20&zoom=6&year=2010&course=BSIT", http.StatusOK},
+		{"?bbox=170,-50,-170,-10", http.StatusOK}, // across the antimeridian
+		{"?bbox=120,5,127", http.StatusBadRequest},
+		{"?bbox=120,north,127,20", http.StatusBadRequest},
+		{"?bbox=120,20,127,5", http.StatusBadRequest},
+		{"?bbox=-200,5,127,20", http.StatusBadRequest},
+		{"?zoom=21", http.StatusBadRequest},
+		{"?zoom=-1", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest("GET", "/map"+tt.query, nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		if resp.StatusCode != tt.wantCode {
+			t.Errorf("%s: expected status %d; got %d", tt.query, tt.wantCode, resp.StatusCode)
+		}
+	}
+}
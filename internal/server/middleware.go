@@ -0,0 +1,111 @@
+package server
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unorcitconnect/internal/auth"
+	"unorcitconnect/internal/database"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	adminLocalsKey            = "admin"
+	adminPermissionsLocalsKey = "admin_permissions"
+)
+
+// requireAdmin validates the bearer access token and loads the admin and its
+// permissions into c.Locals so downstream handlers can use currentAdmin.
+// Admins that still have to change their password are rejected.
+func (s *FiberServer) requireAdmin(c *fiber.Ctx) error {
+	return s.authenticateAdmin(c, false)
+}
+
+// requireAdminPendingPasswordChange is requireAdmin for the routes an admin
+// needs to complete a forced password change.
+func (s *FiberServer) requireAdminPendingPasswordChange(c *fiber.Ctx) error {
+	return s.authenticateAdmin(c, true)
+}
+
+func (s *FiberServer) authenticateAdmin(c *fiber.Ctx, allowPendingPasswordChange bool) error {
+	header := c.Get(fiber.HeaderAuthorization)
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || token == "" {
+		return c.Status(401).JSON(fiber.Map{"error": "Missing authorization token"})
+	}
+
+	claims, err := s.tokens.Parse(token, adminAccessToken)
+	if err != nil {
+		if err == auth.ErrExpiredToken {
+			return c.Status(401).JSON(fiber.Map{"error": "Session expired"})
+		}
+		return c.Status(401).JSON(fiber.Map{"error": "Invalid authorization token"})
+	}
+
+	id, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return c.Status(401).JSON(fiber.Map{"error": "Invalid authorization token"})
+	}
+
+	admin, err := s.db.GetAdminByID(c.Context(), id)
+	if err != nil {
+		return c.Status(401).JSON(fiber.Map{"error": "Invalid authorization token"})
+	}
+
+	if admin.Disabled {
+		return c.Status(403).JSON(fiber.Map{"error": "Admin account is disabled"})
+	}
+
+	if admin.MustChangePassword && !allowPendingPasswordChange {
+		return c.Status(403).JSON(fiber.Map{
+			"error":                "Password change required",
+			"must_change_password": true,
+		})
+	}
+
+	permissions, err := s.db.GetAdminPermissions(c.Context(), admin.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to load permissions"})
+	}
+
+	c.Locals(adminLocalsKey, admin)
+	c.Locals(adminPermissionsLocalsKey, permissions)
+	return c.Next()
+}
+
+// requirePermission must be chained after requireAdmin. The admin needs every
+// listed permission to continue.
+func (s *FiberServer) requirePermission(permissions ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		for _, permission := range permissions {
+			if !hasPermission(c, permission) {
+				return c.Status(403).JSON(fiber.Map{"error": "Missing permission: " + permission})
+			}
+		}
+		return c.Next()
+	}
+}
+
+// requireAnyPermission must be chained after requireAdmin. The admin needs at
+// least one of the listed permissions; the handler decides what each unlocks.
+func (s *FiberServer) requireAnyPermission(permissions ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		for _, permission := range permissions {
+			if hasPermission(c, permission) {
+				return c.Next()
+			}
+		}
+		return c.Status(403).JSON(fiber.Map{"error": "Missing permission: one of " + strings.Join(permissions, ", ")})
+	}
+}
+
+func hasPermission(c *fiber.Ctx, permission string) bool {
+	granted, _ := c.Locals(adminPermissionsLocalsKey).([]string)
+	return slices.Contains(granted, permission)
+}
+
+func currentAdmin(c *fiber.Ctx) *database.Admin {
+	admin, _ := c.Locals(adminLocalsKey).(*database.Admin)
+	return admin
+}
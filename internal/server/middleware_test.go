@@ -0,0 +1,127 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+	"time"
+	"unorcitconnect/internal/auth"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRequirePermission(t *testing.T) {
+	app := fiber.New()
+	s := &FiberServer{App: app}
+
+	grant := func(permissions ...string) fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals(adminPermissionsLocalsKey, permissions)
+			return c.Next()
+		}
+	}
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(http.StatusOK) }
+
+	app.Get("/allowed", grant("sponsorship.confirm"), s.requirePermission("sponsorship.confirm"), ok)
+	app.Get("/denied", grant("sponsorship.confirm"), s.requirePermission("alumni.delete"), ok)
+	app.Get("/partial", grant("alumni.read"), s.requirePermission("alumni.read", "nomination.read"), ok)
+	app.Get("/any", grant("alumni.read"), s.requireAnyPermission("alumni.read", "nomination.read"), ok)
+	app.Get("/none", grant("sponsorship.read"), s.requireAnyPermission("alumni.read", "nomination.read"), ok)
+
+	tests := map[string]int{
+		"/allowed": http.StatusOK,
+		"/denied":  http.StatusForbidden,
+		"/partial": http.StatusForbidden,
+		"/any":     http.StatusOK,
+		"/none":    http.StatusForbidden,
+	}
+	for path, want := range tests {
+		req, err := http.NewRequest("GET", path, nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		if resp.StatusCode != want {
+			t.Errorf("%s: expected status %d; got %d", path, want, resp.StatusCode)
+		}
+	}
+}
+
+func TestRequireVerification(t *testing.T) {
+	app := fiber.New()
+	s := &FiberServer{App: app, tokens: auth.NewTokenManager([]byte("secret"))}
+
+	app.Post("/nominations", s.requireVerification("nomination"), func(c *fiber.Ctx) error {
+		if !verifiedEmailMatches(c, c.Query("email")) {
+			return c.SendStatus(http.StatusForbidden)
+		}
+		return c.SendStatus(http.StatusOK)
+	})
+
+	nominationToken, _, _ := s.issueVerificationToken("Nominator@Example.com", "nomination")
+	registrationToken, _, _ := s.issueVerificationToken("nominator@example.com", "registration")
+
+	tests := []struct {
+		name  string
+		token string
+		email string
+		want  int
+	}{
+		{"missing token", "", "nominator@example.com", http.StatusUnauthorized},
+		{"wrong purpose", registrationToken, "nominator@example.com", http.StatusUnauthorized},
+		{"other email", nominationToken, "someone@example.com", http.StatusForbidden},
+		{"matching email", nominationToken, "nominator@example.com", http.StatusOK},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest("POST", "/nominations?email="+tt.email, nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+		if tt.token != "" {
+			req.Header.Set(verificationTokenHeader, tt.token)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s: expected status %d; got %d", tt.name, tt.want, resp.StatusCode)
+		}
+	}
+}
+
+func TestRequireAlumniRejectsOtherTokens(t *testing.T) {
+	app := fiber.New()
+	s := &FiberServer{App: app, tokens: auth.NewTokenManager([]byte("secret"))}
+
+	app.Get("/me", s.requireAlumni, func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+
+	adminToken, _, _ := s.tokens.Issue(adminAccessToken, "1", time.Minute)
+	verificationToken, _, _ := s.issueVerificationToken("alumni@example.com", "registration")
+
+	tests := map[string]string{
+		"missing token":      "",
+		"admin token":        adminToken,
+		"verification token": verificationToken,
+	}
+	for name, token := range tests {
+		req, err := http.NewRequest("GET", "/me", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("%s: expected status %d; got %d", name, http.StatusUnauthorized, resp.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"unorcitconnect/internal/database"
+)
+
+// Nomination Campaign Handlers
+type CampaignCategoryRequest struct {
+	Name                    string `json:"name"`
+	Description             string `json:"description"`
+	MinYearsSinceGraduation int    `json:"min_years_since_graduation"`
+	MaxYearsSinceGraduation int    `json:"max_years_since_graduation"`
+	RequireNomineeEmail     bool   `json:"require_nominee_email"`
+}
+
+type NominationCampaignRequest struct {
+	Name       string                    `json:"name"`
+	OpensAt    time.Time                 `json:"opens_at"`
+	ClosesAt   time.Time                 `json:"closes_at"`
+	Categories []CampaignCategoryRequest `json:"categories"`
+}
+
+func (r NominationCampaignRequest) campaign() database.NominationCampaign {
+	campaign := database.NominationCampaign{
+		Name:       r.Name,
+		OpensAt:    r.OpensAt,
+		ClosesAt:   r.ClosesAt,
+		Categories: make([]database.CampaignCategory, 0, len(r.Categories)),
+	}
+	for _, category := range r.Categories {
+		campaign.Categories = append(campaign.Categories, database.CampaignCategory{
+			Name:                    category.Name,
+			Description:             category.Description,
+			MinYearsSinceGraduation: category.MinYearsSinceGraduation,
+			MaxYearsSinceGraduation: category.MaxYearsSinceGraduation,
+			RequireNomineeEmail:     category.RequireNomineeEmail,
+		})
+	}
+	return campaign
+}
+
+// getOpenCampaignHandler tells the nomination form whether nominations are
+// open and for which categories.
+func (s *FiberServer) getOpenCampaignHandler(c *fiber.Ctx) error {
+	campaign, err := s.db.GetOpenNominationCampaign(c.Context(), time.Now())
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch nomination campaign"})
+	}
+
+	return c.JSON(fiber.Map{
+		"open":     campaign != nil,
+		"campaign": campaign,
+	})
+}
+
+func (s *FiberServer) listCampaignsHandler(c *fiber.Ctx) error {
+	campaigns, err := s.db.GetNominationCampaigns(c.Context())
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch nomination campaigns"})
+	}
+	return c.JSON(fiber.Map{"campaigns": campaigns})
+}
+
+func (s *FiberServer) getCampaignHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid campaign ID"})
+	}
+
+	campaign, err := s.db.GetNominationCampaign(c.Context(), id)
+	if err != nil {
+		if errors.Is(err, database.ErrCampaignNotFound) {
+			return c.Status(404).JSON(fiber.Map{"error": "Nomination campaign not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch nomination campaign"})
+	}
+	return c.JSON(fiber.Map{"campaign": campaign})
+}
+
+func (s *FiberServer) createCampaignHandler(c *fiber.Ctx) error {
+	var req NominationCampaignRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	campaign := req.campaign()
+	if err := s.db.SaveNominationCampaign(c.Context(), &campaign); err != nil {
+		return campaignError(c, err, "Failed to create nomination campaign")
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"message":  "Nomination campaign created successfully",
+		"campaign": campaign,
+	})
+}
+
+func (s *FiberServer) updateCampaignHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid campaign ID"})
+	}
+
+	var req NominationCampaignRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	campaign := req.campaign()
+	campaign.ID = id
+	if err := s.db.SaveNominationCampaign(c.Context(), &campaign); err != nil {
+		return campaignError(c, err, "Failed to update nomination campaign")
+	}
+
+	return c.JSON(fiber.Map{
+		"message":  "Nomination campaign updated successfully",
+		"campaign": campaign,
+	})
+}
+
+func (s *FiberServer) deleteCampaignHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid campaign ID"})
+	}
+
+	if err := s.db.DeleteNominationCampaign(c.Context(), id); err != nil {
+		return campaignError(c, err, "Failed to delete nomination campaign")
+	}
+	return c.JSON(fiber.Map{"message": "Nomination campaign deleted successfully"})
+}
+
+// campaignError responds to a failed campaign change, using fallback for
+// unexpected errors.
+func campaignError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, database.ErrInvalidCampaign):
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, database.ErrCampaignNotFound):
+		return c.Status(404).JSON(fiber.Map{"error": "Nomination campaign not found"})
+	case errors.Is(err, database.ErrCampaignOverlap):
+		return c.Status(409).JSON(fiber.Map{"error": "The campaign's dates overlap another campaign"})
+	case errors.Is(err, database.ErrCampaignInUse):
+		return c.Status(409).JSON(fiber.Map{"error": "A campaign with nominations cannot be deleted"})
+	case errors.Is(err, database.ErrResultsLocked):
+		return c.Status(409).JSON(fiber.Map{"error": "The campaign's results are locked"})
+	default:
+		return c.Status(500).JSON(fiber.Map{"error": fallback})
+	}
+}
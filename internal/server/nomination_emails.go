@@ -0,0 +1,116 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"unorcitconnect/internal/auth"
+	"unorcitconnect/internal/database"
+	"unorcitconnect/internal/email"
+)
+
+const (
+	emailOptOutTokenKind = "email_opt_out"
+	// Opt-out links sit in inboxes, so they outlive any other token
+	emailOptOutTTL = 2 * 365 * 24 * time.Hour
+)
+
+type EmailOptOutRequest struct {
+	Token string `json:"token"`
+}
+
+// optOutURL is the unsubscribe link for nomination emails to address. The
+// frontend confirms before posting the token in its query string, so mail
+// scanners following the link do not opt anyone out. EMAIL_OPT_OUT_URL
+// overrides the page it opens; paths are resolved against the public URL.
+func (s *FiberServer) optOutURL(address string) (string, error) {
+	token, _, err := s.tokens.Issue(emailOptOutTokenKind, normalizeEmail(address), emailOptOutTTL)
+	if err != nil {
+		return "", err
+	}
+
+	base := os.Getenv("EMAIL_OPT_OUT_URL")
+	if base == "" {
+		base = "/"
+	}
+	separator := "?"
+	if strings.Contains(base, "?") {
+		separator = "&"
+	}
+	return s.absoluteURL(base) + separator + url.Values{"opt_out_token": {token}}.Encode(), nil
+}
+
+// notifyNomination emails the nominee, when their address was given, and
+// sends the nominator a receipt, skipping anyone who opted out. The
+// nomination is saved either way, so failures are only logged.
+func (s *FiberServer) notifyNomination(c *fiber.Ctx, n *database.Nomination) {
+	message := email.NominationEmail{
+		NomineeName:  strings.TrimSpace(n.FirstName + " " + n.LastName),
+		NomineeEmail: strings.TrimSpace(n.NominatedEmail),
+		Year:         n.Year,
+		Category:     n.Category,
+		SubmittedAt:  n.CreatedAt,
+	}
+	if n.CampaignID != nil {
+		if campaign, err := s.db.GetNominationCampaign(c.Context(), *n.CampaignID); err == nil {
+			message.Campaign = campaign.Name
+		}
+	}
+
+	if message.NomineeEmail != "" && !strings.EqualFold(message.NomineeEmail, n.NominatorEmail) {
+		message.NomineeNotified = s.sendNominationEmail(c, n.ID, message.NomineeEmail, message, s.email.SendNomineeCongratulations)
+	}
+	s.sendNominationEmail(c, n.ID, n.NominatorEmail, message, s.email.SendNominationReceipt)
+}
+
+// sendNominationEmail sends message to address with its own opt-out link,
+// reporting whether it was sent.
+func (s *FiberServer) sendNominationEmail(c *fiber.Ctx, nominationID int, address string, message email.NominationEmail, send func(string, email.NominationEmail) error) bool {
+	optedOut, err := s.db.IsEmailOptedOut(c.Context(), address)
+	if err != nil {
+		log.Printf("failed to check opt-out for nomination %d: %v", nominationID, err)
+		return false
+	}
+	if optedOut {
+		return false
+	}
+
+	if message.OptOutURL, err = s.optOutURL(address); err != nil {
+		log.Printf("failed to create opt-out link for nomination %d: %v", nominationID, err)
+		return false
+	}
+	if err := send(address, message); err != nil {
+		log.Printf("failed to send email about nomination %d: %v", nominationID, err)
+		return false
+	}
+	return true
+}
+
+func (s *FiberServer) emailOptOutHandler(c *fiber.Ctx) error {
+	var req EmailOptOutRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	claims, err := s.tokens.Parse(req.Token, emailOptOutTokenKind)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) || errors.Is(err, auth.ErrExpiredToken) {
+			return c.Status(400).JSON(fiber.Map{"error": "This unsubscribe link is invalid or has expired"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to unsubscribe"})
+	}
+
+	if err := s.db.OptOutEmail(c.Context(), claims.Subject); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to unsubscribe"})
+	}
+	return c.JSON(fiber.Map{
+		"message": "You will no longer receive emails about nominations",
+		"email":   claims.Subject,
+	})
+}
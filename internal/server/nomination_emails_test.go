@@ -0,0 +1,37 @@
+package server
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"unorcitconnect/internal/auth"
+)
+
+func TestOptOutURL(t *testing.T) {
+	t.Setenv("EMAIL_OPT_OUT_URL", "")
+	s := &FiberServer{
+		config: Config{PublicURL: "https://alumni.example.com"},
+		tokens: auth.NewTokenManager([]byte("secret")),
+	}
+
+	link, err := s.optOutURL("Nominee@Example.com")
+	if err != nil {
+		t.Fatalf("optOutURL() returned error: %v", err)
+	}
+	if !strings.HasPrefix(link, "https://alumni.example.com/?opt_out_token=") {
+		t.Fatalf("expected link on the public URL; got %q", link)
+	}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("invalid link %q: %v", link, err)
+	}
+	claims, err := s.tokens.Parse(u.Query().Get("opt_out_token"), emailOptOutTokenKind)
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+	if claims.Subject != "nominee@example.com" {
+		t.Errorf("expected the normalized address as subject; got %q", claims.Subject)
+	}
+}
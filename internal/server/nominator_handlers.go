@@ -0,0 +1,121 @@
+package server
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"unorcitconnect/internal/database"
+)
+
+// Nominator Handlers, for nominators signed in through a nomination OTP
+func (s *FiberServer) getOwnNominationsHandler(c *fiber.Ctx) error {
+	nominations, err := s.db.FindNominationsByNominator(c.Context(), verifiedEmail(c))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch nominations"})
+	}
+
+	// Nominations in the open campaign can still be edited
+	campaign, err := s.db.GetOpenNominationCampaign(c.Context(), time.Now())
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch nominations"})
+	}
+	var openCampaignID *int
+	if campaign != nil {
+		openCampaignID = &campaign.ID
+	}
+
+	return c.JSON(fiber.Map{
+		"nominations":      nominations,
+		"open_campaign_id": openCampaignID,
+	})
+}
+
+func (s *FiberServer) updateOwnNominationHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid nomination ID"})
+	}
+
+	var req NominationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	nomination := req.nomination()
+	nomination.ID = id
+
+	if err := s.db.UpdateOwnNomination(c.Context(), verifiedEmail(c), &nomination); err != nil {
+		return nominationError(c, err, "Failed to update nomination")
+	}
+
+	return c.JSON(fiber.Map{
+		"message":    "Nomination updated successfully",
+		"nomination": nomination,
+	})
+}
+
+func (s *FiberServer) withdrawNominationHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid nomination ID"})
+	}
+
+	if err := s.db.WithdrawNomination(c.Context(), verifiedEmail(c), id); err != nil {
+		return nominationError(c, err, "Failed to withdraw nomination")
+	}
+	return c.JSON(fiber.Map{"message": "Nomination withdrawn successfully"})
+}
+
+func (s *FiberServer) getOwnNominationHistoryHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid nomination ID"})
+	}
+
+	history, err := s.db.GetNominationHistory(c.Context(), id, verifiedEmail(c))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch nomination history"})
+	}
+	if len(history) == 0 {
+		return c.Status(404).JSON(fiber.Map{"error": "Nomination not found"})
+	}
+	return c.JSON(fiber.Map{"history": history})
+}
+
+func (s *FiberServer) getNominationHistoryHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid nomination ID"})
+	}
+
+	history, err := s.db.GetNominationHistory(c.Context(), id, "")
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch nomination history"})
+	}
+	return c.JSON(fiber.Map{"history": history})
+}
+
+// nominationError responds to a nomination that could not be saved, changed
+// or withdrawn, using fallback for unexpected errors.
+func nominationError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, database.ErrNominationNotFound):
+		return c.Status(404).JSON(fiber.Map{"error": "Nomination not found"})
+	case errors.Is(err, database.ErrNominationsClosed):
+		return c.Status(403).JSON(fiber.Map{"error": "Nominations are closed"})
+	case errors.Is(err, database.ErrResultsLocked):
+		return c.Status(409).JSON(fiber.Map{"error": "The results of this campaign are final"})
+	case errors.Is(err, database.ErrUnknownCategory):
+		return c.Status(400).JSON(fiber.Map{"error": "Unknown award category"})
+	case errors.Is(err, database.ErrNomineeNotFound):
+		return c.Status(400).JSON(fiber.Map{"error": "The chosen nominee is not in the alumni records"})
+	case errors.Is(err, database.ErrNominationRule):
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, database.ErrDuplicateNomination):
+		return c.Status(409).JSON(fiber.Map{"error": err.Error()})
+	default:
+		return c.Status(500).JSON(fiber.Map{"error": fallback})
+	}
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"unorcitconnect/internal/database"
+)
+
+// minNomineeSearchLength keeps the autocomplete from listing the whole
+// directory a letter at a time.
+const minNomineeSearchLength = 2
+
+// Nominee Handlers
+type MergeNomineesRequest struct {
+	NominationIDs      []int `json:"nomination_ids"`
+	TargetNominationID int   `json:"target_nomination_id"`
+	AlumniID           int   `json:"alumni_id"`
+}
+
+// searchNomineesHandler suggests alumni for the nomination form as the
+// nominator types a name.
+func (s *FiberServer) searchNomineesHandler(c *fiber.Ctx) error {
+	search := strings.TrimSpace(c.Query("q"))
+	if len([]rune(search)) < minNomineeSearchLength {
+		return c.JSON(fiber.Map{"candidates": []database.NomineeCandidate{}})
+	}
+
+	limit, err := queryInt(c, "limit", 10)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	candidates, err := s.db.SearchNomineeCandidates(c.Context(), search, limit)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to search alumni"})
+	}
+	return c.JSON(fiber.Map{"candidates": candidates})
+}
+
+func (s *FiberServer) nomineeMergeSuggestionsHandler(c *fiber.Ctx) error {
+	suggestions, err := s.db.SuggestNomineeMerges(c.Context(), c.Query("category"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to find duplicate nominees"})
+	}
+	return c.JSON(fiber.Map{"suggestions": suggestions})
+}
+
+// mergeNomineesHandler reassigns nominations to one canonical nominee,
+// either an alumnus or the nominee of another nomination.
+func (s *FiberServer) mergeNomineesHandler(c *fiber.Ctx) error {
+	var req MergeNomineesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	merged, err := s.db.MergeNominees(c.Context(), req.NominationIDs, database.MergeTarget{
+		AlumniID:     req.AlumniID,
+		NominationID: req.TargetNominationID,
+	}, currentAdmin(c).Username)
+	if err != nil {
+		switch {
+		case errors.Is(err, database.ErrInvalidMerge):
+			return c.Status(400).JSON(fiber.Map{"error": "Choose the nominations to merge and either an alumnus or a nomination to merge them into"})
+		case errors.Is(err, database.ErrNomineeNotFound):
+			return c.Status(404).JSON(fiber.Map{"error": "Alumni not found"})
+		case errors.Is(err, database.ErrNominationNotFound):
+			return c.Status(404).JSON(fiber.Map{"error": "Target nomination not found"})
+		case errors.Is(err, database.ErrResultsLocked):
+			return c.Status(409).JSON(fiber.Map{"error": "The results of a campaign these nominations belong to are final"})
+		default:
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to merge nominees"})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Nominees merged successfully",
+		"merged":  merged,
+	})
+}
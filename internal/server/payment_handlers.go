@@ -0,0 +1,190 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"unorcitconnect/internal/database"
+	"unorcitconnect/internal/payments"
+)
+
+// checkoutStatuses maps provider events to the Payment status they settle.
+var checkoutStatuses = map[string]string{
+	payments.EventPaid:    database.CheckoutPaid,
+	payments.EventFailed:  database.CheckoutFailed,
+	payments.EventExpired: database.CheckoutExpired,
+}
+
+// Online Payment Handlers
+func (s *FiberServer) createCheckoutHandler(c *fiber.Ctx) error {
+	if s.payments == nil {
+		return c.Status(503).JSON(fiber.Map{"error": "Online payment is not available"})
+	}
+
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid alumni ID"})
+	}
+
+	alumni, err := s.db.GetAlumniByID(c.Context(), id)
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Alumni not found"})
+	}
+	if !verifiedEmailMatches(c, alumni.Email) {
+		return c.Status(403).JSON(fiber.Map{"error": "Email does not match the verified email"})
+	}
+
+	return s.startCheckout(c, alumni)
+}
+
+// startCheckout opens a checkout session for the alumnus's registration fee.
+func (s *FiberServer) startCheckout(c *fiber.Ctx, alumni *database.Alumni) error {
+	if s.payments == nil {
+		return c.Status(503).JSON(fiber.Map{"error": "Online payment is not available"})
+	}
+	if alumni.Paid || alumni.PaymentStatus == database.PaymentApproved {
+		return c.Status(409).JSON(fiber.Map{"error": "Your payment has already been approved"})
+	}
+
+	session, err := s.payments.CreateCheckout(c.Context(), payments.CheckoutRequest{
+		Reference:   fmt.Sprintf("alumni-%d", alumni.ID),
+		Description: s.fee.Description,
+		Amount:      s.fee.Amount,
+		Currency:    s.fee.Currency,
+		Email:       alumni.Email,
+		Name:        strings.TrimSpace(alumni.FirstName + " " + alumni.LastName),
+		SuccessURL:  s.absoluteURL(s.fee.SuccessURL),
+		CancelURL:   s.absoluteURL(s.fee.CancelURL),
+	})
+	if err != nil {
+		log.Printf("failed to create checkout for alumni %d: %v", alumni.ID, err)
+		return c.Status(502).JSON(fiber.Map{"error": "Failed to start online payment"})
+	}
+
+	payment := database.Payment{
+		AlumniID:    alumni.ID,
+		Provider:    s.payments.Name(),
+		SessionID:   session.ID,
+		Amount:      s.fee.Amount,
+		Currency:    s.fee.Currency,
+		Status:      database.CheckoutPending,
+		CheckoutURL: session.URL,
+	}
+	if err := s.db.CreatePayment(c.Context(), &payment); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to start online payment"})
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"checkout_url": session.URL,
+		"session_id":   session.ID,
+		"amount":       payment.Amount,
+		"currency":     payment.Currency,
+	})
+}
+
+// paymentWebhookHandler receives the provider's notifications. Only events
+// with a valid signature are applied; applying one twice has no effect.
+func (s *FiberServer) paymentWebhookHandler(c *fiber.Ctx) error {
+	if s.payments == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Online payment is not available"})
+	}
+
+	event, err := s.payments.ParseWebhook(c.Body(), c.Get(s.payments.SignatureHeader()))
+	if err != nil {
+		log.Printf("rejected payment webhook: %v", err)
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid webhook"})
+	}
+
+	return s.applyPaymentEvent(c, event)
+}
+
+func (s *FiberServer) applyPaymentEvent(c *fiber.Ctx, event *payments.Event) error {
+	status, ok := checkoutStatuses[event.Type]
+	if !ok {
+		return c.JSON(fiber.Map{"received": true})
+	}
+
+	payment, changed, err := s.db.ApplyPaymentEvent(c.Context(), s.payments.Name(), event.SessionID, event.ID, status, event.Amount, event.Currency)
+	if err != nil {
+		switch {
+		case errors.Is(err, database.ErrPaymentNotFound):
+			// Not one of ours, e.g. another app on the same account
+			log.Printf("ignored payment event %s for unknown session %s", event.ID, event.SessionID)
+			return c.JSON(fiber.Map{"received": true})
+		default:
+			log.Printf("failed to apply payment event %s: %v", event.ID, err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to apply payment event"})
+		}
+	}
+
+	if changed && payment.Status == database.CheckoutMismatched {
+		// Acknowledged all the same: the provider would only retry the event
+		log.Printf("payment event %s for session %s paid %d %s, which does not match the checkout", event.ID, event.SessionID, event.Amount, event.Currency)
+	}
+	if changed && payment.Status == database.CheckoutPaid {
+		// The payment is recorded either way; a mail failure is only logged
+		if alumni, err := s.db.GetAlumniByID(c.Context(), payment.AlumniID); err == nil {
+			name := strings.TrimSpace(alumni.FirstName + " " + alumni.LastName)
+			if err := s.email.SendPaymentDecision(alumni.Email, name, true, ""); err != nil {
+				log.Printf("failed to send payment confirmation to alumni %d: %v", alumni.ID, err)
+			}
+		}
+	}
+
+	return c.JSON(fiber.Map{"received": true})
+}
+
+func (s *FiberServer) getPaymentTotalsHandler(c *fiber.Ctx) error {
+	totals, err := s.db.GetPaymentTotals(c.Context())
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch payment totals"})
+	}
+
+	collected := make(map[string]int64)
+	for _, total := range totals {
+		if total.Status == database.CheckoutPaid {
+			collected[total.Currency] += total.Amount
+		}
+	}
+
+	paid := true
+	_, paidAlumni, err := s.db.SearchAlumni(c.Context(), database.AlumniQuery{Paid: &paid, PageSize: 1})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch payment totals"})
+	}
+
+	return c.JSON(fiber.Map{
+		"totals":      totals,
+		"collected":   collected,
+		"paid_alumni": paidAlumni,
+	})
+}
+
+func (s *FiberServer) getAlumniPaymentsHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid alumni ID"})
+	}
+
+	list, err := s.db.GetPayments(c.Context(), id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch payments"})
+	}
+
+	return c.JSON(fiber.Map{"payments": list})
+}
+
+// absoluteURL resolves a path such as "/?payment=success" against the
+// configured public URL, leaving full URLs unchanged. The request's Host
+// header is never used, so clients cannot point links at another site.
+func (s *FiberServer) absoluteURL(target string) string {
+	if strings.HasPrefix(target, "/") {
+		return s.config.PublicURL + target
+	}
+	return target
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"unorcitconnect/internal/database"
+	"unorcitconnect/internal/payments"
+)
+
+func TestPaymentWebhookRequiresSignature(t *testing.T) {
+	fake, err := payments.NewFakeProvider("whsec_test")
+	if err != nil {
+		t.Fatalf("NewFakeProvider() returned error: %v", err)
+	}
+	payload, signature, err := fake.Event(payments.EventPaid, "cs_1", 150000, "PHP")
+	if err != nil {
+		t.Fatalf("Event() returned error: %v", err)
+	}
+	other, _ := payments.NewFakeProvider("whsec_other")
+
+	tests := []struct {
+		name      string
+		provider  payments.Provider
+		signature string
+		want      int
+	}{
+		{"not configured", nil, signature, http.StatusNotFound},
+		{"missing signature", fake, "", http.StatusBadRequest},
+		{"wrong secret", other, signature, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		app := fiber.New()
+		s := &FiberServer{App: app, payments: tt.provider}
+		app.Post("/webhook", s.paymentWebhookHandler)
+
+		req, err := http.NewRequest("POST", "/webhook", strings.NewReader(string(payload)))
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+		req.Header.Set("X-Fake-Signature", tt.signature)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s: expected status %d; got %d", tt.name, tt.want, resp.StatusCode)
+		}
+	}
+}
+
+func TestAbsoluteURLUsesPublicURL(t *testing.T) {
+	s := &FiberServer{config: Config{PublicURL: "https://alumni.example.com"}}
+
+	tests := map[string]string{
+		"/?payment=success":            "https://alumni.example.com/?payment=success",
+		"https://pay.example.com/done": "https://pay.example.com/done",
+	}
+	for target, want := range tests {
+		if got := s.absoluteURL(target); got != want {
+			t.Errorf("absoluteURL(%q) = %q; want %q", target, got, want)
+		}
+	}
+}
+
+// paymentStoreStub marks every paid event as mismatched; calling any other
+// database method panics.
+type paymentStoreStub struct {
+	database.Service
+}
+
+func (paymentStoreStub) ApplyPaymentEvent(ctx context.Context, provider, sessionID, eventID, status string, amount int64, currency string) (*database.Payment, bool, error) {
+	return &database.Payment{SessionID: sessionID, Status: database.CheckoutMismatched}, true, nil
+}
+
+func TestPaymentWebhookAcknowledgesMismatch(t *testing.T) {
+	fake, err := payments.NewFakeProvider("whsec_test")
+	if err != nil {
+		t.Fatalf("NewFakeProvider() returned error: %v", err)
+	}
+	payload, signature, err := fake.Event(payments.EventPaid, "cs_1", 100, "PHP")
+	if err != nil {
+		t.Fatalf("Event() returned error: %v", err)
+	}
+
+	app := fiber.New()
+	s := &FiberServer{App: app, db: paymentStoreStub{}, payments: fake}
+	app.Post("/webhook", s.paymentWebhookHandler)
+
+	req, err := http.NewRequest("POST", "/webhook", strings.NewReader(string(payload)))
+	if err != nil {
+		t.Fatalf("error creating request. Err: %v", err)
+	}
+	req.Header.Set("X-Fake-Signature", signature)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error making request to server. Err: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected a mismatched payment to be acknowledged; got %d", resp.StatusCode)
+	}
+}
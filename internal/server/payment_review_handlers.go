@@ -0,0 +1,90 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"unorcitconnect/internal/database"
+)
+
+// Payment Review Handlers
+type ReviewPaymentRequest struct {
+	Reason string `json:"reason"`
+}
+
+func (s *FiberServer) startPaymentReviewHandler(c *fiber.Ctx) error {
+	return s.reviewPayment(c, database.PaymentUnderReview)
+}
+
+func (s *FiberServer) approvePaymentHandler(c *fiber.Ctx) error {
+	return s.reviewPayment(c, database.PaymentApproved)
+}
+
+func (s *FiberServer) rejectPaymentHandler(c *fiber.Ctx) error {
+	return s.reviewPayment(c, database.PaymentRejected)
+}
+
+// reviewPayment moves the alumnus's payment to status and, for approvals and
+// rejections, emails them the decision.
+func (s *FiberServer) reviewPayment(c *fiber.Ctx, status string) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid alumni ID"})
+	}
+
+	var req ReviewPaymentRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+		}
+	}
+	reason := strings.TrimSpace(req.Reason)
+
+	if _, err := s.db.GetAlumniByID(c.Context(), id); err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Alumni not found"})
+	}
+
+	alumni, err := s.db.ReviewPayment(c.Context(), id, currentAdmin(c), status, reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, database.ErrRejectionReasonRequired):
+			return c.Status(400).JSON(fiber.Map{"error": "A reason is required to reject a payment"})
+		case errors.Is(err, database.ErrInvalidPaymentTransition):
+			return c.Status(409).JSON(fiber.Map{"error": "Payment cannot be moved to " + status + " from its current status"})
+		default:
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to review payment"})
+		}
+	}
+
+	if status == database.PaymentApproved || status == database.PaymentRejected {
+		// The decision is recorded either way; a mail failure is only logged
+		name := strings.TrimSpace(alumni.FirstName + " " + alumni.LastName)
+		if err := s.email.SendPaymentDecision(alumni.Email, name, status == database.PaymentApproved, reason); err != nil {
+			log.Printf("failed to send payment decision to alumni %d: %v", alumni.ID, err)
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message":        "Payment review recorded",
+		"payment_status": alumni.PaymentStatus,
+		"paid":           alumni.Paid,
+	})
+}
+
+func (s *FiberServer) getPaymentReviewsHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid alumni ID"})
+	}
+
+	reviews, err := s.db.GetPaymentReviews(c.Context(), id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch payment reviews"})
+	}
+
+	return c.JSON(fiber.Map{"reviews": reviews})
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unorcitconnect/internal/database"
+	"unorcitconnect/internal/ratelimit"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// OTP send limits. The per-email rules stop inbox spam against one address,
+// the per-IP rules stop a single client from cycling through addresses.
+var (
+	otpEmailCooldown = ratelimit.Rule{Name: "otp_email_minute", Limit: 1, Window: time.Minute}
+	otpEmailHourly   = ratelimit.Rule{Name: "otp_email_hour", Limit: 5, Window: time.Hour}
+	otpIPMinute      = ratelimit.Rule{Name: "otp_ip_minute", Limit: 5, Window: time.Minute}
+	otpIPHourly      = ratelimit.Rule{Name: "otp_ip_hour", Limit: 30, Window: time.Hour}
+)
+
+// OTP verify limits. Each code already allows only a few guesses; these stop
+// a client from guessing across many codes and addresses.
+var (
+	otpVerifyEmailHourly = ratelimit.Rule{Name: "otp_verify_email_hour", Limit: 20, Window: time.Hour}
+	otpVerifyIPMinute    = ratelimit.Rule{Name: "otp_verify_ip_minute", Limit: 10, Window: time.Minute}
+	otpVerifyIPHourly    = ratelimit.Rule{Name: "otp_verify_ip_hour", Limit: 60, Window: time.Hour}
+)
+
+// newLimiter picks the counter backend from RATE_LIMIT_BACKEND ("postgres",
+// the default, or "memory").
+func newLimiter(db database.Service) *ratelimit.Limiter {
+	switch backend := os.Getenv("RATE_LIMIT_BACKEND"); backend {
+	case "memory":
+		return ratelimit.New(ratelimit.NewMemoryStore())
+	case "", "postgres":
+		return ratelimit.New(ratelimit.NewPostgresStore(db))
+	default:
+		log.Printf("Warning: unknown RATE_LIMIT_BACKEND %q, using postgres", backend)
+		return ratelimit.New(ratelimit.NewPostgresStore(db))
+	}
+}
+
+// otpSendRateLimit rejects OTP send requests with 429 once the target email
+// or the client IP has requested too many codes.
+func (s *FiberServer) otpSendRateLimit(c *fiber.Ctx) error {
+	var req SendOTPRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	checks := []ratelimit.Check{
+		{Rule: otpIPMinute, Key: c.IP()},
+		{Rule: otpIPHourly, Key: c.IP()},
+	}
+	if email != "" {
+		checks = append(checks,
+			ratelimit.Check{Rule: otpEmailCooldown, Key: email},
+			ratelimit.Check{Rule: otpEmailHourly, Key: email},
+		)
+	}
+
+	result, err := s.limiter.AllowAll(c.Context(), checks...)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check rate limit"})
+	}
+	if !result.Allowed {
+		return tooManyRequests(c, result.RetryAfter)
+	}
+
+	return c.Next()
+}
+
+// otpVerifyRateLimit rejects OTP and sign-in link verifications with 429
+// once the email or the client IP has made too many attempts.
+func (s *FiberServer) otpVerifyRateLimit(c *fiber.Ctx) error {
+	var req VerifyOTPRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	checks := []ratelimit.Check{
+		{Rule: otpVerifyIPMinute, Key: c.IP()},
+		{Rule: otpVerifyIPHourly, Key: c.IP()},
+	}
+	if email != "" {
+		checks = append(checks, ratelimit.Check{Rule: otpVerifyEmailHourly, Key: email})
+	}
+
+	result, err := s.limiter.AllowAll(c.Context(), checks...)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check rate limit"})
+	}
+	if !result.Allowed {
+		return tooManyRequests(c, result.RetryAfter)
+	}
+
+	return c.Next()
+}
+
+// tooManyRequests writes the standard 429 response with a Retry-After header.
+func tooManyRequests(c *fiber.Ctx, retryAfter time.Duration) error {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(seconds))
+	return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+		"error":       "Too many requests, please try again later",
+		"retry_after": seconds,
+	})
+}
@@ -2,6 +2,8 @@ package server
 
 import (
 	"fmt"
+	"unorcitconnect/internal/database"
+	"unorcitconnect/internal/payments"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -12,7 +14,7 @@ func (s *FiberServer) RegisterFiberRoutes() {
 	s.App.Use(cors.New(cors.Config{
 		AllowOrigins:     "*",
 		AllowMethods:     "GET,POST,PUT,DELETE,OPTIONS,PATCH",
-		AllowHeaders:     "Accept,Authorization,Content-Type",
+		AllowHeaders:     "Accept,Authorization,Content-Type,X-Verification-Token",
 		AllowCredentials: false, // credentials require explicit origins
 		MaxAge:           300,
 	}))
@@ -23,28 +25,54 @@ func (s *FiberServer) RegisterFiberRoutes() {
 	api := s.App.Group("/api")
 
 	// OTP routes
-	api.Post("/otp/send", s.sendOTPHandler)
-	api.Post("/otp/verify", s.verifyOTPHandler)
+	api.Post("/otp/send", s.otpSendRateLimit, s.sendOTPHandler)
+	api.Post("/otp/verify", s.otpVerifyRateLimit, s.verifyOTPHandler)
 
 	// Alumni routes
 	api.Get("/alumni", s.getAllAlumniHandler)
 	api.Get("/alumni/locations", s.getAlumniLocationsHandler)
-	api.Get("/alumni/:id/payment-proof", s.getPaymentProofHandler)
-	api.Post("/alumni/:id/payment-proof", s.uploadPaymentProofHandler)
-	api.Post("/alumni", func(c *fiber.Ctx) error {
+	api.Get("/alumni/map", s.getAlumniMapHandler)
+	api.Post("/alumni/:id/payment-proof", s.requireVerification("registration"), s.uploadPaymentProofHandler)
+	api.Post("/alumni", s.requireVerification("registration"), func(c *fiber.Ctx) error {
 		fmt.Printf("🎯 POST /alumni route hit! URL: %s\n", c.OriginalURL())
 		return s.createAlumniHandler(c)
 	})
-	api.Put("/alumni/:id", func(c *fiber.Ctx) error {
+	api.Put("/alumni/:id", s.requireVerification("registration"), func(c *fiber.Ctx) error {
 		fmt.Printf("🎯 PUT /alumni/:id route hit! URL: %s\n", c.OriginalURL())
 		return s.updateAlumniHandler(c)
 	})
+	api.Post("/alumni/:id/checkout", s.requireVerification("registration"), s.createCheckoutHandler)
 	api.Get("/check-alumni-email", s.checkAlumniEmailHandler) // Check if email exists
 
+	// Online payment webhook, authenticated by the provider's signature
+	api.Post("/payments/webhook", s.paymentWebhookHandler)
+
+	// Alumni self-service routes, signed in through an emailed magic link
+	api.Post("/alumni/login", s.otpSendRateLimit, s.requestLoginLinkHandler)
+	api.Post("/alumni/login/verify", s.otpVerifyRateLimit, s.verifyLoginLinkHandler)
+	me := api.Group("/me", s.requireAlumni)
+	me.Get("/", s.getProfileHandler)
+	me.Put("/", s.updateProfileHandler)
+	me.Get("/payment-proof", s.getOwnPaymentProofHandler)
+	me.Post("/payment-proof", s.uploadOwnPaymentProofHandler)
+	me.Put("/privacy", s.updatePrivacyHandler)
+	me.Get("/payment-history", s.getOwnPaymentReviewsHandler)
+	me.Post("/checkout", s.startOwnCheckoutHandler)
+
 	// Nomination routes
-	api.Post("/nominations", s.createNominationHandler)
-	api.Get("/nominations", s.getNominationsHandler)
+	api.Post("/nominations", s.requireVerification("nomination"), s.createNominationHandler)
+	// Listing carries nominator and nominee emails, so it is for admins only
+	api.Get("/nominations", s.requireAdmin, s.requirePermission(database.PermNominationRead), s.getNominationsHandler)
 	api.Get("/nominations/grouped", s.getGroupedNominationsHandler)
+	api.Get("/nominations/campaign", s.getOpenCampaignHandler)
+	mine := api.Group("/nominations/mine", s.requireVerification("nomination"))
+	mine.Get("/", s.getOwnNominationsHandler)
+	mine.Put("/:id", s.updateOwnNominationHandler)
+	mine.Delete("/:id", s.withdrawNominationHandler)
+	mine.Get("/:id/history", s.getOwnNominationHistoryHandler)
+	api.Get("/awards/winners", s.getAwardWinnersHandler)
+	api.Get("/nominees/search", s.requireVerification("nomination"), s.searchNomineesHandler)
+	api.Post("/email/opt-out", s.emailOptOutHandler)
 
 	// Countries routes
 	api.Get("/countries", s.GetCountries)
@@ -53,23 +81,102 @@ func (s *FiberServer) RegisterFiberRoutes() {
 	api.Get("/courses", s.GetCourses)
 
 	// Admin routes
+	api.Get("/admin/setup", s.adminSetupStatusHandler)
+	api.Post("/admin/setup", s.adminSetupHandler)
 	api.Post("/admin/login", s.adminLoginHandler)
-	api.Post("/admin/create", s.createAdminHandler)
-	api.Get("/admin/dashboard", s.adminDashboardHandler)
-
-	// Delete routes (Superuser only)
-	api.Delete("/alumni/:id", s.deleteAlumniHandler)
-	api.Delete("/nominations/:id", s.deleteNominationHandler)
-	api.Delete("/sponsorships/:id", s.deleteSponsorshipHandler)
+	api.Post("/admin/refresh", s.refreshAdminSessionHandler)
+	api.Post("/admin/create", s.requireAdmin, s.requirePermission(database.PermAdminManage), s.createAdminHandler)
+	api.Get("/admin/dashboard", s.requireAdmin, s.requireAnyPermission(database.PermAlumniRead, database.PermNominationRead), s.adminDashboardHandler)
+	api.Get("/admin/roles", s.requireAdmin, s.requirePermission(database.PermAdminManage), s.getRolesHandler)
+
+	// Admin user management routes
+	api.Put("/admin/users/me/password", s.requireAdminPendingPasswordChange, s.changeOwnPasswordHandler)
+	adminUsers := api.Group("/admin/users", s.requireAdmin, s.requirePermission(database.PermAdminManage))
+	adminUsers.Get("/", s.listAdminsHandler)
+	adminUsers.Post("/", s.createAdminHandler)
+	adminUsers.Put("/:id/roles", s.setAdminRolesHandler)
+	adminUsers.Post("/:id/disable", s.disableAdminHandler)
+	adminUsers.Post("/:id/enable", s.enableAdminHandler)
+	adminUsers.Post("/:id/reset-password", s.resetAdminPasswordHandler)
+	adminUsers.Delete("/:id", s.deleteAdminHandler)
+
+	// Full alumni records, unlike the public /alumni list
+	api.Get("/admin/alumni", s.requireAdmin, s.requirePermission(database.PermAlumniRead), s.getAdminAlumniHandler)
+	api.Get("/admin/alumni/:id/payment-proof", s.requireAdmin, s.requirePermission(database.PermPaymentReview), s.getPaymentProofHandler)
+
+	// Payment review routes
+	paymentReviews := api.Group("/admin/alumni/:id/payment", s.requireAdmin, s.requirePermission(database.PermPaymentReview))
+	paymentReviews.Get("/reviews", s.getPaymentReviewsHandler)
+	paymentReviews.Post("/review", s.startPaymentReviewHandler)
+	paymentReviews.Post("/approve", s.approvePaymentHandler)
+	paymentReviews.Post("/reject", s.rejectPaymentHandler)
+
+	// Nomination campaign routes
+	campaigns := api.Group("/admin/nomination-campaigns", s.requireAdmin, s.requirePermission(database.PermNominationManage))
+	campaigns.Get("/", s.listCampaignsHandler)
+	campaigns.Post("/", s.createCampaignHandler)
+	campaigns.Get("/judge-candidates", s.getJudgeCandidatesHandler)
+	campaigns.Get("/:id", s.getCampaignHandler)
+	campaigns.Put("/:id", s.updateCampaignHandler)
+	campaigns.Delete("/:id", s.deleteCampaignHandler)
+
+	// Judging routes
+	campaigns.Get("/:id/tally", s.getCampaignTallyHandler)
+	campaigns.Get("/:id/shortlist", s.getShortlistHandler)
+	campaigns.Post("/:id/shortlist", s.addToShortlistHandler)
+	campaigns.Delete("/:id/shortlist/:entryId", s.removeFromShortlistHandler)
+	campaigns.Get("/:id/criteria", s.getJudgingCriteriaHandler)
+	campaigns.Put("/:id/criteria", s.setJudgingCriteriaHandler)
+	campaigns.Get("/:id/judges", s.getCampaignJudgesHandler)
+	campaigns.Put("/:id/judges", s.setCampaignJudgesHandler)
+	campaigns.Get("/:id/rankings", s.getRankingsHandler)
+	campaigns.Post("/:id/lock", s.lockResultsHandler)
+	campaigns.Post("/:id/publish", s.publishResultsHandler)
+
+	judging := api.Group("/judging", s.requireAdmin, s.requirePermission(database.PermNominationJudge))
+	judging.Get("/campaigns", s.getJudgeCampaignsHandler)
+	judging.Get("/campaigns/:id", s.getJudgeBallotHandler)
+	judging.Put("/campaigns/:id/shortlist/:entryId/scores", s.saveJudgeScoresHandler)
+
+	// Nominee de-duplication routes
+	api.Get("/admin/nominees/suggestions", s.requireAdmin, s.requirePermission(database.PermNominationRead), s.nomineeMergeSuggestionsHandler)
+	api.Post("/admin/nominees/merge", s.requireAdmin, s.requirePermission(database.PermNominationManage), s.mergeNomineesHandler)
+	api.Get("/admin/nominations/:id/history", s.requireAdmin, s.requirePermission(database.PermNominationRead), s.getNominationHistoryHandler)
+
+	// Online payment reporting routes
+	api.Get("/admin/payments/totals", s.requireAdmin, s.requirePermission(database.PermPaymentRead), s.getPaymentTotalsHandler)
+	api.Get("/admin/alumni/:id/payments", s.requireAdmin, s.requirePermission(database.PermPaymentRead), s.getAlumniPaymentsHandler)
+
+	// Export routes
+	api.Get("/admin/export/alumni", s.requireAdmin, s.requirePermission(database.PermAlumniRead), s.exportAlumniHandler)
+	api.Get("/admin/export/nominations", s.requireAdmin, s.requirePermission(database.PermNominationExport), s.exportNominationsHandler)
+	api.Get("/admin/export/sponsorships", s.requireAdmin, s.requirePermission(database.PermSponsorshipRead), s.exportSponsorshipsHandler)
+
+	// Import routes
+	api.Post("/admin/import/alumni", s.requireAdmin, s.requirePermission(database.PermAlumniWrite), s.importAlumniHandler)
+
+	// Delete routes
+	api.Delete("/alumni/:id", s.requireAdmin, s.requirePermission(database.PermAlumniDelete), s.deleteAlumniHandler)
+	api.Delete("/nominations/:id", s.requireAdmin, s.requirePermission(database.PermNominationDelete), s.deleteNominationHandler)
+	api.Delete("/sponsorships/:id", s.requireAdmin, s.requirePermission(database.PermSponsorshipDelete), s.deleteSponsorshipHandler)
 
 	// Sponsorship routes
-	api.Post("/sponsorships", s.createSponsorshipHandler)
+	api.Post("/sponsorships", s.requireVerification("sponsorship"), s.createSponsorshipHandler)
 	api.Get("/sponsorships", s.getAllSponsorshipsHandler)
 	api.Get("/sponsorships/email/:email", s.getSponsorshipByEmailHandler)
-	api.Put("/sponsorships/:id", s.updateSponsorshipHandler)
-	api.Put("/sponsorships/:id/confirm", s.updateSponsorshipConfirmationHandler)
+	api.Put("/sponsorships/:id", s.requireVerification("sponsorship"), s.updateSponsorshipHandler)
+	api.Put("/sponsorships/:id/confirm", s.requireAdmin, s.requirePermission(database.PermSponsorshipConfirm), s.updateSponsorshipConfirmationHandler)
 	api.Get("/sponsorships/stats", s.getSponsorshipStatsHandler)
 
+	// Development routes
+	if s.config.DevMode && s.email.Catcher() != nil {
+		api.Get("/dev/mail", s.getCaughtMailHandler)
+		api.Delete("/dev/mail", s.clearCaughtMailHandler)
+	}
+	if _, ok := s.payments.(*payments.FakeProvider); ok && s.config.DevMode {
+		api.Post("/dev/payments/:session/complete", s.completeFakePaymentHandler)
+	}
+
 	// Serve static files from frontend/dist (SPA fallback)
 	s.App.Static("/", "./frontend/dist")
 
@@ -1,29 +1,78 @@
 package server
 
 import (
+	"context"
+	"log"
+	"os"
+
 	"github.com/gofiber/fiber/v2"
 
+	"unorcitconnect/internal/auth"
 	"unorcitconnect/internal/database"
 	"unorcitconnect/internal/email"
+	"unorcitconnect/internal/payments"
+	"unorcitconnect/internal/ratelimit"
+	"unorcitconnect/internal/storage"
+	"unorcitconnect/internal/upload"
 )
 
 type FiberServer struct {
 	*fiber.App
 
-	db    database.Service
-	email *email.EmailService
+	config  Config
+	db      database.Service
+	email   *email.EmailService
+	tokens  *auth.TokenManager
+	setup   setupState
+	limiter *ratelimit.Limiter
+	blobs   storage.BlobStore
+	uploads *upload.Validator
+
+	// payments is nil when online payment is not configured
+	payments payments.Provider
+	fee      payments.Fee
 }
 
 func New() *FiberServer {
+	config := LoadConfig()
 	server := &FiberServer{
 		App: fiber.New(fiber.Config{
 			ServerHeader: "unorcitconnect",
 			AppName:      "unorcitconnect",
+			// Set PROXY_HEADER (e.g. X-Forwarded-For) when running behind a
+			// reverse proxy so rate limits see the real client IP.
+			ProxyHeader: os.Getenv("PROXY_HEADER"),
 		}),
 
-		db:    database.New(),
-		email: email.NewEmailService(),
+		config:  config,
+		db:      database.New(),
+		email:   email.NewEmailService(config.DevMode),
+		tokens:  auth.NewTokenManagerFromEnv(),
+		uploads: upload.NewFromEnv(),
+	}
+
+	blobs, err := storage.NewFromEnv()
+	if err != nil {
+		log.Fatalf("failed to configure file storage: %v", err)
 	}
+	server.blobs = blobs
+
+	provider, err := payments.NewFromEnv()
+	if err != nil {
+		log.Fatalf("failed to configure online payment: %v", err)
+	}
+	if provider != nil {
+		fee, err := payments.FeeFromEnv()
+		if err != nil {
+			log.Fatalf("failed to configure online payment: %v", err)
+		}
+		server.payments, server.fee = provider, fee
+	}
+
+	server.limiter = newLimiter(server.db)
+	server.seedAdmin()
+	server.prepareAdminSetup()
+	server.migrateLegacyPaymentProofs(context.Background())
 
 	return server
 }
@@ -0,0 +1,322 @@
+package upload
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+var errMalformed = errors.New("file is corrupt or truncated")
+
+// StripMetadata removes EXIF (and similar) metadata, which can include the
+// GPS location a photo was taken at, from JPEG, PNG and HEIC images. Other
+// types are returned unchanged.
+func StripMetadata(contentType string, data []byte) ([]byte, error) {
+	switch contentType {
+	case TypeJPEG:
+		return stripJPEG(data)
+	case TypePNG:
+		return stripPNG(data)
+	case TypeHEIC:
+		return stripHEIC(data)
+	}
+	return data, nil
+}
+
+// stripJPEG drops APP1 segments carrying EXIF or XMP data. Everything from
+// the start of scan onwards is image data and is copied unchanged.
+func stripJPEG(data []byte) ([]byte, error) {
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:2]) // SOI
+
+	i := 2
+	for i < len(data) {
+		if data[i] != 0xFF {
+			return nil, errMalformed
+		}
+		// Markers may be preceded by any number of 0xFF fill bytes.
+		for i+1 < len(data) && data[i+1] == 0xFF {
+			i++
+		}
+		if i+1 >= len(data) {
+			return nil, errMalformed
+		}
+		marker := data[i+1]
+
+		switch {
+		case marker == 0xD9 || marker == 0xDA: // EOI, SOS
+			out.Write(data[i:])
+			return out.Bytes(), nil
+		case marker == 0x01 || marker >= 0xD0 && marker <= 0xD7: // no length
+			out.Write(data[i : i+2])
+			i += 2
+			continue
+		}
+
+		if i+4 > len(data) {
+			return nil, errMalformed
+		}
+		end := i + 2 + int(binary.BigEndian.Uint16(data[i+2:i+4]))
+		if end > len(data) || end < i+4 {
+			return nil, errMalformed
+		}
+
+		payload := data[i+4 : end]
+		isMetadata := marker == 0xE1 &&
+			(bytes.HasPrefix(payload, []byte("Exif\x00")) || bytes.HasPrefix(payload, []byte("http://ns.adobe.com/xap/")))
+		if !isMetadata {
+			out.Write(data[i:end])
+		}
+		i = end
+	}
+	return out.Bytes(), nil
+}
+
+var pngMetadataChunks = map[string]bool{"eXIf": true, "tEXt": true, "zTXt": true, "iTXt": true, "tIME": true}
+
+// stripPNG drops the chunks that carry EXIF, text and timestamp metadata.
+// Chunks are self-contained, so no checksums need updating.
+func stripPNG(data []byte) ([]byte, error) {
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:8]) // signature
+
+	i := 8
+	for i < len(data) {
+		if i+8 > len(data) {
+			return nil, errMalformed
+		}
+		length := int(binary.BigEndian.Uint32(data[i : i+4]))
+		chunkType := string(data[i+4 : i+8])
+		end := i + 12 + length
+		if length < 0 || end > len(data) || end < i {
+			return nil, errMalformed
+		}
+
+		if !pngMetadataChunks[chunkType] {
+			out.Write(data[i:end])
+		}
+		i = end
+		if chunkType == "IEND" {
+			break
+		}
+	}
+	return out.Bytes(), nil
+}
+
+// stripHEIC blanks the payload of every Exif item. HEIF locates items by
+// absolute offset, so the bytes are zeroed in place rather than removed,
+// which keeps every other offset in the file valid.
+func stripHEIC(data []byte) ([]byte, error) {
+	out := append([]byte(nil), data...)
+
+	meta, ok := findBox(out, 0, len(out), "meta")
+	if !ok {
+		return out, nil
+	}
+	// meta is a full box: skip version and flags.
+	metaStart, metaEnd := meta.body+4, meta.end
+	if metaStart > metaEnd {
+		return nil, errMalformed
+	}
+
+	exifItems, err := heifExifItems(out, metaStart, metaEnd)
+	if err != nil || len(exifItems) == 0 {
+		return out, err
+	}
+
+	idatStart := -1
+	if idat, ok := findBox(out, metaStart, metaEnd, "idat"); ok {
+		idatStart = idat.body
+	}
+
+	iloc, ok := findBox(out, metaStart, metaEnd, "iloc")
+	if !ok {
+		return nil, errMalformed
+	}
+	extents, err := heifItemExtents(out[iloc.body:iloc.end], exifItems)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range extents {
+		// Offsets and lengths come from the file, so compare by
+		// subtracting: adding them could overflow
+		start := e.offset
+		if e.inIdat {
+			if idatStart < 0 || start > len(out)-idatStart {
+				return nil, errMalformed
+			}
+			start += idatStart
+		}
+		if start < 0 || e.length < 0 || start > len(out) || e.length > len(out)-start {
+			return nil, errMalformed
+		}
+		clear(out[start : start+e.length])
+	}
+	return out, nil
+}
+
+type box struct {
+	body, end int // payload start and end offsets
+}
+
+// findBox returns the first box of the given type between start and end.
+func findBox(data []byte, start, end int, boxType string) (box, bool) {
+	for i := start; i+8 <= end; {
+		size := int(binary.BigEndian.Uint32(data[i : i+4]))
+		header := 8
+		switch size {
+		case 0:
+			size = end - i
+		case 1:
+			if i+16 > end {
+				return box{}, false
+			}
+			size = int(binary.BigEndian.Uint64(data[i+8 : i+16]))
+			header = 16
+		}
+		if size < header || size > end-i {
+			return box{}, false
+		}
+		if string(data[i+4:i+8]) == boxType {
+			return box{body: i + header, end: i + size}, true
+		}
+		i += size
+	}
+	return box{}, false
+}
+
+// heifExifItems returns the IDs of items whose type is "Exif" in the iinf box.
+func heifExifItems(data []byte, start, end int) (map[uint32]bool, error) {
+	iinf, ok := findBox(data, start, end, "iinf")
+	if !ok {
+		return nil, nil
+	}
+	if iinf.body+4 > iinf.end {
+		return nil, errMalformed
+	}
+	i := iinf.body + 4
+	if data[iinf.body] == 0 {
+		i += 2
+	} else {
+		i += 4
+	}
+
+	items := make(map[uint32]bool)
+	for i < iinf.end {
+		infe, ok := findBox(data, i, iinf.end, "infe")
+		if !ok {
+			break
+		}
+		p := infe.body
+		if p+4 > infe.end {
+			return nil, errMalformed
+		}
+		version := data[p]
+		p += 4
+		if version >= 2 {
+			var id uint32
+			if version == 2 {
+				if p+8 > infe.end {
+					return nil, errMalformed
+				}
+				id = uint32(binary.BigEndian.Uint16(data[p:]))
+				p += 2
+			} else {
+				if p+10 > infe.end {
+					return nil, errMalformed
+				}
+				id = binary.BigEndian.Uint32(data[p:])
+				p += 4
+			}
+			p += 2 // item_protection_index
+			if string(data[p:p+4]) == "Exif" {
+				items[id] = true
+			}
+		}
+		i = infe.end
+	}
+	return items, nil
+}
+
+type extent struct {
+	offset, length int
+	inIdat         bool
+}
+
+// heifItemExtents parses an iloc box body and returns the extents of items.
+func heifItemExtents(b []byte, items map[uint32]bool) ([]extent, error) {
+	r := &reader{b: b}
+	version := r.uint(1)
+	r.skip(3) // flags
+	sizes := r.uint(2)
+	offsetSize, lengthSize := int(sizes>>12&0xF), int(sizes>>8&0xF)
+	baseOffsetSize, indexSize := int(sizes>>4&0xF), int(sizes&0xF)
+	if version != 1 && version != 2 {
+		indexSize = 0
+	}
+
+	itemCount := r.uint(2)
+	if version == 2 {
+		itemCount = r.uint(4)
+	}
+
+	var extents []extent
+	for n := 0; n < int(itemCount) && r.err == nil; n++ {
+		var id uint32
+		if version < 2 {
+			id = uint32(r.uint(2))
+		} else {
+			id = uint32(r.uint(4))
+		}
+		constructionMethod := 0
+		if version == 1 || version == 2 {
+			constructionMethod = int(r.uint(2) & 0xF)
+		}
+		r.skip(2) // data_reference_index
+		base := int(r.uint(baseOffsetSize))
+		extentCount := int(r.uint(2))
+
+		for e := 0; e < extentCount && r.err == nil; e++ {
+			r.skip(indexSize)
+			offset := int(r.uint(offsetSize))
+			length := int(r.uint(lengthSize))
+			if base < 0 || offset < 0 || length < 0 || offset > math.MaxInt-base {
+				return nil, errMalformed
+			}
+			if items[id] && constructionMethod <= 1 {
+				extents = append(extents, extent{offset: base + offset, length: length, inIdat: constructionMethod == 1})
+			}
+		}
+	}
+	return extents, r.err
+}
+
+// reader reads big-endian integers of 0 to 8 bytes, recording overruns.
+type reader struct {
+	b   []byte
+	pos int
+	err error
+}
+
+func (r *reader) uint(n int) uint64 {
+	if r.err != nil || r.pos+n > len(r.b) {
+		r.err = errMalformed
+		return 0
+	}
+	var v uint64
+	for _, c := range r.b[r.pos : r.pos+n] {
+		v = v<<8 | uint64(c)
+	}
+	r.pos += n
+	return v
+}
+
+func (r *reader) skip(n int) {
+	r.uint(0)
+	if r.err == nil && r.pos+n > len(r.b) {
+		r.err = errMalformed
+		return
+	}
+	r.pos += n
+}
@@ -0,0 +1,48 @@
+package upload
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+var heicBrands = map[string]bool{
+	"heic": true, "heix": true, "heim": true, "heis": true,
+	"hevc": true, "hevx": true, "mif1": true, "msf1": true,
+}
+
+// Sniff identifies data by its magic bytes and returns one of the Type
+// constants, or "" when the format is not recognised.
+func Sniff(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("%PDF-")):
+		return TypePDF
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return TypeJPEG
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return TypePNG
+	case isHEIC(data):
+		return TypeHEIC
+	}
+	return ""
+}
+
+// isHEIC looks for an ISO BMFF ftyp box naming a HEIF brand.
+func isHEIC(data []byte) bool {
+	if len(data) < 16 || string(data[4:8]) != "ftyp" {
+		return false
+	}
+	size := int(binary.BigEndian.Uint32(data[0:4]))
+	if size < 16 || size > len(data) {
+		return false
+	}
+	if heicBrands[string(data[8:12])] {
+		return true
+	}
+	// Compatible brands follow the major brand and minor version.
+	for i := 16; i+4 <= size; i += 4 {
+		if heicBrands[string(data[i:i+4])] {
+			return true
+		}
+	}
+	return false
+}
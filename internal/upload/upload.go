@@ -0,0 +1,151 @@
+// Package upload validates user-submitted files. The type is decided by the
+// file's content rather than the client's Content-Type header, the whole
+// file is read within a size limit, and metadata is stripped from images.
+package upload
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Content types the validator can recognise.
+const (
+	TypePDF  = "application/pdf"
+	TypeJPEG = "image/jpeg"
+	TypePNG  = "image/png"
+	TypeHEIC = "image/heic"
+)
+
+// DefaultAllowed is the allowlist used when UPLOAD_ALLOWED_TYPES is unset.
+var DefaultAllowed = []string{TypePDF, TypeJPEG, TypePNG, TypeHEIC}
+
+// DefaultMaxSize is the size limit used when UPLOAD_MAX_SIZE_MB is unset.
+const DefaultMaxSize = 5 * 1024 * 1024
+
+var (
+	ErrEmpty           = errors.New("file is empty")
+	ErrTooLarge        = errors.New("file is too large")
+	ErrUnsupportedType = errors.New("file type is not allowed")
+)
+
+var typeNames = map[string]string{
+	TypePDF:  "PDF",
+	TypeJPEG: "JPEG",
+	TypePNG:  "PNG",
+	TypeHEIC: "HEIC",
+}
+
+// File is an upload that passed validation.
+type File struct {
+	Filename    string
+	ContentType string // sniffed from the content
+	Data        []byte // with image metadata removed
+}
+
+// Validator checks uploads against a size limit and a type allowlist.
+type Validator struct {
+	maxSize int64
+	allowed map[string]bool
+}
+
+func NewValidator(maxSize int64, allowed []string) *Validator {
+	v := &Validator{maxSize: maxSize, allowed: make(map[string]bool)}
+	for _, t := range allowed {
+		v.allowed[t] = true
+	}
+	return v
+}
+
+// NewFromEnv reads UPLOAD_MAX_SIZE_MB and UPLOAD_ALLOWED_TYPES (a comma
+// separated list of content types), falling back to the defaults above.
+func NewFromEnv() *Validator {
+	maxSize := int64(DefaultMaxSize)
+	if mb, err := strconv.Atoi(os.Getenv("UPLOAD_MAX_SIZE_MB")); err == nil && mb > 0 {
+		maxSize = int64(mb) * 1024 * 1024
+	}
+
+	allowed := DefaultAllowed
+	if list := os.Getenv("UPLOAD_ALLOWED_TYPES"); list != "" {
+		allowed = nil
+		for _, t := range strings.Split(list, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				allowed = append(allowed, t)
+			}
+		}
+	}
+
+	return NewValidator(maxSize, allowed)
+}
+
+// Open validates a multipart upload.
+func (v *Validator) Open(fh *multipart.FileHeader) (*File, error) {
+	if fh.Size > v.maxSize {
+		return nil, v.tooLarge()
+	}
+
+	src, err := fh.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open upload: %w", err)
+	}
+	defer src.Close()
+
+	return v.Read(fh.Filename, src)
+}
+
+// Read validates the content of r, reading at most the size limit.
+func (v *Validator) Read(filename string, r io.Reader) (*File, error) {
+	data, err := io.ReadAll(io.LimitReader(r, v.maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, ErrEmpty
+	}
+	if int64(len(data)) > v.maxSize {
+		return nil, v.tooLarge()
+	}
+
+	contentType := Sniff(data)
+	if contentType == "" || !v.allowed[contentType] {
+		return nil, fmt.Errorf("%w: only %s files are accepted", ErrUnsupportedType, v.allowedNames())
+	}
+
+	data, err = StripMetadata(contentType, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedType, err)
+	}
+
+	return &File{
+		Filename:    filepath.Base(filepath.Clean("/" + filename)),
+		ContentType: contentType,
+		Data:        data,
+	}, nil
+}
+
+func (v *Validator) tooLarge() error {
+	return fmt.Errorf("%w: the limit is %dMB", ErrTooLarge, v.maxSize/(1024*1024))
+}
+
+// allowedNames lists the allowed types for error messages, e.g. "PDF or PNG".
+func (v *Validator) allowedNames() string {
+	var names []string
+	for _, t := range DefaultAllowed {
+		if v.allowed[t] {
+			names = append(names, typeNames[t])
+		}
+	}
+	switch len(names) {
+	case 0:
+		return "no"
+	case 1:
+		return names[0]
+	default:
+		return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+	}
+}
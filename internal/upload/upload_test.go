@@ -0,0 +1,176 @@
+package upload
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func segment(marker byte, payload string) []byte {
+	b := []byte{0xFF, marker, 0, 0}
+	binary.BigEndian.PutUint16(b[2:], uint16(len(payload)+2))
+	return append(b, payload...)
+}
+
+func chunk(chunkType, data string) []byte {
+	b := make([]byte, 4, 12+len(data))
+	binary.BigEndian.PutUint32(b, uint32(len(data)))
+	b = append(b, chunkType...)
+	b = append(b, data...)
+	return append(b, 0, 0, 0, 0) // CRC is not checked
+}
+
+func boxOf(boxType string, payload ...[]byte) []byte {
+	body := bytes.Join(payload, nil)
+	b := make([]byte, 4, 8+len(body))
+	binary.BigEndian.PutUint32(b, uint32(8+len(body)))
+	b = append(b, boxType...)
+	return append(b, body...)
+}
+
+func TestSniff(t *testing.T) {
+	tests := map[string]string{
+		"%PDF-1.7\n":        TypePDF,
+		"\xFF\xD8\xFF\xE0":  TypeJPEG,
+		"\x89PNG\r\n\x1a\n": TypePNG,
+		"\x00\x00\x00\x18ftypheic\x00\x00\x00\x00mif1heic": TypeHEIC,
+		"<html>%PDF-": "",
+	}
+	for data, want := range tests {
+		if got := Sniff([]byte(data)); got != want {
+			t.Errorf("Sniff(%q) = %q; want %q", data, got, want)
+		}
+	}
+}
+
+func TestValidatorRead(t *testing.T) {
+	v := NewValidator(16, []string{TypePDF})
+
+	file, err := v.Read("../../proof.pdf", strings.NewReader("%PDF-1.4 proof"))
+	if err != nil {
+		t.Fatalf("Read() returned error: %v", err)
+	}
+	if file.ContentType != TypePDF || file.Filename != "proof.pdf" {
+		t.Errorf("unexpected file %q (%s)", file.Filename, file.ContentType)
+	}
+
+	if _, err := v.Read("big.pdf", strings.NewReader("%PDF-1.4 this is too long")); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("expected ErrTooLarge, got %v", err)
+	}
+	if _, err := v.Read("photo.pdf", strings.NewReader("\x89PNG\r\n\x1a\n")); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("expected a PNG named .pdf to be rejected, got %v", err)
+	}
+	if _, err := v.Read("empty.pdf", strings.NewReader("")); !errors.Is(err, ErrEmpty) {
+		t.Errorf("expected ErrEmpty, got %v", err)
+	}
+}
+
+func TestStripJPEG(t *testing.T) {
+	var in bytes.Buffer
+	in.Write([]byte{0xFF, 0xD8})
+	in.Write(segment(0xE0, "JFIF\x00keep"))
+	in.Write(segment(0xE1, "Exif\x00\x00GPS"))
+	in.Write(segment(0xE1, "http://ns.adobe.com/xap/1.0/\x00xmp"))
+	in.Write(segment(0xDA, "scan"))
+	in.Write([]byte{0x12, 0x34, 0xFF, 0xD9})
+
+	out, err := StripMetadata(TypeJPEG, in.Bytes())
+	if err != nil {
+		t.Fatalf("StripMetadata() returned error: %v", err)
+	}
+	if bytes.Contains(out, []byte("Exif")) || bytes.Contains(out, []byte("adobe")) {
+		t.Error("expected EXIF and XMP segments to be removed")
+	}
+	if !bytes.Contains(out, []byte("JFIF\x00keep")) || !bytes.HasSuffix(out, []byte{0x12, 0x34, 0xFF, 0xD9}) {
+		t.Error("expected other segments and scan data to be kept")
+	}
+}
+
+func TestStripPNG(t *testing.T) {
+	in := []byte("\x89PNG\r\n\x1a\n")
+	in = append(in, chunk("IHDR", "header")...)
+	in = append(in, chunk("eXIf", "GPS")...)
+	in = append(in, chunk("IDAT", "pixels")...)
+	in = append(in, chunk("IEND", "")...)
+
+	out, err := StripMetadata(TypePNG, in)
+	if err != nil {
+		t.Fatalf("StripMetadata() returned error: %v", err)
+	}
+	if bytes.Contains(out, []byte("eXIf")) {
+		t.Error("expected eXIf chunk to be removed")
+	}
+	if len(out) != len(in)-len(chunk("eXIf", "GPS")) {
+		t.Errorf("expected only the eXIf chunk to be removed, got %d bytes", len(out))
+	}
+}
+
+func TestStripHEIC(t *testing.T) {
+	ftyp := boxOf("ftyp", []byte("heic\x00\x00\x00\x00mif1heic"))
+
+	// infe version 2: item 1 is an Exif item.
+	infe := boxOf("infe", []byte{2, 0, 0, 0, 0, 1, 0, 0}, []byte("Exif"))
+	iinf := boxOf("iinf", []byte{0, 0, 0, 0, 0, 1}, infe)
+
+	exifPayload := []byte("\x00\x00\x00\x06Exif\x00\x00MM\x00*GPS")
+	// iloc version 0, 4-byte offsets and lengths, no base offset; the
+	// offset is patched once the position of mdat is known.
+	iloc := boxOf("iloc", []byte{0, 0, 0, 0, 0x44, 0x00, 0, 1, 0, 1, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, byte(len(exifPayload))})
+	meta := boxOf("meta", []byte{0, 0, 0, 0}, iinf, iloc)
+
+	in := append(append([]byte{}, ftyp...), meta...)
+	mdatOffset := len(in) + 8
+	in = append(in, boxOf("mdat", exifPayload)...)
+	offsetPos := len(ftyp) + 8 + 4 + len(iinf) + 8 + 4 + 2 + 2 + 2 + 2 + 2
+	binary.BigEndian.PutUint32(in[offsetPos:], uint32(mdatOffset))
+
+	out, err := StripMetadata(TypeHEIC, in)
+	if err != nil {
+		t.Fatalf("StripMetadata() returned error: %v", err)
+	}
+	if len(out) != len(in) {
+		t.Fatalf("expected file size to be unchanged, got %d; want %d", len(out), len(in))
+	}
+	if bytes.Contains(out, []byte("GPS")) {
+		t.Error("expected Exif item payload to be blanked")
+	}
+	if !bytes.Equal(out[:mdatOffset], in[:mdatOffset]) {
+		t.Error("expected boxes before the Exif payload to be unchanged")
+	}
+}
+
+func TestStripHEICMalformed(t *testing.T) {
+	ftyp := boxOf("ftyp", []byte("heic\x00\x00\x00\x00mif1heic"))
+	infe := boxOf("infe", []byte{2, 0, 0, 0, 0, 1, 0, 0}, []byte("Exif"))
+	iinf := boxOf("iinf", []byte{0, 0, 0, 0, 0, 1}, infe)
+
+	// iloc version 0 with 8-byte offsets and lengths: one extent of item 1
+	// at an offset near the largest int64, so offset+length overflows.
+	hugeExtent := []byte{0, 0, 0, 0, 0x88, 0x00, 0, 1, 0, 1, 0, 0, 0, 1}
+	hugeExtent = binary.BigEndian.AppendUint64(hugeExtent, 0x7FFFFFFFFFFFFFF0)
+	hugeExtent = binary.BigEndian.AppendUint64(hugeExtent, 0x20)
+	meta := boxOf("meta", []byte{0, 0, 0, 0}, iinf, boxOf("iloc", hugeExtent))
+
+	// A meta box whose 64-bit size runs far past the end of the file
+	largeSize := []byte{0, 0, 0, 1, 'm', 'e', 't', 'a'}
+	largeSize = binary.BigEndian.AppendUint64(largeSize, 0x7FFFFFFFFFFFFFFF)
+
+	tests := map[string]struct {
+		in      []byte
+		wantErr bool
+	}{
+		"extent offset overflow": {append(append([]byte{}, ftyp...), meta...), true},
+		"box size overflow":      {append(append([]byte{}, ftyp...), largeSize...), false},
+	}
+	for name, tt := range tests {
+		_, err := StripMetadata(TypeHEIC, tt.in)
+		if tt.wantErr && !errors.Is(err, errMalformed) {
+			t.Errorf("%s: expected errMalformed, got %v", name, err)
+		}
+		if !tt.wantErr && err != nil {
+			t.Errorf("%s: expected no error, got %v", name, err)
+		}
+	}
+}